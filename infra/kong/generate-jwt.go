@@ -4,21 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // Kong JWT Configuration
-const (
-	JWTKey    = "bitzap-key"
-	JWTSecret = "bitzap-secret-2025"
-)
+const JWTKey = "bitzap-key"
+
+// JWTSecret is auth.secretKey of auth-svc, read from JWT_SECRET_KEY
+var JWTSecret = os.Getenv("JWT_SECRET_KEY")
 
 // JWTClaims represents the JWT payload
 type JWTClaims struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
+	KongKey  string `json:"kong_key"` // Kong credential key, see key_claim_name
 	jwt.RegisteredClaims
 }
 
@@ -29,6 +31,7 @@ func generateJWTToken(userID string, expiresInHours int) (string, error) {
 	claims := JWTClaims{
 		UserID:   userID,
 		Username: userID,
+		KongKey:  JWTKey,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    JWTKey, // Kong key
 			Subject:   userID, // User ID
@@ -70,6 +73,10 @@ func main() {
 	fmt.Println("🔑 Kong JWT Token Generator (Go Version)")
 	fmt.Println("=" + fmt.Sprintf("%40s", ""))
 
+	if JWTSecret == "" {
+		log.Fatal("JWT_SECRET_KEY is not set, export the auth.secretKey of auth-svc first")
+	}
+
 	// Generate token
 	userID := "testuser"
 	expiresInHours := 24
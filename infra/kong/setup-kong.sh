@@ -4,6 +4,12 @@
 # This script configures Kong with services, routes, and authentication
 
 KONG_ADMIN_URL="http://localhost:8001"
+# Secret of bitzap-key credential, must match auth.secretKey of auth-svc
+if [ -z "$JWT_SECRET_KEY" ]; then
+    echo "JWT_SECRET_KEY is not set, export the auth.secretKey of auth-svc first" >&2
+    exit 1
+fi
+JWT_SECRET="$JWT_SECRET_KEY"
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 
 echo "Setting up Kong Gateway for BitZap..."
@@ -20,7 +26,13 @@ echo "Kong is ready!"
 # Method 1: Load configuration from YAML file (recommended)
 if command -v deck &> /dev/null; then
     echo "Loading configuration from kong.yml using deck..."
-    deck sync --kong-addr $KONG_ADMIN_URL --state $SCRIPT_DIR/kong.yml
+    # kong.yml keeps a placeholder for the secret, render it into a private temp file
+    STATE_FILE="$(mktemp)"
+    trap 'rm -f "$STATE_FILE"' EXIT
+    YAML_SECRET="${JWT_SECRET//\'/\'\'}"
+    KONG_STATE="$(<"$SCRIPT_DIR/kong.yml")"
+    printf '%s\n' "${KONG_STATE//__JWT_SECRET_KEY__/"$YAML_SECRET"}" > "$STATE_FILE"
+    deck sync --kong-addr $KONG_ADMIN_URL --state "$STATE_FILE"
     echo "Configuration loaded from YAML!"
 else
     echo "deck not found, using manual API calls..."
@@ -108,7 +120,7 @@ else
 
     curl -i -X POST $KONG_ADMIN_URL/consumers/bitzap-app/jwt \
       --data key=bitzap-key \
-      --data secret=bitzap-secret-2025
+      --data-urlencode "secret=$JWT_SECRET"
 
     curl -i -X POST $KONG_ADMIN_URL/consumers \
       --data username=mobile-app
@@ -125,21 +137,25 @@ else
     # JWT for shortener routes
     curl -i -X POST $KONG_ADMIN_URL/routes/create-short-url/plugins \
       --data name=jwt \
-      --data config.secret_is_base64=false
+      --data config.secret_is_base64=false \
+      --data config.key_claim_name=kong_key
 
     curl -i -X POST $KONG_ADMIN_URL/routes/list-urls/plugins \
       --data name=jwt \
-      --data config.secret_is_base64=false
+      --data config.secret_is_base64=false \
+      --data config.key_claim_name=kong_key
 
     # JWT for analytics routes
     curl -i -X POST $KONG_ADMIN_URL/routes/analytics-dashboard/plugins \
       --data name=jwt \
-      --data config.secret_is_base64=false
+      --data config.secret_is_base64=false \
+      --data config.key_claim_name=kong_key
 
     # JWT for billing routes
     curl -i -X POST $KONG_ADMIN_URL/routes/billing-routes/plugins \
       --data name=jwt \
-      --data config.secret_is_base64=false
+      --data config.secret_is_base64=false \
+      --data config.key_claim_name=kong_key
 
     echo "JWT authentication enabled!"
 
@@ -186,7 +202,7 @@ echo "- Analytics: http://localhost:8000/api/v1/analytics (JWT required)"
 echo "- Billing: http://localhost:8000/api/v1/billing (JWT required)"
 echo ""
 echo "JWT Consumers:"
-echo "- App: bitzap-key / bitzap-secret-2025"
+echo "- App: bitzap-key / auth.secretKey of auth-svc"
 echo "- Mobile: mobile-key / mobile-secret-2025"
 echo ""
 echo "Generate JWT token:"
@@ -0,0 +1,130 @@
+// Command account-purge deletes accounts whose deletion grace period is over.
+// Activity logs of deleted accounts are kept only under random pseudonym,
+// without user ID, IP address, user agent and metadata. Run it periodically,
+// e.g. daily from cron.
+//
+// Run from auth-svc directory so configs/config.yaml is found:
+//
+//	go run ./cmd/account-purge
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	repository_impl "github.com/taititans/bitzap/auth-svc/internal/domain/repository/repository_impl"
+	"github.com/taititans/bitzap/auth-svc/internal/initialize"
+	"github.com/taititans/bitzap/auth-svc/internal/logic"
+	"github.com/taititans/bitzap/auth-svc/internal/service"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+func main() {
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	cfg := config.LoadConfig()
+
+	logger := initialize.InitLogger(initialize.LoggerConfig{
+		Path:   "./log/",
+		File:   "account-purge.log",
+		Level:  cfg.Server.LogLevel,
+		Stdout: true,
+		StSkip: 1,
+	})
+	defer logger.Sync()
+	appLogger := util.NewZapLogger(logger)
+
+	db := initialize.InitDatabase(initialize.DatabaseConfig{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		User:            cfg.Database.User,
+		Password:        cfg.Database.Password,
+		DBName:          cfg.Database.DBName,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.Database.ConnMaxLifetime) * time.Hour,
+	})
+	defer initialize.CloseDatabase(db)
+
+	redisClient := initialize.InitRedis(initialize.RedisConfig{
+		Address:      cfg.Redis.Default.Address,
+		Password:     cfg.Redis.Default.Password,
+		DB:           cfg.Redis.Default.DB,
+		DialTimeout:  cfg.Redis.Default.DialTimeout,
+		ReadTimeout:  cfg.Redis.Default.ReadTimeout,
+		WriteTimeout: cfg.Redis.Default.WriteTimeout,
+		MaxActive:    cfg.Redis.Default.MaxActive,
+	})
+	defer initialize.CloseRedis(redisClient)
+
+	signingKeyLogic, err := logic.NewSigningKeyLogic(cfg.Auth, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+
+	userRepo := repository_impl.NewUserRepository(db)
+	userActivityLogRepo := repository_impl.NewUserActivityLogRepository(db)
+	tenantRepo := repository_impl.NewTenantRepository(db)
+	tenantMemberRepo := repository_impl.NewTenantMemberRepository(db)
+	redisRepo := repository_impl.NewRedisRepository(redisClient, appLogger)
+
+	kongLogic := logic.NewKongLogic(
+		cfg.Kong,
+		repository_impl.NewKongAdminClient(cfg.Kong, appLogger),
+		signingKeyLogic,
+		userRepo,
+		tenantRepo,
+		appLogger,
+	)
+	permissionLogic := logic.NewPermissionLogic(
+		cfg.Auth.Permission,
+		repository_impl.NewRoleRepository(db),
+		repository_impl.NewUserRoleRepository(db),
+		repository_impl.NewUserPermissionRepository(db),
+		redisRepo,
+		appLogger,
+	)
+	tokenLogic := logic.NewTokenLogic(cfg.Auth, signingKeyLogic, kongLogic, tenantMemberRepo, redisRepo, appLogger)
+	tenantLogic := logic.NewTenantLogic(
+		cfg.Auth.Tenant,
+		tenantRepo,
+		tenantMemberRepo,
+		userRepo,
+		userActivityLogRepo,
+		tokenLogic,
+		kongLogic,
+		appLogger,
+	)
+	privacyLogic := logic.NewPrivacyLogic(
+		cfg.Auth.Privacy,
+		userRepo,
+		userActivityLogRepo,
+		repository_impl.NewAPIKeyRepository(db),
+		redisRepo,
+		service.NewEmailService(cfg.Email, redisClient, appLogger),
+		tokenLogic,
+		permissionLogic,
+		tenantLogic,
+		nil, // re-authentication is only used by deletion requests
+		kongLogic,
+		appLogger,
+	)
+
+	result, err := privacyLogic.PurgeDue(context.Background())
+	if err != nil {
+		log.Fatalf("Account purge failed: %v", err)
+	}
+
+	log.Printf("Account purge: purged=%d failed=%d", result.Purged, result.Failed)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
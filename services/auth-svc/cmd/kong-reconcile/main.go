@@ -0,0 +1,83 @@
+// Command kong-reconcile repairs drift between users, tenants and Kong consumers.
+// It provisions consumers and JWT credentials of active users and tenants and
+// deletes consumers tagged by auth-svc that no longer match either.
+//
+// Run from auth-svc directory so configs/config.yaml is found:
+//
+//	go run ./cmd/kong-reconcile
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	repository_impl "github.com/taititans/bitzap/auth-svc/internal/domain/repository/repository_impl"
+	"github.com/taititans/bitzap/auth-svc/internal/initialize"
+	"github.com/taititans/bitzap/auth-svc/internal/logic"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+func main() {
+	// Load environment variables from .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	cfg := config.LoadConfig()
+	if !cfg.Kong.Enabled {
+		log.Println("Kong provisioning is disabled, nothing to reconcile")
+		return
+	}
+
+	logger := initialize.InitLogger(initialize.LoggerConfig{
+		Path:   "./log/",
+		File:   "kong-reconcile.log",
+		Level:  cfg.Server.LogLevel,
+		Stdout: true,
+		StSkip: 1,
+	})
+	defer logger.Sync()
+	appLogger := util.NewZapLogger(logger)
+
+	db := initialize.InitDatabase(initialize.DatabaseConfig{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		User:            cfg.Database.User,
+		Password:        cfg.Database.Password,
+		DBName:          cfg.Database.DBName,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.Database.ConnMaxLifetime) * time.Hour,
+	})
+	defer initialize.CloseDatabase(db)
+
+	signingKeyLogic, err := logic.NewSigningKeyLogic(cfg.Auth, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+
+	kongLogic := logic.NewKongLogic(
+		cfg.Kong,
+		repository_impl.NewKongAdminClient(cfg.Kong, appLogger),
+		signingKeyLogic,
+		repository_impl.NewUserRepository(db),
+		repository_impl.NewTenantRepository(db),
+		appLogger,
+	)
+
+	result, err := kongLogic.Reconcile(context.Background())
+	if err != nil {
+		log.Fatalf("Kong reconciliation failed: %v", err)
+	}
+
+	log.Printf("Kong reconciliation: provisioned=%d deprovisioned=%d orphans=%d failed=%d",
+		result.Provisioned, result.Deprovisioned, result.Orphans, result.Failed)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
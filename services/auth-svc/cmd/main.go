@@ -27,8 +27,11 @@ import (
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 	"github.com/taititans/bitzap/auth-svc/internal/config"
 	"github.com/taititans/bitzap/auth-svc/internal/controller/http"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/admin"
 	"github.com/taititans/bitzap/auth-svc/internal/controller/http/auth"
 	"github.com/taititans/bitzap/auth-svc/internal/controller/http/email"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/tenant"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/wellknown"
 	repository_impl "github.com/taititans/bitzap/auth-svc/internal/domain/repository/repository_impl"
 	"github.com/taititans/bitzap/auth-svc/internal/initialize"
 	"github.com/taititans/bitzap/auth-svc/internal/logic"
@@ -98,9 +101,17 @@ func main() {
 
 	// Initialize repositories
 	userRepo := repository_impl.NewUserRepository(db)
+	roleRepo := repository_impl.NewRoleRepository(db)
 	userRoleRepo := repository_impl.NewUserRoleRepository(db)
 	userPermissionRepo := repository_impl.NewUserPermissionRepository(db)
 	userActivityLogRepo := repository_impl.NewUserActivityLogRepository(db)
+	userRecoveryCodeRepo := repository_impl.NewUserRecoveryCodeRepository(db)
+	userIdentityRepo := repository_impl.NewUserIdentityRepository(db)
+	apiKeyRepo := repository_impl.NewAPIKeyRepository(db)
+	userDeviceRepo := repository_impl.NewUserDeviceRepository(db)
+	tenantRepo := repository_impl.NewTenantRepository(db)
+	tenantMemberRepo := repository_impl.NewTenantMemberRepository(db)
+	tenantInvitationRepo := repository_impl.NewTenantInvitationRepository(db)
 
 	// Redis configuration from environment
 	redisConfig := initialize.RedisConfig{
@@ -120,15 +131,57 @@ func main() {
 	// Initialize email service with config from environment
 	emailService := service.NewEmailService(cfg.Email, redisClient, appLogger)
 
+	// Initialize Redis repository
+	redisRepo := repository_impl.NewRedisRepository(redisClient, appLogger)
+
+	// Initialize SMS provider
+	smsProvider := repository_impl.NewSMSProvider(cfg.SMS, appLogger)
+
+	// Initialize OAuth providers
+	oauthProviders := repository_impl.NewOAuthProviders(cfg.Auth.OAuth, appLogger)
+
+	// Initialize Kong Admin API client
+	kongAdminClient := repository_impl.NewKongAdminClient(cfg.Kong, appLogger)
+
 	// Initialize business logic
-	authLogic := logic.NewAuthLogic(userRepo, userRoleRepo, userPermissionRepo, userActivityLogRepo, emailService, appLogger)
+	signingKeyLogic, err := logic.NewSigningKeyLogic(cfg.Auth, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+	kongLogic := logic.NewKongLogic(cfg.Kong, kongAdminClient, signingKeyLogic, userRepo, tenantRepo, appLogger)
+	permissionLogic := logic.NewPermissionLogic(cfg.Auth.Permission, roleRepo, userRoleRepo, userPermissionRepo, redisRepo, appLogger)
+	tokenLogic := logic.NewTokenLogic(cfg.Auth, signingKeyLogic, kongLogic, tenantMemberRepo, redisRepo, appLogger)
+	loginGuardLogic := logic.NewLoginGuardLogic(cfg.Auth.LoginProtection, redisRepo, userActivityLogRepo, appLogger)
+	passwordPolicy := logic.NewPasswordPolicy(cfg.Auth.PasswordPolicy)
+	usernamePolicy := logic.NewUsernamePolicy(cfg.Auth.UsernamePolicy)
+	twoFactorLogic := logic.NewTwoFactorLogic(cfg.Auth.TwoFactor, userRepo, userRecoveryCodeRepo, userActivityLogRepo, redisRepo, loginGuardLogic, appLogger)
+	otpLogic := logic.NewOTPLogic(cfg.Auth.OTP, userRepo, redisRepo, emailService, smsProvider, appLogger)
+	reauthLogic := logic.NewReauthLogic(cfg.Auth.Reauth, tokenLogic, otpLogic, appLogger)
+	oauthLogic := logic.NewOAuthLogic(cfg.Auth.OAuth, oauthProviders, userRepo, userIdentityRepo, userActivityLogRepo, redisRepo, usernamePolicy, kongLogic, permissionLogic, appLogger)
+	deviceLogic := logic.NewDeviceLogic(cfg.Auth.DeviceAlert, userRepo, userDeviceRepo, userActivityLogRepo, redisRepo, emailService, tokenLogic, kongLogic, appLogger)
+	emailVerificationLogic := logic.NewEmailVerificationLogic(cfg.Auth.EmailVerification, userRepo, userActivityLogRepo, redisRepo, emailService, appLogger)
+	tenantLogic := logic.NewTenantLogic(cfg.Auth.Tenant, tenantRepo, tenantMemberRepo, userRepo, userActivityLogRepo, tokenLogic, kongLogic, appLogger)
+	invitationLogic := logic.NewInvitationLogic(cfg.Auth.Tenant, tenantLogic, tenantRepo, tenantMemberRepo, tenantInvitationRepo, userRepo, userActivityLogRepo, emailService, appLogger)
+	authLogic := logic.NewAuthLogic(userRepo, userRoleRepo, userPermissionRepo, userActivityLogRepo, emailService, tokenLogic, loginGuardLogic, passwordPolicy, usernamePolicy, twoFactorLogic, otpLogic, oauthLogic, kongLogic, permissionLogic, deviceLogic, emailVerificationLogic, invitationLogic, reauthLogic, appLogger)
+
+	apiKeyLogic := logic.NewAPIKeyLogic(cfg.Auth.APIKey, apiKeyRepo, userRepo, userActivityLogRepo, permissionLogic, tenantLogic, appLogger)
+	activityLogic := logic.NewActivityLogic(userActivityLogRepo, appLogger)
+	sessionLogic := logic.NewSessionLogic(tokenLogic, userActivityLogRepo, appLogger)
+	privacyLogic := logic.NewPrivacyLogic(cfg.Auth.Privacy, userRepo, userActivityLogRepo, apiKeyRepo, redisRepo, emailService, tokenLogic, permissionLogic, tenantLogic, reauthLogic, kongLogic, appLogger)
+	adminUserLogic := logic.NewAdminUserLogic(userRepo, userActivityLogRepo, tokenLogic, permissionLogic, kongLogic, appLogger)
 
 	// Initialize services
-	authService := service.NewAuthService(authLogic)
+	authService := service.NewAuthService(authLogic, twoFactorLogic, apiKeyLogic, activityLogic, deviceLogic, sessionLogic, privacyLogic)
+	wellKnownService := service.NewWellKnownService(signingKeyLogic)
+	adminService := service.NewAdminService(adminUserLogic, activityLogic)
+	tenantService := service.NewTenantService(tenantLogic, invitationLogic, apiKeyLogic)
 
 	// Initialize controllers
 	authController := auth.NewAuthController(authService, appLogger)
 	emailController := email.NewEmailController(emailService, appLogger)
+	wellKnownController := wellknown.NewWellKnownController(wellKnownService, appLogger)
+	adminController := admin.NewAdminController(adminService, appLogger)
+	tenantController := tenant.NewTenantController(tenantService, appLogger)
 
 	// Fiber app
 	app := fiber.New()
@@ -141,8 +194,14 @@ func main() {
 	// Swagger route
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	// Auth middleware
+	authMiddleware := middleware.AuthMiddleware(tokenLogic, appLogger)
+	accessControl := middleware.NewAccessControl(permissionLogic, appLogger)
+	tenantContext := middleware.TenantContextMiddleware(tenantLogic, appLogger)
+	internalMiddleware := middleware.InternalAuth(cfg.Auth.InternalToken, appLogger)
+
 	// Setup auth routes
-	http.SetupAuthRoutes(app, authController, emailController)
+	http.SetupAuthRoutes(app, authController, emailController, adminController, tenantController, wellKnownController, authMiddleware, accessControl, tenantContext, internalMiddleware)
 
 	// Ping route
 	app.Get("/ping", func(c *fiber.Ctx) error {
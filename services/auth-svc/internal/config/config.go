@@ -4,6 +4,8 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,6 +18,8 @@ type Config struct {
 	Redis    RedisConfig    `yaml:"redis"`
 	Auth     AuthConfig     `yaml:"auth"`
 	Email    EmailConfig    `yaml:"email"`
+	SMS      SMSConfig      `yaml:"sms"`
+	Kong     KongConfig     `yaml:"kong"`
 }
 
 // ServerConfig holds server configuration
@@ -93,8 +97,189 @@ type RedisDefaultConfig struct {
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
 	SecretKey                string `yaml:"secretKey"`
+	Issuer                   string `yaml:"issuer"`
+	InternalToken            string `yaml:"internalToken"`
 	AccessTokenExpireMinute  int    `yaml:"accessTokenExpireMinute"`
 	RefreshTokenExpireMinute int    `yaml:"refreshTokenExpireMinute"`
+
+	LoginProtection LoginProtectionConfig `yaml:"loginProtection"`
+	PasswordPolicy  PasswordPolicyConfig  `yaml:"passwordPolicy"`
+	UsernamePolicy  UsernamePolicyConfig  `yaml:"usernamePolicy"`
+	TwoFactor       TwoFactorConfig       `yaml:"twoFactor"`
+	OTP             OTPConfig             `yaml:"otp"`
+	OAuth           OAuthConfig           `yaml:"oauth"`
+	Signing         SigningConfig         `yaml:"signing"`
+	APIKey          APIKeyConfig          `yaml:"apiKey"`
+	Permission      PermissionConfig      `yaml:"permission"`
+	DeviceAlert     DeviceAlertConfig     `yaml:"deviceAlert"`
+	Privacy         PrivacyConfig         `yaml:"privacy"`
+	Reauth          ReauthConfig          `yaml:"reauth"`
+
+	EmailVerification EmailVerificationConfig `yaml:"emailVerification"`
+	Tenant            TenantConfig            `yaml:"tenant"`
+}
+
+// LoginProtectionConfig holds brute-force protection configuration for login
+type LoginProtectionConfig struct {
+	WindowMinute          int   `yaml:"windowMinute"`
+	MaxAttemptsPerEmail   int   `yaml:"maxAttemptsPerEmail"`
+	MaxAttemptsPerIP      int   `yaml:"maxAttemptsPerIP"`
+	BlockMinutes          []int `yaml:"blockMinutes"`
+	BlockLevelResetMinute int   `yaml:"blockLevelResetMinute"`
+}
+
+// PasswordPolicyConfig holds password strength policy configuration
+type PasswordPolicyConfig struct {
+	MinLength         int  `yaml:"minLength"`
+	MaxLength         int  `yaml:"maxLength"`
+	RequireUpper      bool `yaml:"requireUpper"`
+	RequireLower      bool `yaml:"requireLower"`
+	RequireNumber     bool `yaml:"requireNumber"`
+	RequireSpecial    bool `yaml:"requireSpecial"`
+	RejectAccountInfo bool `yaml:"rejectAccountInfo"`
+}
+
+// UsernamePolicyConfig holds username format policy configuration
+type UsernamePolicyConfig struct {
+	MinLength     int      `yaml:"minLength"`
+	MaxLength     int      `yaml:"maxLength"`
+	ReservedNames []string `yaml:"reservedNames"`
+}
+
+// TwoFactorConfig holds TOTP two-factor authentication configuration
+type TwoFactorConfig struct {
+	Issuer                string `yaml:"issuer"`
+	Digits                int    `yaml:"digits"`
+	PeriodSecond          int    `yaml:"periodSecond"`
+	Skew                  int    `yaml:"skew"`
+	EnrollExpireMinute    int    `yaml:"enrollExpireMinute"`
+	ChallengeExpireMinute int    `yaml:"challengeExpireMinute"`
+	MaxAttempts           int    `yaml:"maxAttempts"`
+	RecoveryCodeCount     int    `yaml:"recoveryCodeCount"`
+}
+
+// OTPConfig holds one-time passcode login configuration
+type OTPConfig struct {
+	Length               int    `yaml:"length"`
+	ExpireMinute         int    `yaml:"expireMinute"`
+	MaxAttempts          int    `yaml:"maxAttempts"`
+	ResendIntervalSecond int    `yaml:"resendIntervalSecond"`
+	MaxSendsPerHour      int    `yaml:"maxSendsPerHour"`
+	DefaultCountryCode   string `yaml:"defaultCountryCode"`
+}
+
+const (
+	// OAuthProviderOIDC is generic OpenID Connect provider type
+	OAuthProviderOIDC = "oidc"
+	// OAuthProviderGitHub is GitHub OAuth2 provider type
+	OAuthProviderGitHub = "github"
+)
+
+// OAuthConfig holds OAuth2 / OIDC social login configuration
+type OAuthConfig struct {
+	RedirectBaseURL   string                `yaml:"redirectBaseURL"`
+	StateExpireMinute int                   `yaml:"stateExpireMinute"`
+	Providers         []OAuthProviderConfig `yaml:"providers"`
+}
+
+// OAuthProviderConfig holds configuration of one OAuth2 / OIDC provider.
+// Endpoints of oidc providers are discovered from issuer when not set,
+// issuer is required to validate their ID tokens.
+type OAuthProviderConfig struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	Enabled      bool     `yaml:"enabled"`
+	ClientID     string   `yaml:"clientID"`
+	ClientSecret string   `yaml:"clientSecret"`
+	Issuer       string   `yaml:"issuer"`
+	AuthURL      string   `yaml:"authURL"`
+	TokenURL     string   `yaml:"tokenURL"`
+	UserInfoURL  string   `yaml:"userInfoURL"`
+	JWKSURL      string   `yaml:"jwksURL"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// SigningConfig holds asymmetric token signing configuration.
+// Tokens are signed with HS256 and secretKey when no keys are configured.
+type SigningConfig struct {
+	IssuerURL         string             `yaml:"issuerURL"`
+	GenerateIfMissing bool               `yaml:"generateIfMissing"`
+	AcceptHS256       bool               `yaml:"acceptHS256"`
+	JWKSCacheSecond   int                `yaml:"jwksCacheSecond"`
+	Keys              []SigningKeyConfig `yaml:"keys"`
+}
+
+// SigningKeyConfig holds one signing key. The newest activated key signs tokens,
+// every key is published in JWKS until it retires so rotations overlap.
+type SigningKeyConfig struct {
+	KeyID          string    `yaml:"kid"`
+	Algorithm      string    `yaml:"algorithm"`
+	PrivateKeyFile string    `yaml:"privateKeyFile"`
+	PrivateKey     string    `yaml:"privateKey"`
+	ActivateAt     time.Time `yaml:"activateAt"`
+	RetireAt       time.Time `yaml:"retireAt"`
+}
+
+// APIKeyConfig holds API key configuration
+type APIKeyConfig struct {
+	Prefix                 string   `yaml:"prefix"`
+	MaxPerUser             int      `yaml:"maxPerUser"`
+	MaxPerTenant           int      `yaml:"maxPerTenant"`
+	MaxExpireDays          int      `yaml:"maxExpireDays"`
+	RotateGraceMinute      int      `yaml:"rotateGraceMinute"`
+	LastUsedIntervalSecond int      `yaml:"lastUsedIntervalSecond"`
+	AllowedScopes          []string `yaml:"allowedScopes"`
+}
+
+// PermissionConfig holds role based access control configuration
+type PermissionConfig struct {
+	DefaultRole       string `yaml:"defaultRole"`
+	CacheExpireSecond int    `yaml:"cacheExpireSecond"`
+}
+
+// DeviceAlertConfig holds new device login alert configuration
+type DeviceAlertConfig struct {
+	Enabled          bool `yaml:"enabled"`
+	IPv4PrefixBits   int  `yaml:"ipv4PrefixBits"`
+	IPv6PrefixBits   int  `yaml:"ipv6PrefixBits"`
+	ReportExpireHour int  `yaml:"reportExpireHour"`
+}
+
+// PrivacyConfig holds data export and account deletion configuration.
+// Exports larger than ExportMaxSizeKB are refused, 0 means no limit.
+type PrivacyConfig struct {
+	ExportLinkExpireHour int `yaml:"exportLinkExpireHour"`
+	ExportCooldownMinute int `yaml:"exportCooldownMinute"`
+	ExportMaxSizeKB      int `yaml:"exportMaxSizeKB"`
+	DeletionGraceDay     int `yaml:"deletionGraceDay"`
+	PurgeBatchSize       int `yaml:"purgeBatchSize"`
+}
+
+// ReauthConfig holds re-authentication of sensitive actions. Sessions signed in
+// within FreshLoginMinute confirm them without password or emailed code.
+type ReauthConfig struct {
+	FreshLoginMinute int `yaml:"freshLoginMinute"`
+}
+
+// TenantConfig holds tenant configuration. PlanSeats limits members plus pending
+// invitations per tenant plan, plans missing or set to 0 are unlimited. Requests to
+// <slug>.BaseDomain or custom domain of tenant resolve to that tenant, disabled when
+// BaseDomain is empty.
+type TenantConfig struct {
+	MaxOwnedPerUser      int            `yaml:"maxOwnedPerUser"`
+	InvitationExpireHour int            `yaml:"invitationExpireHour"`
+	PlanSeats            map[string]int `yaml:"planSeats"`
+	BaseDomain           string         `yaml:"baseDomain"`
+}
+
+// EmailVerificationConfig holds policy for unverified accounts and resend throttling
+// of verification emails. Policy is one of block, limited or grace, empty allows login.
+type EmailVerificationConfig struct {
+	Policy               string `yaml:"policy"`
+	GraceDay             int    `yaml:"graceDay"`
+	ResendIntervalSecond int    `yaml:"resendIntervalSecond"`
+	MaxSendsPerHour      int    `yaml:"maxSendsPerHour"`
+	MaxSendsPerHourIP    int    `yaml:"maxSendsPerHourIP"`
 }
 
 // LoadConfig loads configuration from YAML file
@@ -121,6 +306,23 @@ func LoadConfig() *Config {
 	// Load sensitive data from environment variables
 	config.Email.MailjetAPIKey = getEnv("MAILJET_API_KEY", config.Email.MailjetAPIKey)
 	config.Email.MailjetSecretKey = getEnv("MAILJET_SECRET_KEY", config.Email.MailjetSecretKey)
+	config.SMS.Provider = getEnv("SMS_PROVIDER", config.SMS.Provider)
+	config.Auth.SecretKey = getEnv("JWT_SECRET_KEY", config.Auth.SecretKey)
+	config.Auth.InternalToken = getEnv("INTERNAL_API_TOKEN", config.Auth.InternalToken)
+	config.Kong.AdminURL = getEnv("KONG_ADMIN_URL", config.Kong.AdminURL)
+	config.Kong.AdminToken = getEnv("KONG_ADMIN_TOKEN", config.Kong.AdminToken)
+	config.Auth.Tenant.BaseDomain = getEnv("TENANT_BASE_DOMAIN", config.Auth.Tenant.BaseDomain)
+	for i := range config.Auth.OAuth.Providers {
+		provider := &config.Auth.OAuth.Providers[i]
+		envPrefix := "OAUTH_" + strings.ToUpper(provider.Name)
+		provider.ClientID = getEnv(envPrefix+"_CLIENT_ID", provider.ClientID)
+		provider.ClientSecret = getEnv(envPrefix+"_CLIENT_SECRET", provider.ClientSecret)
+	}
+	for i := range config.Auth.Signing.Keys {
+		key := &config.Auth.Signing.Keys[i]
+		envName := "SIGNING_KEY_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key.KeyID))
+		key.PrivateKey = getEnv(envName, key.PrivateKey)
+	}
 
 	return &config
 }
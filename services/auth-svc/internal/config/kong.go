@@ -0,0 +1,26 @@
+package config
+
+// KongConfig holds Kong Admin API configuration for consumer provisioning
+type KongConfig struct {
+	Enabled              bool   `yaml:"enabled" env:"KONG_ENABLED"`
+	AdminURL             string `yaml:"admin_url" env:"KONG_ADMIN_URL"`
+	AdminToken           string `yaml:"admin_token" env:"KONG_ADMIN_TOKEN"`
+	ConsumerPrefix       string `yaml:"consumer_prefix"`
+	TenantConsumerPrefix string `yaml:"tenant_consumer_prefix"`
+	StaticKey            string `yaml:"static_key"`
+	Tag                  string `yaml:"tag"`
+	TimeoutSecond        int    `yaml:"timeout_second"`
+}
+
+// DefaultKongConfig returns default Kong configuration
+func DefaultKongConfig() KongConfig {
+	return KongConfig{
+		Enabled:              false,
+		AdminURL:             "http://localhost:8001",
+		ConsumerPrefix:       "user-",
+		TenantConsumerPrefix: "tenant-",
+		StaticKey:            "bitzap-key",
+		Tag:                  "auth-svc",
+		TimeoutSecond:        5,
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+const (
+	// SMSProviderConsole writes SMS messages to application log
+	SMSProviderConsole = "console"
+	// SMSProviderFile appends SMS messages to a local file
+	SMSProviderFile = "file"
+)
+
+// SMSConfig holds SMS provider configuration
+type SMSConfig struct {
+	Provider string `yaml:"provider" env:"SMS_PROVIDER"`
+	Sender   string `yaml:"sender" env:"SMS_SENDER"`
+	FilePath string `yaml:"file_path" env:"SMS_FILE_PATH"`
+}
+
+// DefaultSMSConfig returns default SMS configuration
+func DefaultSMSConfig() SMSConfig {
+	return SMSConfig{
+		Provider: SMSProviderConsole,
+		Sender:   "Bitzap",
+		FilePath: "logs/sms.log",
+	}
+}
@@ -9,6 +9,7 @@ var (
 	RedisKeyNotFound       = fmt.Errorf("Can not find key from Redis")
 	ErrorUserHaveBeenLock  = fmt.Errorf("User have been lock")
 	ErrorUserHaveBeenBlock = fmt.Errorf("User have been block")
+	ErrorRefreshTokenReuse = fmt.Errorf("Refresh token have been reused")
 )
 
 var (
@@ -30,6 +31,59 @@ var (
 	CodeCompanyExisted      = customCode{code: 115, message: "Company already exists", detail: nil, httpStatus: http.StatusOK}
 	CodeInvalidPhoneNumber  = customCode{code: 116, message: "Invalid phone number", detail: nil, httpStatus: http.StatusOK}
 	CodeOnlyOneCompany      = customCode{code: 117, message: "Only one company is allowed", detail: nil, httpStatus: http.StatusOK}
+	CodePassTooShort        = customCode{code: 118, message: "Password is too short", detail: nil, httpStatus: http.StatusOK}
+	CodePassTooLong         = customCode{code: 119, message: "Password is too long", detail: nil, httpStatus: http.StatusOK}
+	CodePassContainAccount  = customCode{code: 120, message: "Password shouldn't contain email or username", detail: nil, httpStatus: http.StatusOK}
+	CodeAccountReserved     = customCode{code: 121, message: "Account name is reserved", detail: nil, httpStatus: http.StatusOK}
+	CodeAccountLength       = customCode{code: 122, message: "Account length is invalid", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorRequired   = customCode{code: 123, message: "Two-factor authentication required", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorInvalid    = customCode{code: 124, message: "Invalid two-factor code", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorEnabled    = customCode{code: 125, message: "Two-factor authentication already enabled", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorDisabled   = customCode{code: 126, message: "Two-factor authentication not enabled", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorNoEnroll   = customCode{code: 127, message: "Two-factor enrollment not found or expired", detail: nil, httpStatus: http.StatusOK}
+	CodeTwoFactorChallenge  = customCode{code: 128, message: "Two-factor challenge is invalid or expired", detail: nil, httpStatus: http.StatusUnauthorized}
+	CodeOTPInvalid          = customCode{code: 129, message: "Invalid or expired OTP code", detail: nil, httpStatus: http.StatusUnauthorized}
+	CodeOTPResendTooSoon    = customCode{code: 130, message: "OTP was sent recently, please wait before resending", detail: nil, httpStatus: http.StatusTooManyRequests}
+	CodeOTPSendLimit        = customCode{code: 131, message: "OTP send limit reached, please try again later", detail: nil, httpStatus: http.StatusTooManyRequests}
+	CodeOAuthProvider       = customCode{code: 132, message: "OAuth provider not supported", detail: nil, httpStatus: http.StatusNotFound}
+	CodeOAuthState          = customCode{code: 133, message: "OAuth state is invalid or expired", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeOAuthExchange       = customCode{code: 134, message: "OAuth provider login failed", detail: nil, httpStatus: http.StatusBadGateway}
+	CodeOAuthUnverified     = customCode{code: 135, message: "Email of OAuth account is not verified", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeOAuthLinkBlocked    = customCode{code: 136, message: "Account with this email is not verified, login with password and verify email first", detail: nil, httpStatus: http.StatusConflict}
+	CodeAPIKeyNotFound      = customCode{code: 137, message: "API key not found", detail: nil, httpStatus: http.StatusNotFound}
+	CodeAPIKeyInvalid       = customCode{code: 138, message: "API key is invalid, expired or revoked", detail: nil, httpStatus: http.StatusUnauthorized}
+	CodeAPIKeyScope         = customCode{code: 139, message: "API key scope is not allowed", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeAPIKeyLimit         = customCode{code: 140, message: "API key limit reached", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeAPIKeyForbidden     = customCode{code: 141, message: "API key doesn't have required scope", detail: nil, httpStatus: http.StatusForbidden}
+	CodeRoleRequired        = customCode{code: 142, message: "User doesn't have required role", detail: nil, httpStatus: http.StatusForbidden}
+	CodePermissionDenied    = customCode{code: 143, message: "User doesn't have required permission", detail: nil, httpStatus: http.StatusForbidden}
+	CodeDeviceReportInvalid = customCode{code: 144, message: "Security link is invalid or expired", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeSessionNotFound     = customCode{code: 145, message: "Session not found", detail: nil, httpStatus: http.StatusNotFound}
+	CodeExportTooSoon       = customCode{code: 146, message: "Data export was requested recently, please try again later", detail: nil, httpStatus: http.StatusTooManyRequests}
+	CodeExportLinkInvalid   = customCode{code: 147, message: "Download link is invalid or expired", detail: nil, httpStatus: http.StatusNotFound}
+	CodeDeletionScheduled   = customCode{code: 148, message: "Account deletion is already scheduled", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeDeletionNotPending  = customCode{code: 149, message: "Account deletion is not scheduled", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeInvalidEmail        = customCode{code: 150, message: "Invalid email address", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeEmailUnchanged      = customCode{code: 151, message: "New email is the same as current email", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeEmailChangeInvalid  = customCode{code: 152, message: "Email change link is invalid or expired", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeVerifyResendSoon    = customCode{code: 153, message: "Verification email was sent recently, please wait before resending", detail: nil, httpStatus: http.StatusTooManyRequests}
+	CodeVerifyResendLimit   = customCode{code: 154, message: "Verification email send limit reached, please try again later", detail: nil, httpStatus: http.StatusTooManyRequests}
+	CodeEmailNotVerified    = customCode{code: 155, message: "Email must be verified to access this resource", detail: nil, httpStatus: http.StatusForbidden}
+	CodeTenantSlugInvalid   = customCode{code: 156, message: "Tenant slug must be 3-63 lowercase letters, digits or hyphens", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeTenantRoleInvalid   = customCode{code: 157, message: "Invalid tenant role", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeTenantForbidden     = customCode{code: 158, message: "Tenant role doesn't allow this action", detail: nil, httpStatus: http.StatusForbidden}
+	CodeTenantLastOwner     = customCode{code: 159, message: "Tenant must keep at least one owner", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeTenantLimit         = customCode{code: 160, message: "Tenant limit reached", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeTenantSeatLimit     = customCode{code: 161, message: "Tenant seat limit of plan reached", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeInvitationInvalid   = customCode{code: 162, message: "Invitation is invalid or expired", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeInvitationNotFound  = customCode{code: 163, message: "Invitation not found", detail: nil, httpStatus: http.StatusNotFound}
+	CodeInvitationEmail     = customCode{code: 164, message: "Invitation was sent to another email", detail: nil, httpStatus: http.StatusForbidden}
+	CodeInvitationPending   = customCode{code: 165, message: "Invitation to this email is already pending", detail: nil, httpStatus: http.StatusConflict}
+	CodeAlreadyTenantMember = customCode{code: 166, message: "User is already member of tenant", detail: nil, httpStatus: http.StatusConflict}
+	CodeAPIKeyNotGranted    = customCode{code: 167, message: "API key scope isn't granted to key owner", detail: nil, httpStatus: http.StatusForbidden}
+	CodePhoneVerified       = customCode{code: 168, message: "Phone number already verified", detail: nil, httpStatus: http.StatusBadRequest}
+	CodeReauthRequired      = customCode{code: 169, message: "Confirm with password, code sent by email or sign in again", detail: nil, httpStatus: http.StatusForbidden}
+	CodeExportTooLarge      = customCode{code: 170, message: "Data export is too large, please contact support", detail: nil, httpStatus: http.StatusRequestEntityTooLarge}
 
 	CodeInvalidToken              = customCode{code: 201, message: "Invalid token", detail: nil, httpStatus: http.StatusUnauthorized}
 	CodeTokenExpired              = customCode{code: 202, message: "Token expired", detail: nil, httpStatus: http.StatusUnauthorized}
@@ -83,6 +137,26 @@ var (
 	CodeCompanyDomainAlreadyExists = customCode{code: 1000, message: "Company domain already exists", detail: nil, httpStatus: http.StatusOK}
 )
 
+// PasswordPolicyCodes lists codes returned when password violates policy
+var PasswordPolicyCodes = []customCode{
+	CodePassTooShort,
+	CodePassTooLong,
+	CodePassMissUpperCase,
+	CodePassMissLowerCase,
+	CodePassMissNumber,
+	CodePassMissSpecial,
+	CodePassContainAccount,
+}
+
+// UsernamePolicyCodes lists codes returned when username violates format rules
+var UsernamePolicyCodes = []customCode{
+	CodeAccountLength,
+	CodeWhiteSpaceInAccount,
+	CodeAccountHaveSpecial,
+	CodeAccountBeginNumber,
+	CodeAccountReserved,
+}
+
 type customCode struct {
 	code       int
 	message    string
@@ -120,3 +194,16 @@ func (c customCode) String() string {
 func (c customCode) HttpStatus() int {
 	return c.httpStatus
 }
+
+// CodeFromError finds code whose message matches error message
+func CodeFromError(err error, codes ...customCode) (customCode, bool) {
+	if err == nil {
+		return customCode{}, false
+	}
+	for _, c := range codes {
+		if c.message == err.Error() {
+			return c, true
+		}
+	}
+	return customCode{}, false
+}
@@ -6,11 +6,35 @@ import (
 )
 
 var (
-	RedisKeyRefreshToken    = RedisKey{PrefixKey: "rf_token"}
-	RedisKeyRolePermission  = RedisKey{PrefixKey: "role_perm_1"}
-	RedisKeyUserCompanyRole = RedisKey{PrefixKey: "usr_comp_role_1"}
-	RedisKeyLoginRateLimit  = RedisKey{PrefixKey: "rate_limit_login"}
-	RedisKeyBlockLogin      = RedisKey{PrefixKey: "block_login"}
+	RedisKeyRefreshToken       = RedisKey{PrefixKey: "rf_token"}
+	RedisKeyRefreshFamily      = RedisKey{PrefixKey: "rf_token_family"}
+	RedisKeyRefreshUsed        = RedisKey{PrefixKey: "rf_token_used"}
+	RedisKeyRevokedFamily      = RedisKey{PrefixKey: "rf_token_family_revoked"}
+	RedisKeyUserFamilies       = RedisKey{PrefixKey: "rf_token_user_families"}
+	RedisKeySession            = RedisKey{PrefixKey: "rf_token_session"}
+	RedisKeyTokenDenylist      = RedisKey{PrefixKey: "token_denylist"}
+	RedisKeyRolePermission     = RedisKey{PrefixKey: "role_perm_1"}
+	RedisKeyUserCompanyRole    = RedisKey{PrefixKey: "usr_comp_role_1"}
+	RedisKeyLoginRateLimit     = RedisKey{PrefixKey: "rate_limit_login"}
+	RedisKeyBlockLogin         = RedisKey{PrefixKey: "block_login"}
+	RedisKeyBlockLoginLevel    = RedisKey{PrefixKey: "block_login_level"}
+	RedisKeyTwoFactorEnroll    = RedisKey{PrefixKey: "2fa_enroll"}
+	RedisKeyTwoFactorChallenge = RedisKey{PrefixKey: "2fa_challenge"}
+	RedisKeyTwoFactorAttempt   = RedisKey{PrefixKey: "2fa_challenge_attempt"}
+	RedisKeyTwoFactorUsedStep  = RedisKey{PrefixKey: "2fa_used_step"}
+	RedisKeyOTPCode            = RedisKey{PrefixKey: "otp_code"}
+	RedisKeyOTPAttempt         = RedisKey{PrefixKey: "otp_attempt"}
+	RedisKeyOTPResend          = RedisKey{PrefixKey: "otp_resend"}
+	RedisKeyOTPSendCount       = RedisKey{PrefixKey: "otp_send_count"}
+	RedisKeyOAuthState         = RedisKey{PrefixKey: "oauth_state"}
+	RedisKeyDeviceReport       = RedisKey{PrefixKey: "device_report"}
+	RedisKeyDataExport         = RedisKey{PrefixKey: "data_export"}
+	RedisKeyDataExportLock     = RedisKey{PrefixKey: "data_export_lock"}
+	RedisKeyVerifyResend       = RedisKey{PrefixKey: "verify_resend"}
+	RedisKeyVerifySendCount    = RedisKey{PrefixKey: "verify_send_count"}
+	RedisKeyVerifyIPCount      = RedisKey{PrefixKey: "verify_ip_count"}
+	RedisKeyEmailChange        = RedisKey{PrefixKey: "email_change"}
+	RedisKeyEmailChangeUser    = RedisKey{PrefixKey: "email_change_user"}
 
 	RedisKeyWhitelistIP = RedisKey{PrefixKey: "authsvc-v1:whitelist_ip"}
 
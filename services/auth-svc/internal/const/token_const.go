@@ -0,0 +1,20 @@
+package _const
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	TokenSchemeBearer = "Bearer"
+
+	// TokenScopeUnverified limits tokens of unverified users under limited email verification policy
+	TokenScopeUnverified = "unverified"
+)
+
+const (
+	// EmailPolicyBlock rejects login of unverified users
+	EmailPolicyBlock = "block"
+	// EmailPolicyLimited allows login of unverified users with limited token scope
+	EmailPolicyLimited = "limited"
+	// EmailPolicyGrace allows login of unverified users for grace days after registration
+	EmailPolicyGrace = "grace"
+)
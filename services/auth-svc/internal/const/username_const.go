@@ -0,0 +1,7 @@
+package _const
+
+// ReservedUsernames are names that can't be registered because they are used
+// by the system or collide with gateway and short-link paths
+var ReservedUsernames = []string{
+	"admin", "api", "auth", "email", "ping", "r", "root", "support", "swagger", "system", "www",
+}
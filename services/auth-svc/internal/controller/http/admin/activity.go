@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ListActivity lists activity of all users
+// @Summary     List activity
+// @Description List activity of all users newest first, with ID, username and email of user. Logs of purged users have null user_id and are grouped by pseudonym. Pass next_cursor of previous page as cursor to get next page. Dates accept RFC3339 or YYYY-MM-DD, to is exclusive. Requires activity:read permission.
+// @Tags        admin
+// @Produce     json
+// @Security    BearerAuth
+// @Param       user_id   query int    false "User ID"
+// @Param       pseudonym query string false "Pseudonym of purged user"
+// @Param       action    query string false "Comma separated actions, e.g. login,change_password,password_reset"
+// @Param       ip        query string false "IP address"
+// @Param       from      query string false "Created at or after"
+// @Param       to        query string false "Created before"
+// @Param       cursor    query string false "Cursor from previous page"
+// @Param       limit     query int    false "Page size, max 100"
+// @Success     200 {object} model.ActivityPage "Activity logs"
+// @Failure     400 {object} map[string]string "Invalid filter"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires activity:read permission"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/activity [get]
+func (c *AdminController) ListActivity(ctx *fiber.Ctx) error {
+	filter := model.ActivityFilter{
+		Pseudonym: strings.TrimSpace(ctx.Query("pseudonym")),
+		IPAddress: ctx.Query("ip"),
+		Limit:     ctx.QueryInt("limit", model.DefaultPageSize),
+	}
+	if actions := ctx.Query("action"); actions != "" {
+		filter.Actions = strings.Split(actions, ",")
+	}
+
+	if userID := ctx.Query("user_id"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 32)
+		if err != nil {
+			return c.invalidFilter(ctx, "Invalid user_id")
+		}
+		filter.UserID = uint(id)
+	}
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		id, err := strconv.ParseUint(cursor, 10, 32)
+		if err != nil {
+			return c.invalidFilter(ctx, "Invalid cursor")
+		}
+		filter.Cursor = uint(id)
+	}
+
+	var err error
+	if filter.From, err = util.ParseTimeParam(ctx.Query("from")); err != nil {
+		return c.invalidFilter(ctx, "Invalid from")
+	}
+	if filter.To, err = util.ParseTimeParam(ctx.Query("to")); err != nil {
+		return c.invalidFilter(ctx, "Invalid to")
+	}
+
+	page, err := c.adminService.ListActivity(ctx.Context(), filter)
+	if err != nil {
+		c.logger.Error("Failed to list activity", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to list activity",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    page,
+	})
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"github.com/taititans/bitzap/auth-svc/internal/service"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// AdminController handles HTTP requests for admin user management
+type AdminController struct {
+	adminService service.AdminService
+	logger       util.Logger
+}
+
+// NewAdminController creates a new admin controller
+func NewAdminController(adminService service.AdminService, logger util.Logger) AdminControllerInterface {
+	return &AdminController{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,16 @@
+package admin
+
+import "github.com/gofiber/fiber/v2"
+
+// AdminControllerInterface defines the interface for admin controller
+type AdminControllerInterface interface {
+	ListUsers(ctx *fiber.Ctx) error
+	GetUser(ctx *fiber.Ctx) error
+	LockUser(ctx *fiber.Ctx) error
+	UnlockUser(ctx *fiber.Ctx) error
+	RevokeUserSessions(ctx *fiber.Ctx) error
+	VerifyUserEmail(ctx *fiber.Ctx) error
+	AssignUserRole(ctx *fiber.Ctx) error
+	RemoveUserRole(ctx *fiber.Ctx) error
+	ListActivity(ctx *fiber.Ctx) error
+}
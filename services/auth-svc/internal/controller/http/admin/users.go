@@ -0,0 +1,436 @@
+package admin
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// maxReasonLength is maximum length of admin action reason
+const maxReasonLength = 500
+
+// ListUsers lists users
+// @Summary     List users
+// @Description List users with search, filters and pagination. Dates accept RFC3339 or YYYY-MM-DD, created_to is exclusive. Requires admin role.
+// @Tags        admin
+// @Produce     json
+// @Security    BearerAuth
+// @Param       q            query string false "Search email, username or name"
+// @Param       is_verified  query bool   false "Filter by email verification"
+// @Param       is_active    query bool   false "Filter by active (false means locked)"
+// @Param       role         query string false "Filter by role name"
+// @Param       created_from query string false "Created at or after"
+// @Param       created_to   query string false "Created before"
+// @Param       page         query int    false "Page, starts at 1"
+// @Param       page_size    query int    false "Page size, max 100"
+// @Success     200 {object} model.UserList "Users"
+// @Failure     400 {object} map[string]string "Invalid filter"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users [get]
+func (c *AdminController) ListUsers(ctx *fiber.Ctx) error {
+	req := model.ListUsersRequest{
+		UserFilter: model.UserFilter{
+			Query: ctx.Query("q"),
+			Role:  ctx.Query("role"),
+		},
+		Page:     ctx.QueryInt("page", 1),
+		PageSize: ctx.QueryInt("page_size", model.DefaultPageSize),
+	}
+
+	var err error
+	if req.IsVerified, err = parseBoolQuery(ctx, "is_verified"); err != nil {
+		return c.invalidFilter(ctx, "Invalid is_verified")
+	}
+	if req.IsActive, err = parseBoolQuery(ctx, "is_active"); err != nil {
+		return c.invalidFilter(ctx, "Invalid is_active")
+	}
+	if req.CreatedFrom, err = util.ParseTimeParam(ctx.Query("created_from")); err != nil {
+		return c.invalidFilter(ctx, "Invalid created_from")
+	}
+	if req.CreatedTo, err = util.ParseTimeParam(ctx.Query("created_to")); err != nil {
+		return c.invalidFilter(ctx, "Invalid created_to")
+	}
+
+	users, err := c.adminService.ListUsers(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to list users", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to list users",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    users,
+	})
+}
+
+// GetUser gets user
+// @Summary     Get user
+// @Description Get user with roles. Requires admin role.
+// @Tags        admin
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path int true "User ID"
+// @Success     200 {object} entity.User "User"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id} [get]
+func (c *AdminController) GetUser(ctx *fiber.Ctx) error {
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return c.invalidUserID(ctx)
+	}
+
+	user, err := c.adminService.GetUser(ctx.Context(), userID)
+	if err != nil {
+		return c.adminError(ctx, err, "Failed to get user")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    user,
+	})
+}
+
+// LockUser locks user
+// @Summary     Lock user
+// @Description Deactivate user, revoke its sessions and remove its gateway consumer. Requires admin role.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id      path int                      true "User ID"
+// @Param       request body model.AdminActionRequest true "Reason"
+// @Success     200 {object} map[string]interface{} "User locked"
+// @Failure     400 {object} map[string]string "Bad request or locking yourself"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/lock [post]
+func (c *AdminController) LockUser(ctx *fiber.Ctx) error {
+	userID, req, ok, err := c.parseAction(ctx, true)
+	if !ok {
+		return err
+	}
+
+	if err := c.adminService.LockUser(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to lock user", util.Error(err))
+		return c.adminError(ctx, err, "Failed to lock user")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "User locked",
+	})
+}
+
+// UnlockUser unlocks user
+// @Summary     Unlock user
+// @Description Activate locked user. Requires admin role.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id      path int                      true "User ID"
+// @Param       request body model.AdminActionRequest true "Reason"
+// @Success     200 {object} map[string]interface{} "User unlocked"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/unlock [post]
+func (c *AdminController) UnlockUser(ctx *fiber.Ctx) error {
+	userID, req, ok, err := c.parseAction(ctx, true)
+	if !ok {
+		return err
+	}
+
+	if err := c.adminService.UnlockUser(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to unlock user", util.Error(err))
+		return c.adminError(ctx, err, "Failed to unlock user")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "User unlocked",
+	})
+}
+
+// RevokeUserSessions revokes all sessions of user
+// @Summary     Revoke user sessions
+// @Description Revoke every refresh and access token of user, signing it out on all devices. Requires admin role.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id      path int                      true  "User ID"
+// @Param       request body model.AdminActionRequest false "Optional reason"
+// @Success     200 {object} map[string]interface{} "Sessions revoked"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/sessions [delete]
+func (c *AdminController) RevokeUserSessions(ctx *fiber.Ctx) error {
+	userID, req, ok, err := c.parseAction(ctx, false)
+	if !ok {
+		return err
+	}
+
+	if err := c.adminService.RevokeUserSessions(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to revoke user sessions", util.Error(err))
+		return c.adminError(ctx, err, "Failed to revoke user sessions")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Sessions revoked",
+	})
+}
+
+// VerifyUserEmail forces email verification
+// @Summary     Verify user email
+// @Description Mark email of user verified without verification link. Requires admin role.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id      path int                      true  "User ID"
+// @Param       request body model.AdminActionRequest false "Optional reason"
+// @Success     200 {object} map[string]interface{} "Email verified"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/verify-email [post]
+func (c *AdminController) VerifyUserEmail(ctx *fiber.Ctx) error {
+	userID, req, ok, err := c.parseAction(ctx, false)
+	if !ok {
+		return err
+	}
+
+	if err := c.adminService.VerifyUserEmail(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to verify user email", util.Error(err))
+		return c.adminError(ctx, err, "Failed to verify user email")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Email verified",
+	})
+}
+
+// AssignUserRole assigns role to user
+// @Summary     Assign role
+// @Description Assign role to user. Requires admin role.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id      path int                    true "User ID"
+// @Param       request body model.AdminRoleRequest true "Role"
+// @Success     200 {object} map[string]interface{} "Role assigned"
+// @Failure     400 {object} map[string]string "Bad request or role not found"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/roles [post]
+func (c *AdminController) AssignUserRole(ctx *fiber.Ctx) error {
+	adminID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return c.invalidUserID(ctx)
+	}
+
+	var req model.AdminRoleRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Role == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Role is required",
+		})
+	}
+	req.AdminID = adminID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.adminService.AssignUserRole(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to assign role", util.Error(err))
+		return c.adminError(ctx, err, "Failed to assign role")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Role assigned",
+	})
+}
+
+// RemoveUserRole removes role from user
+// @Summary     Remove role
+// @Description Remove role from user. Admins can't remove their own admin role. Requires admin role.
+// @Tags        admin
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id   path int    true "User ID"
+// @Param       role path string true "Role name"
+// @Success     200 {object} map[string]interface{} "Role removed"
+// @Failure     400 {object} map[string]string "Bad request or role not found"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Requires admin role"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /admin/users/{id}/roles/{role} [delete]
+func (c *AdminController) RemoveUserRole(ctx *fiber.Ctx) error {
+	adminID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return c.invalidUserID(ctx)
+	}
+
+	if err := c.adminService.RemoveUserRole(ctx.Context(), userID, model.AdminRoleRequest{
+		Role:      ctx.Params("role"),
+		AdminID:   adminID,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}); err != nil {
+		c.logger.Error("Failed to remove role", util.Error(err))
+		return c.adminError(ctx, err, "Failed to remove role")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Role removed",
+	})
+}
+
+// parseAction parses user ID and reason of admin action.
+// When not ok, error response is already written and returned error must be returned by handler.
+func (c *AdminController) parseAction(ctx *fiber.Ctx, reasonRequired bool) (uint, model.AdminActionRequest, bool, error) {
+	var req model.AdminActionRequest
+
+	adminID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return 0, req, false, c.userCtxNotFound(ctx)
+	}
+
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return 0, req, false, c.invalidUserID(ctx)
+	}
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			c.logger.Error("Failed to parse request body", util.Error(err))
+			return 0, req, false, ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeBadRequest.Code(),
+				"message": "Invalid request body",
+			})
+		}
+	}
+	if (reasonRequired && req.Reason == "") || len(req.Reason) > maxReasonLength {
+		return 0, req, false, ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Reason is required (max 500 chars)",
+		})
+	}
+
+	req.AdminID = adminID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	return userID, req, true, nil
+}
+
+// adminError maps admin action errors to response
+func (c *AdminController) adminError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeUserNotFound,
+		_const.CodeUserHaveBeenLock,
+		_const.CodeUserHaveBeenUnlock,
+		_const.CodeRoleNotFound,
+		_const.CodeActionNotAllowed,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	status := code.HttpStatus()
+	if code == _const.CodeUserNotFound {
+		status = 404
+	}
+	return ctx.Status(status).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
+
+// invalidFilter responds to invalid list filter
+func (c *AdminController) invalidFilter(ctx *fiber.Ctx, message string) error {
+	return ctx.Status(400).JSON(fiber.Map{
+		"code":    _const.CodeBadRequest.Code(),
+		"message": message,
+	})
+}
+
+// invalidUserID responds to invalid user ID path param
+func (c *AdminController) invalidUserID(ctx *fiber.Ctx) error {
+	return ctx.Status(400).JSON(fiber.Map{
+		"code":    _const.CodeBadRequest.Code(),
+		"message": "Invalid user ID",
+	})
+}
+
+// userCtxNotFound responds when authenticated user is missing in context
+func (c *AdminController) userCtxNotFound(ctx *fiber.Ctx) error {
+	return ctx.Status(_const.CodeUserCtxNotFound.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeUserCtxNotFound.Code(),
+		"message": _const.CodeUserCtxNotFound.Message(),
+	})
+}
+
+// parseUserID parses id path param
+func parseUserID(ctx *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	return uint(id), err
+}
+
+// parseBoolQuery parses optional bool query param
+func parseBoolQuery(ctx *fiber.Ctx, key string) (*bool, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
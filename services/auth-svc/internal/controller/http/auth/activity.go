@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// GetMyActivity lists activity and security log of current user
+// @Summary     Get my activity
+// @Description List activity of current user newest first. Pass next_cursor of previous page as cursor to get next page. Dates accept RFC3339 or YYYY-MM-DD, to is exclusive.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Param       action query string false "Comma separated actions, e.g. login,change_password,password_reset"
+// @Param       ip     query string false "IP address"
+// @Param       from   query string false "Created at or after"
+// @Param       to     query string false "Created before"
+// @Param       cursor query string false "Cursor from previous page"
+// @Param       limit  query int    false "Page size, max 100"
+// @Success     200 {object} model.ActivityPage "Activity logs"
+// @Failure     400 {object} map[string]string "Invalid filter"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/activity [get]
+func (c *AuthController) GetMyActivity(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	filter, message := parseActivityFilter(ctx)
+	if message != "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": message,
+		})
+	}
+
+	page, err := c.authService.ListMyActivity(ctx.Context(), userID, filter)
+	if err != nil {
+		c.logger.Error("Failed to list activity", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to list activity",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    page,
+	})
+}
+
+// parseActivityFilter parses activity filter query params, returns message when invalid
+func parseActivityFilter(ctx *fiber.Ctx) (model.ActivityFilter, string) {
+	filter := model.ActivityFilter{
+		IPAddress: ctx.Query("ip"),
+		Limit:     ctx.QueryInt("limit", model.DefaultPageSize),
+	}
+	if actions := ctx.Query("action"); actions != "" {
+		filter.Actions = strings.Split(actions, ",")
+	}
+
+	var err error
+	if filter.From, err = util.ParseTimeParam(ctx.Query("from")); err != nil {
+		return filter, "Invalid from"
+	}
+	if filter.To, err = util.ParseTimeParam(ctx.Query("to")); err != nil {
+		return filter, "Invalid to"
+	}
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		id, err := strconv.ParseUint(cursor, 10, 32)
+		if err != nil {
+			return filter, "Invalid cursor"
+		}
+		filter.Cursor = uint(id)
+	}
+
+	return filter, ""
+}
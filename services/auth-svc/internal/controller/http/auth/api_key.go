@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// CreateAPIKey issues new API key for current user
+// @Summary     Create API key
+// @Description Issue scoped API key. The key is returned only once, store it securely.
+// @Tags        api-keys
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.CreateAPIKeyRequest true "Name, scopes and expiry"
+// @Success     200 {object} model.CreatedAPIKey "API key created"
+// @Failure     400 {object} map[string]string "Invalid scope or limit reached"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Scope isn't granted to current user"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/api-keys [post]
+func (c *AuthController) CreateAPIKey(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Name == "" || len(req.Name) > 100 || len(req.Scopes) == 0 {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Name (max 100 chars) and at least one scope are required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	created, err := c.authService.CreateAPIKey(ctx.Context(), userID, req)
+	if err != nil {
+		c.logger.Error("Failed to create API key", util.Error(err))
+		return c.apiKeyError(ctx, err, "Failed to create API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key created. Store the key securely, it is shown only once",
+		"data":    created,
+	})
+}
+
+// ListAPIKeys lists API keys of current user
+// @Summary     List API keys
+// @Description List API keys with prefix, scopes, expiry and last use. Secrets are never returned.
+// @Tags        api-keys
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "API keys"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/api-keys [get]
+func (c *AuthController) ListAPIKeys(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	apiKeys, err := c.authService.ListAPIKeys(ctx.Context(), userID)
+	if err != nil {
+		c.logger.Error("Failed to list API keys", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to list API keys",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    apiKeys,
+	})
+}
+
+// RotateAPIKey replaces API key secret
+// @Summary     Rotate API key
+// @Description Issue new key with same name, scopes and expiry. Old key keeps working during grace period.
+// @Tags        api-keys
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path int true "API key ID"
+// @Success     200 {object} model.CreatedAPIKey "New API key"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Scope of key is no longer granted to current user"
+// @Failure     404 {object} map[string]string "API key not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/api-keys/{id}/rotate [post]
+func (c *AuthController) RotateAPIKey(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid API key ID",
+		})
+	}
+
+	created, err := c.authService.RotateAPIKey(ctx.Context(), userID, uint(id), model.APIKeyActionRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	})
+	if err != nil {
+		c.logger.Error("Failed to rotate API key", util.Error(err))
+		return c.apiKeyError(ctx, err, "Failed to rotate API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key rotated. Store the new key securely, it is shown only once",
+		"data":    created,
+	})
+}
+
+// RevokeAPIKey revokes API key
+// @Summary     Revoke API key
+// @Description Revoke API key immediately
+// @Tags        api-keys
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path int true "API key ID"
+// @Success     200 {object} map[string]interface{} "API key revoked"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "API key not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/api-keys/{id} [delete]
+func (c *AuthController) RevokeAPIKey(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid API key ID",
+		})
+	}
+
+	if err := c.authService.RevokeAPIKey(ctx.Context(), userID, uint(id), model.APIKeyActionRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}); err != nil {
+		c.logger.Error("Failed to revoke API key", util.Error(err))
+		return c.apiKeyError(ctx, err, "Failed to revoke API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key revoked",
+	})
+}
+
+// VerifyAPIKey verifies API key for gateway and internal services
+// @Summary     Verify API key (internal)
+// @Description Verify API key and optional required scope, records last use. Key only grants scopes its owner still has, keys of tenant stop working when owner no longer manages tenant. Requires X-Internal-Token header.
+// @Tags        internal
+// @Accept      json
+// @Produce     json
+// @Param       X-Internal-Token header string true "Internal token"
+// @Param       request body model.VerifyAPIKeyRequest true "API key and required scope"
+// @Success     200 {object} model.VerifyAPIKeyResponse "API key owner, tenant and scopes still granted to owner"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Invalid API key or internal token"
+// @Failure     403 {object} map[string]string "Missing scope"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /internal/api-keys/verify [post]
+func (c *AuthController) VerifyAPIKey(ctx *fiber.Ctx) error {
+	var req model.VerifyAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Key == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Key is required",
+		})
+	}
+	if req.ClientIP == "" {
+		req.ClientIP = ctx.IP()
+	}
+
+	result, err := c.authService.VerifyAPIKey(ctx.Context(), req)
+	if err != nil {
+		return c.apiKeyError(ctx, err, "Failed to verify API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    result,
+	})
+}
+
+// apiKeyError maps API key errors to response
+func (c *AuthController) apiKeyError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeAPIKeyNotFound,
+		_const.CodeAPIKeyInvalid,
+		_const.CodeAPIKeyScope,
+		_const.CodeAPIKeyLimit,
+		_const.CodeAPIKeyForbidden,
+		_const.CodeAPIKeyNotGranted,
+	)
+	if !ok {
+		if err.Error() == _const.CodeBadRequest.Message() {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeBadRequest.Code(),
+				"message": "Invalid expiry",
+			})
+		}
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	return ctx.Status(code.HttpStatus()).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// RequestEmailChange starts email change of current user
+// @Summary     Change my email
+// @Description Send confirmation link to new email and notice to current one. Email changes only after link is confirmed. Confirm request with password, code from /auth/me/reauth/code, or leave both empty if signed in within last few minutes.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.ChangeEmailRequest true "New email with password or code confirmation"
+// @Success     200 {object} map[string]interface{} "Confirmation sent"
+// @Failure     400 {object} map[string]string "Invalid email, wrong password or email already in use"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Re-authentication required"
+// @Failure     429 {object} map[string]string "Too many attempts"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/email [post]
+func (c *AuthController) RequestEmailChange(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.ChangeEmailRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+	if req.NewEmail == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "New email is required",
+		})
+	}
+
+	// Get client info
+	req.FamilyID = claims.FamilyID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.authService.RequestEmailChange(ctx.Context(), claims.UserID, req); err != nil {
+		c.logger.Error("Failed to request email change", util.Error(err))
+		return c.emailChangeError(ctx, err, "Failed to request email change")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Confirmation link has been sent to your new email",
+	})
+}
+
+// ConfirmEmailChange confirms email change with token sent to new address
+// @Summary     Confirm email change
+// @Description Switch email of user to new address with token from confirmation email. Sessions other than the one that requested change are signed out.
+// @Tags        auth
+// @Produce     json
+// @Param       token query string true "Confirmation token"
+// @Success     200 {object} map[string]interface{} "Email changed"
+// @Failure     400 {object} map[string]string "Invalid or expired token, or email already in use"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/confirm-email-change [get]
+func (c *AuthController) ConfirmEmailChange(ctx *fiber.Ctx) error {
+	req := model.ConfirmEmailChangeRequest{
+		Token:     ctx.Query("token"),
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+	if req.Token == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+	}
+
+	if err := c.authService.ConfirmEmailChange(ctx.Context(), req); err != nil {
+		c.logger.Error("Failed to confirm email change", util.Error(err))
+		return c.emailChangeError(ctx, err, "Failed to confirm email change")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Email changed successfully",
+	})
+}
+
+// emailChangeError maps email change errors to response
+func (c *AuthController) emailChangeError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeInvalidEmail,
+		_const.CodeEmailUnchanged,
+		_const.CodeEmailChangeInvalid,
+		_const.CodeEmailExists,
+		_const.CodeWrongPassword,
+		_const.CodeOTPInvalid,
+		_const.CodeExceedLoginAttempts,
+		_const.CodeReauthRequired,
+		_const.CodeUserNotFound,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	status := 400
+	switch code {
+	case _const.CodeUserNotFound:
+		status = 404
+	case _const.CodeReauthRequired:
+		status = code.HttpStatus()
+	case _const.CodeExceedLoginAttempts:
+		status = fiber.StatusTooManyRequests
+	}
+	return ctx.Status(status).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
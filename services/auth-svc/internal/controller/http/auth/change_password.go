@@ -11,14 +11,16 @@ import (
 
 // ChangePassword changes user password
 // @Summary     Change user password
-// @Description Change user password
+// @Description Change user password, requires admin role
 // @Tags        auth
 // @Accept      json
 // @Produce     json
+// @Security    BearerAuth
 // @Param       user_id path int true "User ID"
 // @Param       request body model.ChangePasswordRequest true "Password change data"
 // @Success     200 {object} map[string]interface{} "Password changed successfully"
 // @Failure     400 {object} map[string]string "Bad request"
+// @Failure     403 {object} map[string]string "Requires admin role"
 // @Failure     404 {object} map[string]string "User not found"
 // @Failure     500 {object} map[string]string "Internal server error"
 // @Router      /auth/password/{user_id} [put]
@@ -32,6 +34,11 @@ func (c *AuthController) ChangePassword(ctx *fiber.Ctx) error {
 		})
 	}
 
+	return c.changePassword(ctx, uint(userID))
+}
+
+// changePassword changes password of given user
+func (c *AuthController) changePassword(ctx *fiber.Ctx, userID uint) error {
 	var req model.ChangePasswordRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.logger.Error("Failed to parse request body", util.Error(err))
@@ -41,6 +48,10 @@ func (c *AuthController) ChangePassword(ctx *fiber.Ctx) error {
 		})
 	}
 
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
 	// Validate request
 	if err := c.validateChangePasswordRequest(req); err != nil {
 		return ctx.Status(400).JSON(fiber.Map{
@@ -50,13 +61,36 @@ func (c *AuthController) ChangePassword(ctx *fiber.Ctx) error {
 	}
 
 	// Change password
-	err = c.authService.ChangePassword(ctx.Context(), uint(userID), req)
+	err := c.authService.ChangePassword(ctx.Context(), userID, req)
 	if err != nil {
 		c.logger.Error("Failed to change password", util.Error(err))
-		return ctx.Status(404).JSON(fiber.Map{
-			"code":    _const.CodeUserNotFound.Code(),
-			"message": "User not found",
-		})
+
+		// Password policy violation
+		if code, ok := _const.CodeFromError(err, _const.PasswordPolicyCodes...); ok {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		}
+
+		// Handle specific errors
+		switch err.Error() {
+		case _const.CodeWrongOldPassword.Message():
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeWrongOldPassword.Code(),
+				"message": _const.CodeWrongOldPassword.Message(),
+			})
+		case _const.CodeUserNotFound.Message():
+			return ctx.Status(404).JSON(fiber.Map{
+				"code":    _const.CodeUserNotFound.Code(),
+				"message": "User not found",
+			})
+		default:
+			return ctx.Status(500).JSON(fiber.Map{
+				"code":    _const.CodeInternalError.Code(),
+				"message": "Failed to change password",
+			})
+		}
 	}
 
 	return ctx.JSON(fiber.Map{
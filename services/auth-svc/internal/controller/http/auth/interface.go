@@ -6,10 +6,45 @@ import "github.com/gofiber/fiber/v2"
 type AuthControllerInterface interface {
 	Register(ctx *fiber.Ctx) error
 	Login(ctx *fiber.Ctx) error
+	VerifyTwoFactorLogin(ctx *fiber.Ctx) error
+	RequestLoginOTP(ctx *fiber.Ctx) error
+	LoginWithOTP(ctx *fiber.Ctx) error
+	RequestPhoneVerification(ctx *fiber.Ctx) error
+	VerifyPhone(ctx *fiber.Ctx) error
+	OAuthAuthorize(ctx *fiber.Ctx) error
+	OAuthCallback(ctx *fiber.Ctx) error
+	CreateAPIKey(ctx *fiber.Ctx) error
+	ListAPIKeys(ctx *fiber.Ctx) error
+	RotateAPIKey(ctx *fiber.Ctx) error
+	RevokeAPIKey(ctx *fiber.Ctx) error
+	VerifyAPIKey(ctx *fiber.Ctx) error
+	RefreshToken(ctx *fiber.Ctx) error
+	Logout(ctx *fiber.Ctx) error
+	LogoutAll(ctx *fiber.Ctx) error
+	GetMe(ctx *fiber.Ctx) error
+	UpdateMe(ctx *fiber.Ctx) error
+	ChangeMyPassword(ctx *fiber.Ctx) error
+	RequestEmailChange(ctx *fiber.Ctx) error
+	ConfirmEmailChange(ctx *fiber.Ctx) error
+	RequestReauthCode(ctx *fiber.Ctx) error
+	GetMyActivity(ctx *fiber.Ctx) error
+	RequestDataExport(ctx *fiber.Ctx) error
+	DownloadDataExport(ctx *fiber.Ctx) error
+	RequestAccountDeletion(ctx *fiber.Ctx) error
+	CancelAccountDeletion(ctx *fiber.Ctx) error
+	ListSessions(ctx *fiber.Ctx) error
+	RevokeSession(ctx *fiber.Ctx) error
 	GetProfile(ctx *fiber.Ctx) error
 	UpdateProfile(ctx *fiber.Ctx) error
 	ChangePassword(ctx *fiber.Ctx) error
 	RequestPasswordReset(ctx *fiber.Ctx) error
 	ResetPassword(ctx *fiber.Ctx) error
 	VerifyEmail(ctx *fiber.Ctx) error
+	ResendVerification(ctx *fiber.Ctx) error
+	PreviewLoginReport(ctx *fiber.Ctx) error
+	ReportLogin(ctx *fiber.Ctx) error
+	EnrollTwoFactor(ctx *fiber.Ctx) error
+	ConfirmTwoFactor(ctx *fiber.Ctx) error
+	DisableTwoFactor(ctx *fiber.Ctx) error
+	RegenerateRecoveryCodes(ctx *fiber.Ctx) error
 }
@@ -14,9 +14,10 @@ import (
 // @Accept      json
 // @Produce     json
 // @Param       request body model.LoginRequest true "Login credentials"
-// @Success     200 {object} map[string]interface{} "Login successful"
+// @Success     200 {object} map[string]interface{} "Login successful or two-factor challenge"
 // @Failure     400 {object} map[string]string "Bad request"
 // @Failure     401 {object} map[string]string "Invalid credentials"
+// @Failure     429 {object} map[string]string "Too many login attempts"
 // @Failure     500 {object} map[string]string "Internal server error"
 // @Router      /auth/login [post]
 func (c *AuthController) Login(ctx *fiber.Ctx) error {
@@ -35,16 +36,64 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 		})
 	}
 
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
 	// Login user
-	user, err := c.authService.LoginUser(ctx.Context(), req)
+	result, err := c.authService.LoginUser(ctx.Context(), req)
 	if err != nil {
 		c.logger.Error("Failed to login user", util.Error(err))
-		return ctx.Status(401).JSON(fiber.Map{
-			"code":    _const.CodeWrongPassword.Code(),
-			"message": "Invalid email or password",
+
+		// Handle specific errors
+		switch err.Error() {
+		case _const.CodeBlockingAccount.Message():
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"code":    _const.CodeBlockingAccount.Code(),
+				"message": _const.CodeBlockingAccount.Message(),
+			})
+		case _const.CodeExceedLoginAttempts.Message():
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"code":    _const.CodeExceedLoginAttempts.Code(),
+				"message": _const.CodeExceedLoginAttempts.Message(),
+			})
+		case _const.CodeLoginRate.Message():
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"code":    _const.CodeLoginRate.Code(),
+				"message": _const.CodeLoginRate.Message(),
+			})
+		case _const.CodeLockingAccount.Message():
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeLockingAccount.Code(),
+				"message": _const.CodeLockingAccount.Message(),
+			})
+		case _const.CodeUserNotActivated.Message():
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"code":    _const.CodeUserNotActivated.Code(),
+				"message": _const.CodeUserNotActivated.Message(),
+			})
+		default:
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeWrongPassword.Code(),
+				"message": "Invalid email or password",
+			})
+		}
+	}
+
+	// Second factor required
+	if result.TwoFactor != nil {
+		return ctx.JSON(fiber.Map{
+			"code":       _const.CodeTwoFactorRequired.Code(),
+			"message":    _const.CodeTwoFactorRequired.Message(),
+			"two_factor": result.TwoFactor,
 		})
 	}
 
+	return c.loginSuccess(ctx, result)
+}
+
+func (c *AuthController) loginSuccess(ctx *fiber.Ctx, result *model.LoginResult) error {
+	user := result.User
 	return ctx.JSON(fiber.Map{
 		"code":    _const.CodeSuccess.Code(),
 		"message": "Login successful",
@@ -57,6 +106,7 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 			"is_active":   user.IsActive,
 			"is_verified": user.IsVerified,
 		},
+		"token": result.Tokens,
 	})
 }
 
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// Logout revokes current session
+// @Summary     Logout
+// @Description Revoke current access token and its refresh token
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "Logout successful"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/logout [post]
+func (c *AuthController) Logout(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req := model.LogoutRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+
+	if err := c.authService.Logout(ctx.Context(), claims, req); err != nil {
+		c.logger.Error("Failed to logout", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to logout",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Logout successful",
+	})
+}
+
+// LogoutAll revokes all sessions of current user
+// @Summary     Logout from all sessions
+// @Description Revoke every access and refresh token of current user
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "Logout successful"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/logout-all [post]
+func (c *AuthController) LogoutAll(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req := model.LogoutRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+
+	if err := c.authService.LogoutAll(ctx.Context(), claims, req); err != nil {
+		c.logger.Error("Failed to logout from all sessions", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to logout",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Logout from all sessions successful",
+	})
+}
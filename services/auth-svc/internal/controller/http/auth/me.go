@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+)
+
+// GetMe gets profile of authenticated user
+// @Summary     Get current user profile
+// @Description Get profile of user identified by access token
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "User profile"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "User not found"
+// @Router      /auth/me [get]
+func (c *AuthController) GetMe(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	return c.getProfile(ctx, userID)
+}
+
+// UpdateMe updates profile of authenticated user
+// @Summary     Update current user profile
+// @Description Update profile of user identified by access token
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.UpdateProfileRequest true "Profile update data"
+// @Success     200 {object} map[string]interface{} "Profile updated successfully"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "User not found"
+// @Router      /auth/me [put]
+func (c *AuthController) UpdateMe(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	return c.updateProfile(ctx, userID)
+}
+
+// ChangeMyPassword changes password of authenticated user
+// @Summary     Change current user password
+// @Description Change password of user identified by access token
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.ChangePasswordRequest true "Password change data"
+// @Success     200 {object} map[string]interface{} "Password changed successfully"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "User not found"
+// @Router      /auth/me/password [put]
+func (c *AuthController) ChangeMyPassword(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	return c.changePassword(ctx, userID)
+}
+
+func (c *AuthController) userCtxNotFound(ctx *fiber.Ctx) error {
+	return ctx.Status(_const.CodeUserCtxNotFound.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeUserCtxNotFound.Code(),
+		"message": _const.CodeUserCtxNotFound.Message(),
+	})
+}
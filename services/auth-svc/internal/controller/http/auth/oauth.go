@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// oauthStateCookie keeps state of social login in browser that started it
+const oauthStateCookie = "oauth_state"
+
+// OAuthAuthorize redirects user to social login provider
+// @Summary     Start social login
+// @Description Redirect to OAuth2 / OIDC provider authorize page with state, PKCE challenge and nonce. State is also set in HttpOnly cookie checked by callback.
+// @Tags        auth
+// @Param       provider path string true "Provider name configured in config.yaml"
+// @Success     302 "Redirect to provider"
+// @Failure     404 {object} map[string]string "Provider not supported"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/oauth/{provider}/authorize [get]
+func (c *AuthController) OAuthAuthorize(ctx *fiber.Ctx) error {
+	provider := ctx.Params("provider")
+	authorization, err := c.authService.OAuthAuthorize(ctx.Context(), provider)
+	if err != nil {
+		c.logger.Error("Failed to start OAuth login", util.Error(err))
+		return c.oauthError(ctx, err)
+	}
+
+	// Lax lets cookie follow top-level redirect back from provider
+	ctx.Cookie(&fiber.Cookie{
+		Name:     oauthStateCookie,
+		Value:    authorization.State,
+		Path:     "/auth/oauth/" + provider + "/callback",
+		Expires:  time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second),
+		Secure:   ctx.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	return ctx.Redirect(authorization.URL, fiber.StatusFound)
+}
+
+// OAuthCallback handles provider redirect and logs user in
+// @Summary     Social login callback
+// @Description Check state against cookie set by authorize, exchange authorization code, verify OIDC ID token, link account by verified email and issue tokens
+// @Tags        auth
+// @Produce     json
+// @Param       provider path  string true "Provider name configured in config.yaml"
+// @Param       code     query string true "Authorization code"
+// @Param       state    query string true "State"
+// @Success     200 {object} map[string]interface{} "Login successful or two-factor challenge"
+// @Failure     400 {object} map[string]string "Invalid state or unverified email"
+// @Failure     401 {object} map[string]string "Account locked"
+// @Failure     404 {object} map[string]string "Provider not supported"
+// @Failure     409 {object} map[string]string "Existing account is not verified"
+// @Failure     502 {object} map[string]string "Provider login failed"
+// @Router      /auth/oauth/{provider}/callback [get]
+func (c *AuthController) OAuthCallback(ctx *fiber.Ctx) error {
+	req := model.OAuthCallbackRequest{
+		Provider:    ctx.Params("provider"),
+		Code:        ctx.Query("code"),
+		State:       ctx.Query("state"),
+		StateCookie: ctx.Cookies(oauthStateCookie),
+		IPAddress:   ctx.IP(),
+		UserAgent:   ctx.Get("User-Agent"),
+	}
+
+	// State cookie is single use like the state
+	ctx.Cookie(&fiber.Cookie{
+		Name:     oauthStateCookie,
+		Path:     "/auth/oauth/" + req.Provider + "/callback",
+		Expires:  time.Unix(0, 0),
+		Secure:   ctx.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	if req.Code == "" || req.State == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Code and state are required",
+			"error":   ctx.Query("error"),
+		})
+	}
+
+	result, err := c.authService.LoginWithOAuth(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to login with OAuth", util.Error(err))
+
+		if err.Error() == _const.CodeLockingAccount.Message() {
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeLockingAccount.Code(),
+				"message": _const.CodeLockingAccount.Message(),
+			})
+		}
+		return c.oauthError(ctx, err)
+	}
+
+	// Second factor required
+	if result.TwoFactor != nil {
+		return ctx.JSON(fiber.Map{
+			"code":       _const.CodeTwoFactorRequired.Code(),
+			"message":    _const.CodeTwoFactorRequired.Message(),
+			"two_factor": result.TwoFactor,
+		})
+	}
+
+	return c.loginSuccess(ctx, result)
+}
+
+// oauthError maps OAuth errors to response
+func (c *AuthController) oauthError(ctx *fiber.Ctx, err error) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeOAuthProvider,
+		_const.CodeOAuthState,
+		_const.CodeOAuthExchange,
+		_const.CodeOAuthUnverified,
+		_const.CodeOAuthLinkBlocked,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to login with OAuth provider",
+		})
+	}
+
+	return ctx.Status(code.HttpStatus()).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
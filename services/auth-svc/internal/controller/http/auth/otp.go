@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// RequestLoginOTP sends one-time passcode for passwordless login
+// @Summary     Request login OTP
+// @Description Send one-time login code to email (type 1) or verified phone (type 2). Phones are matched in E.164 format, numbers without country code use configured default.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.OTPRequest true "Destination"
+// @Success     200 {object} model.OTPResponse "OTP sent"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     429 {object} map[string]string "Too many OTP requests"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/otp/request [post]
+func (c *AuthController) RequestLoginOTP(ctx *fiber.Ctx) error {
+	var req model.OTPRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Destination == "" || (req.Type != _const.EMAIL && req.Type != _const.PHONE) {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Type and destination are required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	otp, err := c.authService.RequestLoginOTP(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to request login OTP", util.Error(err))
+		return c.otpError(ctx, err, "Failed to send OTP")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "If the account exists, a login code has been sent",
+		"otp":     otp,
+	})
+}
+
+// LoginWithOTP handles passwordless login with one-time passcode
+// @Summary     Login with OTP
+// @Description Authenticate with one-time code sent to email or phone
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.OTPLoginRequest true "Destination and code"
+// @Success     200 {object} map[string]interface{} "Login successful or two-factor challenge"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Invalid code"
+// @Failure     429 {object} map[string]string "Too many attempts, destination or IP temporarily blocked"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/otp/login [post]
+func (c *AuthController) LoginWithOTP(ctx *fiber.Ctx) error {
+	var req model.OTPLoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Destination == "" || req.Code == "" || (req.Type != _const.EMAIL && req.Type != _const.PHONE) {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Type, destination and code are required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	result, err := c.authService.LoginWithOTP(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to login with OTP", util.Error(err))
+
+		switch err.Error() {
+		case _const.CodeExceedLoginAttempts.Message(), _const.CodeBlockingAccount.Message(), _const.CodeLoginRate.Message():
+			code, _ := _const.CodeFromError(err, _const.CodeExceedLoginAttempts, _const.CodeBlockingAccount, _const.CodeLoginRate)
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		case _const.CodeLockingAccount.Message():
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeLockingAccount.Code(),
+				"message": _const.CodeLockingAccount.Message(),
+			})
+		case _const.CodeUserNotActivated.Message():
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"code":    _const.CodeUserNotActivated.Code(),
+				"message": _const.CodeUserNotActivated.Message(),
+			})
+		}
+		return c.otpError(ctx, err, "Failed to login with OTP")
+	}
+
+	// Second factor required
+	if result.TwoFactor != nil {
+		return ctx.JSON(fiber.Map{
+			"code":       _const.CodeTwoFactorRequired.Code(),
+			"message":    _const.CodeTwoFactorRequired.Message(),
+			"two_factor": result.TwoFactor,
+		})
+	}
+
+	return c.loginSuccess(ctx, result)
+}
+
+// RequestPhoneVerification sends verification code to phone of current user
+// @Summary     Request phone verification
+// @Description Send verification code to phone of current user. Only verified phones can log in with OTP, changing phone requires verifying it again.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} model.OTPResponse "Code sent"
+// @Failure     400 {object} map[string]string "Phone missing, invalid or already verified"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     429 {object} map[string]string "Too many OTP requests"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/phone/verify/request [post]
+func (c *AuthController) RequestPhoneVerification(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	otp, err := c.authService.RequestPhoneVerification(ctx.Context(), userID)
+	if err != nil {
+		c.logger.Error("Failed to request phone verification", util.Error(err))
+		return c.otpError(ctx, err, "Failed to send verification code")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Verification code has been sent",
+		"otp":     otp,
+	})
+}
+
+// VerifyPhone verifies phone of current user
+// @Summary     Verify phone
+// @Description Verify phone of current user with code sent to it
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.PhoneVerificationRequest true "Verification code"
+// @Success     200 {object} map[string]interface{} "Phone verified"
+// @Failure     400 {object} map[string]string "Invalid code, phone invalid or already verified"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     409 {object} map[string]string "Phone verified by another account"
+// @Failure     429 {object} map[string]string "Too many attempts"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/phone/verify [post]
+func (c *AuthController) VerifyPhone(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.PhoneVerificationRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Code == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Code is required",
+		})
+	}
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.authService.VerifyPhone(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to verify phone", util.Error(err))
+		return c.otpError(ctx, err, "Failed to verify phone")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Phone verified",
+	})
+}
+
+// otpError maps OTP errors to response
+func (c *AuthController) otpError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeBadRequest,
+		_const.CodeInvalidPhoneNumber,
+		_const.CodeOTPInvalid,
+		_const.CodeOTPResendTooSoon,
+		_const.CodeOTPSendLimit,
+		_const.CodeExceedLoginAttempts,
+		_const.CodeUserNotFound,
+		_const.CodePhoneVerified,
+		_const.CodePhoneExists,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	status := code.HttpStatus()
+	switch code {
+	case _const.CodeExceedLoginAttempts:
+		status = fiber.StatusTooManyRequests
+	case _const.CodePhoneExists:
+		status = fiber.StatusConflict
+	}
+	if status == 200 {
+		status = 400
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
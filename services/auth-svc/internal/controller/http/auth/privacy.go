@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// RequestDataExport exports personal data of current user
+// @Summary     Export my data
+// @Description Bundle profile, roles, permissions and activity logs of current user into zip archive and email link to download it
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "Download link sent"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     413 {object} map[string]string "Export too large"
+// @Failure     429 {object} map[string]string "Export requested recently"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/export [post]
+func (c *AuthController) RequestDataExport(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req := model.DataExportRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+
+	if err := c.authService.RequestDataExport(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to export data", util.Error(err))
+		return c.privacyError(ctx, err, "Failed to export data")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Download link has been sent to your email",
+	})
+}
+
+// DownloadDataExport downloads data export archive
+// @Summary     Download data export
+// @Description Download zip archive of personal data with token from export email. Only user who requested export can download it.
+// @Tags        auth
+// @Produce     application/zip
+// @Security    BearerAuth
+// @Param       token query string true "Download token from export email"
+// @Success     200 {file}   file "Data export archive"
+// @Failure     400 {object} map[string]string "Token is required"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "Invalid or expired link"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/export/download [get]
+func (c *AuthController) DownloadDataExport(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	token := ctx.Query("token")
+	if token == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+	}
+
+	archive, err := c.authService.DownloadDataExport(ctx.Context(), userID, token)
+	if err != nil {
+		c.logger.Error("Failed to download data export", util.Error(err))
+		return c.privacyError(ctx, err, "Failed to download data export")
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="bitzap-data-export.zip"`)
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	return ctx.Send(archive)
+}
+
+// RequestAccountDeletion schedules deletion of current user
+// @Summary     Delete my account
+// @Description Schedule deletion of current user after grace period. Sessions and API keys are revoked right away, log in again to cancel before deletion is due. Confirm with password, code from /auth/me/reauth/code, or leave both empty if signed in within last few minutes.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.AccountDeletionRequest true "Password or code confirmation"
+// @Success     200 {object} model.AccountDeletionResponse "Deletion scheduled"
+// @Failure     400 {object} map[string]string "Wrong password or code, or deletion already scheduled"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Re-authentication required"
+// @Failure     429 {object} map[string]string "Too many attempts"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me [delete]
+func (c *AuthController) RequestAccountDeletion(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.AccountDeletionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	// Get client info
+	req.FamilyID = claims.FamilyID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	resp, err := c.authService.RequestAccountDeletion(ctx.Context(), claims.UserID, req)
+	if err != nil {
+		c.logger.Error("Failed to schedule account deletion", util.Error(err))
+		return c.privacyError(ctx, err, "Failed to schedule account deletion")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Account deletion scheduled",
+		"data":    resp,
+	})
+}
+
+// CancelAccountDeletion cancels scheduled deletion of current user
+// @Summary     Cancel account deletion
+// @Description Cancel scheduled deletion of current user. Revoked API keys aren't restored.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} map[string]interface{} "Deletion canceled"
+// @Failure     400 {object} map[string]string "Deletion isn't scheduled"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/deletion [delete]
+func (c *AuthController) CancelAccountDeletion(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req := model.CancelDeletionRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+
+	if err := c.authService.CancelAccountDeletion(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to cancel account deletion", util.Error(err))
+		return c.privacyError(ctx, err, "Failed to cancel account deletion")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Account deletion canceled",
+	})
+}
+
+// privacyError maps data export and account deletion errors to response
+func (c *AuthController) privacyError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeExportTooSoon,
+		_const.CodeExportLinkInvalid,
+		_const.CodeExportTooLarge,
+		_const.CodeDeletionScheduled,
+		_const.CodeDeletionNotPending,
+		_const.CodeWrongPassword,
+		_const.CodeOTPInvalid,
+		_const.CodeExceedLoginAttempts,
+		_const.CodeReauthRequired,
+		_const.CodeUserNotFound,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	status := code.HttpStatus()
+	switch code {
+	case _const.CodeWrongPassword, _const.CodeOTPInvalid:
+		status = 400
+	case _const.CodeExceedLoginAttempts:
+		status = fiber.StatusTooManyRequests
+	case _const.CodeUserNotFound:
+		status = 404
+	}
+	return ctx.Status(status).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
@@ -11,13 +11,15 @@ import (
 
 // GetProfile gets user profile
 // @Summary     Get user profile
-// @Description Get user profile by user ID
+// @Description Get user profile by user ID, requires user:read permission
 // @Tags        auth
 // @Accept      json
 // @Produce     json
+// @Security    BearerAuth
 // @Param       user_id path int true "User ID"
 // @Success     200 {object} map[string]interface{} "User profile"
 // @Failure     400 {object} map[string]string "Bad request"
+// @Failure     403 {object} map[string]string "Requires user:read permission"
 // @Failure     404 {object} map[string]string "User not found"
 // @Failure     500 {object} map[string]string "Internal server error"
 // @Router      /auth/profile/{user_id} [get]
@@ -31,7 +33,12 @@ func (c *AuthController) GetProfile(ctx *fiber.Ctx) error {
 		})
 	}
 
-	user, err := c.authService.GetUserProfile(ctx.Context(), uint(userID))
+	return c.getProfile(ctx, uint(userID))
+}
+
+// getProfile renders profile of given user
+func (c *AuthController) getProfile(ctx *fiber.Ctx, userID uint) error {
+	user, err := c.authService.GetUserProfile(ctx.Context(), userID)
 	if err != nil {
 		c.logger.Error("Failed to get user profile", util.Error(err))
 		return ctx.Status(404).JSON(fiber.Map{
@@ -60,14 +67,16 @@ func (c *AuthController) GetProfile(ctx *fiber.Ctx) error {
 
 // UpdateProfile updates user profile
 // @Summary     Update user profile
-// @Description Update user profile information
+// @Description Update user profile information, requires user:update permission
 // @Tags        auth
 // @Accept      json
 // @Produce     json
+// @Security    BearerAuth
 // @Param       user_id path int true "User ID"
 // @Param       request body model.UpdateProfileRequest true "Profile update data"
 // @Success     200 {object} map[string]interface{} "Profile updated successfully"
 // @Failure     400 {object} map[string]string "Bad request"
+// @Failure     403 {object} map[string]string "Requires user:update permission"
 // @Failure     404 {object} map[string]string "User not found"
 // @Failure     500 {object} map[string]string "Internal server error"
 // @Router      /auth/profile/{user_id} [put]
@@ -81,6 +90,11 @@ func (c *AuthController) UpdateProfile(ctx *fiber.Ctx) error {
 		})
 	}
 
+	return c.updateProfile(ctx, uint(userID))
+}
+
+// updateProfile updates profile of given user
+func (c *AuthController) updateProfile(ctx *fiber.Ctx, userID uint) error {
 	var req model.UpdateProfileRequest
 	if err := ctx.BodyParser(&req); err != nil {
 		c.logger.Error("Failed to parse request body", util.Error(err))
@@ -90,6 +104,10 @@ func (c *AuthController) UpdateProfile(ctx *fiber.Ctx) error {
 		})
 	}
 
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
 	// Validate request
 	if err := c.validateUpdateProfileRequest(req); err != nil {
 		return ctx.Status(400).JSON(fiber.Map{
@@ -99,9 +117,15 @@ func (c *AuthController) UpdateProfile(ctx *fiber.Ctx) error {
 	}
 
 	// Update profile
-	user, err := c.authService.UpdateUserProfile(ctx.Context(), uint(userID), req)
+	user, err := c.authService.UpdateUserProfile(ctx.Context(), userID, req)
 	if err != nil {
 		c.logger.Error("Failed to update user profile", util.Error(err))
+		if err.Error() == _const.CodeInvalidPhoneNumber.Message() {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeInvalidPhoneNumber.Code(),
+				"message": _const.CodeInvalidPhoneNumber.Message(),
+			})
+		}
 		return ctx.Status(404).JSON(fiber.Map{
 			"code":    _const.CodeUserNotFound.Code(),
 			"message": "User not found",
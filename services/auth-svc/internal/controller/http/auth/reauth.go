@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// RequestReauthCode sends re-authentication code to email of current user
+// @Summary     Request re-authentication code
+// @Description Send code to email of current user. Code confirms account deletion or email change in place of password.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} model.OTPResponse "Code sent"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "User not found"
+// @Failure     429 {object} map[string]string "Too many OTP requests"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/me/reauth/code [post]
+func (c *AuthController) RequestReauthCode(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	otp, err := c.authService.RequestReauthCode(ctx.Context(), userID)
+	if err != nil {
+		c.logger.Error("Failed to request re-authentication code", util.Error(err))
+		return c.otpError(ctx, err, "Failed to send code")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Code has been sent to your email",
+		"otp":     otp,
+	})
+}
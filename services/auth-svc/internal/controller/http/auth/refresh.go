@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// RefreshToken handles refresh token rotation
+// @Summary     Refresh access token
+// @Description Rotate refresh token and issue a new token pair
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.RefreshTokenRequest true "Refresh token"
+// @Success     200 {object} map[string]interface{} "Token refreshed successfully"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Invalid or expired token"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/refresh [post]
+func (c *AuthController) RefreshToken(ctx *fiber.Ctx) error {
+	var req model.RefreshTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.RefreshToken == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Refresh token is required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	// Rotate tokens
+	tokens, err := c.authService.RefreshToken(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to refresh token", util.Error(err))
+
+		// Handle specific errors
+		switch err.Error() {
+		case _const.CodeTokenExpired.Message():
+			return ctx.Status(_const.CodeTokenExpired.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodeTokenExpired.Code(),
+				"message": _const.CodeTokenExpired.Message(),
+			})
+		case _const.CodeInvalidToken.Message(), _const.CodeUserNotFound.Message():
+			return ctx.Status(_const.CodeInvalidToken.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodeInvalidToken.Code(),
+				"message": _const.CodeInvalidToken.Message(),
+			})
+		case _const.CodeLockingAccount.Message():
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeLockingAccount.Code(),
+				"message": _const.CodeLockingAccount.Message(),
+			})
+		case _const.CodeUserNotActivated.Message():
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"code":    _const.CodeUserNotActivated.Code(),
+				"message": _const.CodeUserNotActivated.Message(),
+			})
+		default:
+			return ctx.Status(500).JSON(fiber.Map{
+				"code":    _const.CodeInternalError.Code(),
+				"message": "Failed to refresh token",
+			})
+		}
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Token refreshed successfully",
+		"token":   tokens,
+	})
+}
@@ -9,13 +9,14 @@ import (
 
 // Register handles user registration
 // @Summary     Register new user
-// @Description Register a new user account
+// @Description Register a new user account. With invitation_token of invitation sent to the same email, email is verified and tenant of invitation is joined.
 // @Tags        auth
 // @Accept      json
 // @Produce     json
 // @Param       request body model.RegisterRequest true "Registration data"
 // @Success     201 {object} map[string]interface{} "User created successfully"
-// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     400 {object} map[string]string "Bad request or invitation invalid"
+// @Failure     403 {object} map[string]string "Invitation was sent to another email"
 // @Failure     409 {object} map[string]string "User already exists"
 // @Failure     500 {object} map[string]string "Internal server error"
 // @Router      /auth/register [post]
@@ -44,6 +45,29 @@ func (c *AuthController) Register(ctx *fiber.Ctx) error {
 	if err != nil {
 		c.logger.Error("Failed to register user", util.Error(err))
 
+		// Username or password policy violation
+		if code, ok := _const.CodeFromError(err, append(_const.UsernamePolicyCodes, _const.PasswordPolicyCodes...)...); ok {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		}
+
+		if err.Error() == _const.CodeInvalidPhoneNumber.Message() {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeInvalidPhoneNumber.Code(),
+				"message": _const.CodeInvalidPhoneNumber.Message(),
+			})
+		}
+
+		// Invitation used for registration
+		if code, ok := _const.CodeFromError(err, _const.CodeInvitationInvalid, _const.CodeInvitationEmail); ok {
+			return ctx.Status(code.HttpStatus()).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		}
+
 		// Handle specific errors
 		switch err.Error() {
 		case _const.CodeEmailExists.Message():
@@ -40,6 +40,15 @@ func (c *AuthController) ResetPassword(ctx *fiber.Ctx) error {
 	err := c.authService.ResetPassword(ctx.Context(), req)
 	if err != nil {
 		c.logger.Error("Failed to reset password", util.Error(err))
+
+		// Password policy violation
+		if code, ok := _const.CodeFromError(err, _const.PasswordPolicyCodes...); ok {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		}
+
 		return ctx.Status(500).JSON(fiber.Map{
 			"code":    _const.CodeInternalError.Code(),
 			"message": "Failed to reset password",
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// PreviewLoginReport handles "this wasn't me" link of new device alert
+// @Summary     Preview login report
+// @Description Show login from new device of token from alert email and ask to confirm report. Token isn't used, so link scanners opening the email don't lock account. Confirm with POST /auth/security/report.
+// @Tags        auth
+// @Produce     json
+// @Param       token query string true "Report token from alert email"
+// @Success     200 {object} model.DeviceReportPreview "Reported login"
+// @Failure     400 {object} map[string]string "Invalid or expired token"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/security/report [get]
+func (c *AuthController) PreviewLoginReport(ctx *fiber.Ctx) error {
+	token := ctx.Query("token")
+	if token == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+	}
+
+	preview, err := c.authService.PreviewLoginReport(ctx.Context(), token)
+	if err != nil {
+		return c.reportError(ctx, err, "Failed to preview login report")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "If this wasn't you, confirm the report to lock account and sign out all sessions",
+		"data":    preview,
+	})
+}
+
+// ReportLogin confirms "this wasn't me" report of new device alert
+// @Summary     Report login from new device
+// @Description Lock account and revoke every session after login from new device is reported with token from alert email
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.DeviceReportRequest true "Report token from alert email"
+// @Success     200 {object} map[string]interface{} "Account locked"
+// @Failure     400 {object} map[string]string "Invalid or expired token"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/security/report [post]
+func (c *AuthController) ReportLogin(ctx *fiber.Ctx) error {
+	var req model.DeviceReportRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Token == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+	}
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.authService.ReportLogin(ctx.Context(), req); err != nil {
+		return c.reportError(ctx, err, "Failed to report login")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Account locked and all sessions signed out, reset your password and contact support to unlock it",
+	})
+}
+
+// reportError maps login report errors to response
+func (c *AuthController) reportError(ctx *fiber.Ctx, err error, fallback string) error {
+	if err.Error() == _const.CodeDeviceReportInvalid.Message() {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeDeviceReportInvalid.Code(),
+			"message": _const.CodeDeviceReportInvalid.Message(),
+		})
+	}
+	c.logger.Error(fallback, util.Error(err))
+	return ctx.Status(500).JSON(fiber.Map{
+		"code":    _const.CodeInternalError.Code(),
+		"message": fallback,
+	})
+}
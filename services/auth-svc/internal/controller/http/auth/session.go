@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ListSessions lists sessions of current user
+// @Summary     List my sessions
+// @Description List devices current user is logged in on, most recently seen first. Session of current token is marked current.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {array}  model.Session "Sessions"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/sessions [get]
+func (c *AuthController) ListSessions(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	sessions, err := c.authService.ListSessions(ctx.Context(), claims)
+	if err != nil {
+		c.logger.Error("Failed to list sessions", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to list sessions",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    sessions,
+	})
+}
+
+// RevokeSession revokes session of current user
+// @Summary     Revoke session
+// @Description Log out one device of current user. Its refresh token stops working and access tokens are rejected right away.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Session ID"
+// @Success     200 {object} map[string]interface{} "Session revoked"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "Session not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/sessions/{id} [delete]
+func (c *AuthController) RevokeSession(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req := model.SessionActionRequest{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}
+
+	if err := c.authService.RevokeSession(ctx.Context(), userID, ctx.Params("id"), req); err != nil {
+		if err.Error() == _const.CodeSessionNotFound.Message() {
+			return ctx.Status(404).JSON(fiber.Map{
+				"code":    _const.CodeSessionNotFound.Code(),
+				"message": _const.CodeSessionNotFound.Message(),
+			})
+		}
+		c.logger.Error("Failed to revoke session", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to revoke session",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Session revoked",
+	})
+}
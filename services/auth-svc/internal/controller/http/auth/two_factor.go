@@ -0,0 +1,284 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// VerifyTwoFactorLogin completes login with TOTP or recovery code
+// @Summary     Verify two-factor login
+// @Description Complete login started by /auth/login with TOTP code or one-time recovery code. Wrong codes count as failed logins of user and lead to temporary block.
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.TwoFactorVerifyRequest true "Challenge token and code"
+// @Success     200 {object} map[string]interface{} "Login successful"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Invalid code or challenge"
+// @Failure     429 {object} map[string]string "Too many attempts or login temporarily blocked"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/login/2fa [post]
+func (c *AuthController) VerifyTwoFactorLogin(ctx *fiber.Ctx) error {
+	var req model.TwoFactorVerifyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.ChallengeToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Challenge token and code or recovery code are required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	result, err := c.authService.VerifyTwoFactorLogin(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to verify two-factor login", util.Error(err))
+
+		switch err.Error() {
+		case _const.CodeExceedLoginAttempts.Message(), _const.CodeBlockingAccount.Message(), _const.CodeLoginRate.Message():
+			code, _ := _const.CodeFromError(err, _const.CodeExceedLoginAttempts, _const.CodeBlockingAccount, _const.CodeLoginRate)
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		case _const.CodeLockingAccount.Message():
+			return ctx.Status(401).JSON(fiber.Map{
+				"code":    _const.CodeLockingAccount.Code(),
+				"message": _const.CodeLockingAccount.Message(),
+			})
+		}
+		return c.twoFactorError(ctx, err, "Failed to verify two-factor login")
+	}
+
+	return c.loginSuccess(ctx, result)
+}
+
+// EnrollTwoFactor starts TOTP enrollment
+// @Summary     Enroll two-factor authentication
+// @Description Generate TOTP secret and otpauth URI. Two-factor becomes active after confirm.
+// @Tags        auth
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} model.TwoFactorEnrollResponse "Pending TOTP secret"
+// @Failure     400 {object} map[string]string "Two-factor already enabled"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/2fa/enroll [post]
+func (c *AuthController) EnrollTwoFactor(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	enrollment, err := c.authService.EnrollTwoFactor(ctx.Context(), userID)
+	if err != nil {
+		c.logger.Error("Failed to enroll two-factor", util.Error(err))
+		return c.twoFactorError(ctx, err, "Failed to enroll two-factor")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":       _const.CodeSuccess.Code(),
+		"message":    "Scan the secret with authenticator app and confirm with a code",
+		"enrollment": enrollment,
+	})
+}
+
+// ConfirmTwoFactor confirms TOTP enrollment
+// @Summary     Confirm two-factor authentication
+// @Description Verify code from authenticator app, enable two-factor and return one-time recovery codes
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.TwoFactorCodeRequest true "TOTP code"
+// @Success     200 {object} model.RecoveryCodesResponse "Two-factor enabled"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/2fa/confirm [post]
+func (c *AuthController) ConfirmTwoFactor(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req, message := c.parseTwoFactorCodeRequest(ctx)
+	if message != "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": message,
+		})
+	}
+
+	codes, err := c.authService.ConfirmTwoFactor(ctx.Context(), userID, req)
+	if err != nil {
+		c.logger.Error("Failed to confirm two-factor", util.Error(err))
+		return c.twoFactorError(ctx, err, "Failed to confirm two-factor")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":           _const.CodeSuccess.Code(),
+		"message":        "Two-factor enabled. Store recovery codes in a safe place, they are shown only once",
+		"recovery_codes": codes.RecoveryCodes,
+	})
+}
+
+// DisableTwoFactor disables two-factor authentication
+// @Summary     Disable two-factor authentication
+// @Description Disable two-factor after checking password and TOTP or recovery code
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.TwoFactorDisableRequest true "Password and code"
+// @Success     200 {object} map[string]interface{} "Two-factor disabled"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/2fa/disable [post]
+func (c *AuthController) DisableTwoFactor(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.TwoFactorDisableRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Password == "" || (req.Code == "" && req.RecoveryCode == "") {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Password and code or recovery code are required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.authService.DisableTwoFactor(ctx.Context(), userID, req); err != nil {
+		c.logger.Error("Failed to disable two-factor", util.Error(err))
+
+		if err.Error() == _const.CodeWrongPassword.Message() {
+			return ctx.Status(400).JSON(fiber.Map{
+				"code":    _const.CodeWrongPassword.Code(),
+				"message": _const.CodeWrongPassword.Message(),
+			})
+		}
+		return c.twoFactorError(ctx, err, "Failed to disable two-factor")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Two-factor disabled",
+	})
+}
+
+// RegenerateRecoveryCodes replaces recovery codes
+// @Summary     Regenerate recovery codes
+// @Description Invalidate old recovery codes and return new ones after checking TOTP code
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.TwoFactorCodeRequest true "TOTP code"
+// @Success     200 {object} model.RecoveryCodesResponse "New recovery codes"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/2fa/recovery-codes [post]
+func (c *AuthController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req, message := c.parseTwoFactorCodeRequest(ctx)
+	if message != "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": message,
+		})
+	}
+
+	codes, err := c.authService.RegenerateRecoveryCodes(ctx.Context(), userID, req)
+	if err != nil {
+		c.logger.Error("Failed to regenerate recovery codes", util.Error(err))
+		return c.twoFactorError(ctx, err, "Failed to regenerate recovery codes")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":           _const.CodeSuccess.Code(),
+		"message":        "Recovery codes regenerated",
+		"recovery_codes": codes.RecoveryCodes,
+	})
+}
+
+// parseTwoFactorCodeRequest parses TOTP code request and returns message when request is invalid
+func (c *AuthController) parseTwoFactorCodeRequest(ctx *fiber.Ctx) (model.TwoFactorCodeRequest, string) {
+	var req model.TwoFactorCodeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return req, "Invalid request body"
+	}
+
+	if req.Code == "" {
+		return req, "Code is required"
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	return req, ""
+}
+
+// twoFactorError maps two-factor errors to response
+func (c *AuthController) twoFactorError(ctx *fiber.Ctx, err error, fallback string) error {
+	switch err.Error() {
+	case _const.CodeTwoFactorInvalid.Message():
+		return ctx.Status(401).JSON(fiber.Map{
+			"code":    _const.CodeTwoFactorInvalid.Code(),
+			"message": _const.CodeTwoFactorInvalid.Message(),
+		})
+	case _const.CodeTwoFactorChallenge.Message():
+		return ctx.Status(401).JSON(fiber.Map{
+			"code":    _const.CodeTwoFactorChallenge.Code(),
+			"message": _const.CodeTwoFactorChallenge.Message(),
+		})
+	case _const.CodeTwoFactorEnabled.Message(), _const.CodeTwoFactorDisabled.Message(), _const.CodeTwoFactorNoEnroll.Message():
+		code, _ := _const.CodeFromError(err, _const.CodeTwoFactorEnabled, _const.CodeTwoFactorDisabled, _const.CodeTwoFactorNoEnroll)
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    code.Code(),
+			"message": code.Message(),
+		})
+	case _const.CodeUserNotFound.Message():
+		return ctx.Status(404).JSON(fiber.Map{
+			"code":    _const.CodeUserNotFound.Code(),
+			"message": _const.CodeUserNotFound.Message(),
+		})
+	default:
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/gofiber/fiber/v2"
 	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
 	"github.com/taititans/bitzap/auth-svc/internal/util"
 )
 
@@ -41,3 +42,63 @@ func (c *AuthController) VerifyEmail(ctx *fiber.Ctx) error {
 		"message": "Email verified successfully",
 	})
 }
+
+// ResendVerification sends new email verification link, older links stop working
+// @Summary     Resend email verification
+// @Description Send new verification link to email of unverified account, throttled per email and per IP
+// @Tags        auth
+// @Accept      json
+// @Produce     json
+// @Param       request body model.ResendVerificationRequest true "Email"
+// @Success     200 {object} model.ResendVerificationResponse "Verification link sent"
+// @Failure     400 {object} map[string]string "Bad request"
+// @Failure     429 {object} map[string]string "Too many resend requests"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /auth/resend-verification [post]
+func (c *AuthController) ResendVerification(ctx *fiber.Ctx) error {
+	var req model.ResendVerificationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+
+	if req.Email == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Email is required",
+		})
+	}
+
+	// Get client info
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	resend, err := c.authService.ResendVerification(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to resend email verification", util.Error(err))
+
+		code, ok := _const.CodeFromError(err,
+			_const.CodeVerifyResendSoon,
+			_const.CodeVerifyResendLimit,
+		)
+		if !ok {
+			return ctx.Status(500).JSON(fiber.Map{
+				"code":    _const.CodeInternalError.Code(),
+				"message": "Failed to resend email verification",
+			})
+		}
+		return ctx.Status(code.HttpStatus()).JSON(fiber.Map{
+			"code":    code.Code(),
+			"message": code.Message(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "If the account exists and is not verified, a verification link has been sent",
+		"resend":  resend,
+	})
+}
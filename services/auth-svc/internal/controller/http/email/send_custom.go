@@ -12,9 +12,12 @@ import (
 // @Tags email
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body model.EmailData true "Custom email data"
 // @Success 200 {object} map[string]string "Email sent successfully"
 // @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Requires email:send permission"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /email/send [post]
 func (c *EmailController) SendCustomEmail(ctx *fiber.Ctx) error {
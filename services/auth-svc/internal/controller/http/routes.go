@@ -2,31 +2,141 @@ package http
 
 import (
 	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/admin"
 	"github.com/taititans/bitzap/auth-svc/internal/controller/http/auth"
 	"github.com/taititans/bitzap/auth-svc/internal/controller/http/email"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/tenant"
+	"github.com/taititans/bitzap/auth-svc/internal/controller/http/wellknown"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
 )
 
 // SetupAuthRoutes sets up authentication routes
-func SetupAuthRoutes(app *fiber.App, authController auth.AuthControllerInterface, emailController email.EmailControllerInterface) {
+func SetupAuthRoutes(
+	app *fiber.App,
+	authController auth.AuthControllerInterface,
+	emailController email.EmailControllerInterface,
+	adminController admin.AdminControllerInterface,
+	tenantController tenant.TenantControllerInterface,
+	wellKnownController wellknown.WellKnownControllerInterface,
+	authMiddleware fiber.Handler,
+	accessControl *middleware.AccessControl,
+	tenantContext fiber.Handler,
+	internalMiddleware fiber.Handler,
+) {
+	// Public key discovery for token verifiers
+	app.Get("/.well-known/jwks.json", wellKnownController.JWKS)
+	app.Get("/.well-known/openid-configuration", wellKnownController.OpenIDConfiguration)
+
+	// Tokens of unverified users are limited to basic account routes
+	verifiedEmail := accessControl.RequireVerifiedEmail()
+
 	// Auth group
 	authGroup := app.Group("/auth")
 
 	// Registration and login
 	authGroup.Post("/register", authController.Register)
 	authGroup.Post("/login", authController.Login)
+	authGroup.Post("/login/2fa", authController.VerifyTwoFactorLogin)
+	authGroup.Post("/otp/request", authController.RequestLoginOTP)
+	authGroup.Post("/otp/login", authController.LoginWithOTP)
+	authGroup.Get("/oauth/:provider/authorize", authController.OAuthAuthorize)
+	authGroup.Get("/oauth/:provider/callback", authController.OAuthCallback)
+	authGroup.Post("/refresh", authController.RefreshToken)
+	authGroup.Post("/logout", authMiddleware, authController.Logout)
+	authGroup.Post("/logout-all", authMiddleware, authController.LogoutAll)
 
 	// Email functionality
 	authGroup.Post("/forgot-password", authController.RequestPasswordReset)
 	authGroup.Post("/reset-password", authController.ResetPassword)
 	authGroup.Get("/verify-email", authController.VerifyEmail)
+	authGroup.Post("/resend-verification", authController.ResendVerification)
+	authGroup.Get("/security/report", authController.PreviewLoginReport)
+	authGroup.Post("/security/report", authController.ReportLogin)
+
+	// Current user profile management
+	authGroup.Get("/me", authMiddleware, authController.GetMe)
+	authGroup.Put("/me", authMiddleware, authController.UpdateMe)
+	authGroup.Put("/me/password", authMiddleware, authController.ChangeMyPassword)
+	authGroup.Post("/me/email", authMiddleware, authController.RequestEmailChange)
+	authGroup.Post("/me/reauth/code", authMiddleware, authController.RequestReauthCode)
+	authGroup.Post("/me/phone/verify/request", authMiddleware, authController.RequestPhoneVerification)
+	authGroup.Post("/me/phone/verify", authMiddleware, authController.VerifyPhone)
+	authGroup.Get("/confirm-email-change", authController.ConfirmEmailChange)
+	authGroup.Get("/me/activity", authMiddleware, authController.GetMyActivity)
+
+	// Data export and account deletion
+	authGroup.Post("/me/export", authMiddleware, verifiedEmail, authController.RequestDataExport)
+	authGroup.Get("/export/download", authMiddleware, authController.DownloadDataExport)
+	authGroup.Delete("/me", authMiddleware, authController.RequestAccountDeletion)
+	authGroup.Delete("/me/deletion", authMiddleware, authController.CancelAccountDeletion)
+
+	// Sessions of current user
+	authGroup.Get("/sessions", authMiddleware, authController.ListSessions)
+	authGroup.Delete("/sessions/:id", authMiddleware, authController.RevokeSession)
+
+	// Two-factor authentication
+	authGroup.Post("/2fa/enroll", authMiddleware, verifiedEmail, authController.EnrollTwoFactor)
+	authGroup.Post("/2fa/confirm", authMiddleware, authController.ConfirmTwoFactor)
+	authGroup.Post("/2fa/disable", authMiddleware, authController.DisableTwoFactor)
+	authGroup.Post("/2fa/recovery-codes", authMiddleware, authController.RegenerateRecoveryCodes)
+
+	// API keys
+	authGroup.Post("/api-keys", authMiddleware, verifiedEmail, authController.CreateAPIKey)
+	authGroup.Get("/api-keys", authMiddleware, verifiedEmail, authController.ListAPIKeys)
+	authGroup.Post("/api-keys/:id/rotate", authMiddleware, verifiedEmail, authController.RotateAPIKey)
+	authGroup.Delete("/api-keys/:id", authMiddleware, authController.RevokeAPIKey)
+
+	// Profile management by user ID
+	authGroup.Get("/profile/:user_id", authMiddleware, accessControl.RequirePermission("user", "read"), authController.GetProfile)
+	authGroup.Put("/profile/:user_id", authMiddleware, accessControl.RequirePermission("user", "update"), authController.UpdateProfile)
+	authGroup.Put("/password/:user_id", authMiddleware, accessControl.RequireRole(_const.RoleAdmin), authController.ChangePassword)
+
+	// Admin routes
+	adminGroup := app.Group("/admin", authMiddleware)
+	adminGroup.Get("/activity", accessControl.RequirePermission("activity", "read"), adminController.ListActivity)
+
+	// Admin user management
+	adminUsersGroup := adminGroup.Group("/users", accessControl.RequireRole(_const.RoleAdmin))
+	adminUsersGroup.Get("/", adminController.ListUsers)
+	adminUsersGroup.Get("/:id", adminController.GetUser)
+	adminUsersGroup.Post("/:id/lock", adminController.LockUser)
+	adminUsersGroup.Post("/:id/unlock", adminController.UnlockUser)
+	adminUsersGroup.Post("/:id/verify-email", adminController.VerifyUserEmail)
+	adminUsersGroup.Delete("/:id/sessions", adminController.RevokeUserSessions)
+	adminUsersGroup.Post("/:id/roles", adminController.AssignUserRole)
+	adminUsersGroup.Delete("/:id/roles/:role", adminController.RemoveUserRole)
+
+	// Tenant routes, tenant permissions are checked by per-tenant role.
+	// Tenant context is set per route, path params aren't known to group middlewares.
+	tenantGroup := app.Group("/tenants", authMiddleware)
+	tenantGroup.Post("/", verifiedEmail, tenantController.CreateTenant)
+	tenantGroup.Get("/", tenantController.ListMyTenants)
+	tenantGroup.Get("/:tenant_id", tenantContext, tenantController.GetTenant)
+	tenantGroup.Post("/:tenant_id/switch", tenantContext, tenantController.SwitchTenant)
+	tenantGroup.Get("/:tenant_id/members", tenantContext, tenantController.ListMembers)
+	tenantGroup.Put("/:tenant_id/members/:user_id", tenantContext, tenantController.UpdateMemberRole)
+	tenantGroup.Delete("/:tenant_id/members/:user_id", tenantContext, tenantController.RemoveMember)
+	tenantGroup.Post("/:tenant_id/invitations", verifiedEmail, tenantContext, tenantController.CreateInvitation)
+	tenantGroup.Get("/:tenant_id/invitations", tenantContext, tenantController.ListInvitations)
+	tenantGroup.Delete("/:tenant_id/invitations/:invitation_id", tenantContext, tenantController.RevokeInvitation)
+	tenantGroup.Post("/:tenant_id/api-keys", verifiedEmail, tenantContext, tenantController.CreateAPIKey)
+	tenantGroup.Get("/:tenant_id/api-keys", tenantContext, tenantController.ListAPIKeys)
+	tenantGroup.Post("/:tenant_id/api-keys/:id/rotate", tenantContext, tenantController.RotateAPIKey)
+	tenantGroup.Delete("/:tenant_id/api-keys/:id", tenantContext, tenantController.RevokeAPIKey)
+
+	// Invitation routes, emailed token identifies invitation
+	invitationGroup := app.Group("/invitations")
+	invitationGroup.Get("/preview", tenantController.PreviewInvitation)
+	invitationGroup.Post("/accept", authMiddleware, tenantController.AcceptInvitation)
+	invitationGroup.Post("/decline", tenantController.DeclineInvitation)
 
-	// Profile management
-	authGroup.Get("/profile/:user_id", authController.GetProfile)
-	authGroup.Put("/profile/:user_id", authController.UpdateProfile)
-	authGroup.Put("/password/:user_id", authController.ChangePassword)
+	// Internal routes for gateway and services
+	internalGroup := app.Group("/internal", internalMiddleware)
+	internalGroup.Post("/api-keys/verify", authController.VerifyAPIKey)
 
 	// Email routes
-	emailGroup := app.Group("/email")
+	emailGroup := app.Group("/email", authMiddleware, accessControl.RequirePermission("email", "send"))
 	emailGroup.Post("/verify", emailController.SendEmailVerification)
 	emailGroup.Post("/reset-password", emailController.SendPasswordReset)
 	emailGroup.Post("/welcome", emailController.SendWelcomeEmail)
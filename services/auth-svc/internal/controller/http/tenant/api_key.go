@@ -0,0 +1,191 @@
+package tenant
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// CreateAPIKey issues API key of tenant
+// @Summary     Create tenant API key
+// @Description Issue scoped API key owned by tenant for server-to-server use. Only admins and owners issue keys, and only with scopes granted to themselves. Key stops working when its issuer no longer manages tenant. The key is returned only once, store it securely.
+// @Tags        tenants
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string                     true  "Tenant ID"
+// @Param       X-Company-ID header string                     false "Tenant ID, must match tenant_id when sent"
+// @Param       request      body   model.CreateAPIKeyRequest  true  "Name, scopes and expiry"
+// @Success     200 {object} model.CreatedAPIKey "API key created"
+// @Failure     400 {object} map[string]string "Invalid scope or expiry, limit reached, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action, scope isn't granted to current user or email not verified"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/api-keys [post]
+func (c *TenantController) CreateAPIKey(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Name == "" || len(req.Name) > 100 || len(req.Scopes) == 0 {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Name (max 100 chars) and at least one scope are required",
+		})
+	}
+	req.TenantID = tenantCtx.TenantID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	created, err := c.tenantService.CreateAPIKey(ctx.Context(), tenantCtx.UserID, req)
+	if err != nil {
+		c.logger.Error("Failed to create tenant API key", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to create tenant API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key created. Store the key securely, it is shown only once",
+		"data":    created,
+	})
+}
+
+// ListAPIKeys lists API keys of tenant
+// @Summary     List tenant API keys
+// @Description List API keys of tenant with prefix, scopes, expiry, last use and issuer. Secrets are never returned. Only admins and owners can list them.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Success     200 {array}  entity.APIKey "API keys"
+// @Failure     400 {object} map[string]string "Tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/api-keys [get]
+func (c *TenantController) ListAPIKeys(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	apiKeys, err := c.tenantService.ListAPIKeys(ctx.Context(), tenantCtx.UserID, tenantCtx.TenantID)
+	if err != nil {
+		c.logger.Error("Failed to list tenant API keys", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to list tenant API keys")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    apiKeys,
+	})
+}
+
+// RotateAPIKey replaces secret of tenant API key
+// @Summary     Rotate tenant API key
+// @Description Issue new key of tenant with same name, scopes and expiry, owned by current user. Old key keeps working during grace period. Only admins and owners rotate, and only while scopes are granted to themselves.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Param       id           path   int    true  "API key ID"
+// @Success     200 {object} model.CreatedAPIKey "New API key"
+// @Failure     400 {object} map[string]string "Invalid API key ID, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action or scope isn't granted to current user"
+// @Failure     404 {object} map[string]string "Tenant or API key not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/api-keys/{id}/rotate [post]
+func (c *TenantController) RotateAPIKey(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	id, ok := c.apiKeyID(ctx)
+	if !ok {
+		return nil
+	}
+
+	created, err := c.tenantService.RotateAPIKey(ctx.Context(), tenantCtx.UserID, id, model.APIKeyActionRequest{
+		TenantID:  tenantCtx.TenantID,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	})
+	if err != nil {
+		c.logger.Error("Failed to rotate tenant API key", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to rotate tenant API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key rotated. Store the new key securely, it is shown only once",
+		"data":    created,
+	})
+}
+
+// RevokeAPIKey revokes API key of tenant
+// @Summary     Revoke tenant API key
+// @Description Revoke API key of tenant immediately. Only admins and owners revoke.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Param       id           path   int    true  "API key ID"
+// @Success     200 {object} map[string]interface{} "API key revoked"
+// @Failure     400 {object} map[string]string "Invalid API key ID, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant or API key not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/api-keys/{id} [delete]
+func (c *TenantController) RevokeAPIKey(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	id, ok := c.apiKeyID(ctx)
+	if !ok {
+		return nil
+	}
+
+	if err := c.tenantService.RevokeAPIKey(ctx.Context(), tenantCtx.UserID, id, model.APIKeyActionRequest{
+		TenantID:  tenantCtx.TenantID,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}); err != nil {
+		c.logger.Error("Failed to revoke tenant API key", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to revoke tenant API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "API key revoked",
+	})
+}
+
+// apiKeyID parses API key ID path param, responds with bad request when it's invalid
+func (c *TenantController) apiKeyID(ctx *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		_ = ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid API key ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,20 @@
+package tenant
+
+import (
+	"github.com/taititans/bitzap/auth-svc/internal/service"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// TenantController handles HTTP requests for tenants and their members
+type TenantController struct {
+	tenantService service.TenantService
+	logger        util.Logger
+}
+
+// NewTenantController creates a new tenant controller
+func NewTenantController(tenantService service.TenantService, logger util.Logger) TenantControllerInterface {
+	return &TenantController{
+		tenantService: tenantService,
+		logger:        logger,
+	}
+}
@@ -0,0 +1,24 @@
+package tenant
+
+import "github.com/gofiber/fiber/v2"
+
+// TenantControllerInterface defines the interface for tenant controller
+type TenantControllerInterface interface {
+	CreateTenant(ctx *fiber.Ctx) error
+	ListMyTenants(ctx *fiber.Ctx) error
+	GetTenant(ctx *fiber.Ctx) error
+	ListMembers(ctx *fiber.Ctx) error
+	UpdateMemberRole(ctx *fiber.Ctx) error
+	RemoveMember(ctx *fiber.Ctx) error
+	SwitchTenant(ctx *fiber.Ctx) error
+	CreateInvitation(ctx *fiber.Ctx) error
+	ListInvitations(ctx *fiber.Ctx) error
+	RevokeInvitation(ctx *fiber.Ctx) error
+	PreviewInvitation(ctx *fiber.Ctx) error
+	AcceptInvitation(ctx *fiber.Ctx) error
+	DeclineInvitation(ctx *fiber.Ctx) error
+	CreateAPIKey(ctx *fiber.Ctx) error
+	ListAPIKeys(ctx *fiber.Ctx) error
+	RotateAPIKey(ctx *fiber.Ctx) error
+	RevokeAPIKey(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,253 @@
+package tenant
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// CreateInvitation invites email to tenant
+// @Summary     Invite to tenant
+// @Description Send single-use invitation to join tenant with role to email. Only admins and owners invite, admins can't invite above member. Pending invitations take seats of tenant plan.
+// @Tags        tenants
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string                        true  "Tenant ID"
+// @Param       X-Company-ID header string                        false "Tenant ID, must match tenant_id when sent"
+// @Param       request      body   model.CreateInvitationRequest true  "Email and role"
+// @Success     200 {object} model.TenantInvitation "Invitation sent"
+// @Failure     400 {object} map[string]string "Invalid email or role, seat limit reached, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action or email not verified"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     409 {object} map[string]string "Already member or invitation pending"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/invitations [post]
+func (c *TenantController) CreateInvitation(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	var req model.CreateInvitationRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Email == "" || req.Role == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Email and role are required",
+		})
+	}
+	req.ActorID = tenantCtx.UserID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	invitation, err := c.tenantService.CreateInvitation(ctx.Context(), tenantCtx.TenantID, req)
+	if err != nil {
+		c.logger.Error("Failed to create tenant invitation", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to create tenant invitation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Invitation sent",
+		"data":    invitation,
+	})
+}
+
+// ListInvitations lists invitations of tenant
+// @Summary     List tenant invitations
+// @Description List invitations of tenant with their status, newest first. Only admins and owners can list them.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Success     200 {array}  model.TenantInvitation "Invitations"
+// @Failure     400 {object} map[string]string "Tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/invitations [get]
+func (c *TenantController) ListInvitations(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	invitations, err := c.tenantService.ListInvitations(ctx.Context(), tenantCtx.UserID, tenantCtx.TenantID)
+	if err != nil {
+		c.logger.Error("Failed to list tenant invitations", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to list tenant invitations")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    invitations,
+	})
+}
+
+// RevokeInvitation revokes pending invitation
+// @Summary     Revoke tenant invitation
+// @Description Revoke pending invitation so its link stops working and its seat is freed. Only admins and owners revoke.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id     path   string true  "Tenant ID"
+// @Param       X-Company-ID  header string false "Tenant ID, must match tenant_id when sent"
+// @Param       invitation_id path   int    true  "Invitation ID"
+// @Success     200 {object} map[string]interface{} "Invitation revoked"
+// @Failure     400 {object} map[string]string "Invitation no longer pending, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant or invitation not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/invitations/{invitation_id} [delete]
+func (c *TenantController) RevokeInvitation(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	invitationID, err := strconv.ParseUint(ctx.Params("invitation_id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid invitation ID",
+		})
+	}
+
+	if err := c.tenantService.RevokeInvitation(ctx.Context(), tenantCtx.TenantID, uint(invitationID), model.TenantActionRequest{
+		ActorID:   tenantCtx.UserID,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}); err != nil {
+		c.logger.Error("Failed to revoke tenant invitation", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to revoke tenant invitation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Invitation revoked",
+	})
+}
+
+// PreviewInvitation shows invitation of token
+// @Summary     Preview invitation
+// @Description Show pending invitation of emailed token. Registered tells whether invitee has account, registration can be pre-filled from email and completed with invitation_token.
+// @Tags        invitations
+// @Produce     json
+// @Param       token query string true "Invitation token"
+// @Success     200 {object} model.InvitationPreview "Invitation"
+// @Failure     400 {object} map[string]string "Invitation invalid or expired"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /invitations/preview [get]
+func (c *TenantController) PreviewInvitation(ctx *fiber.Ctx) error {
+	token := ctx.Query("token")
+	if token == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+	}
+
+	preview, err := c.tenantService.PreviewInvitation(ctx.Context(), token)
+	if err != nil {
+		c.logger.Error("Failed to preview invitation", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to preview invitation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    preview,
+	})
+}
+
+// AcceptInvitation accepts invitation for current user
+// @Summary     Accept invitation
+// @Description Join tenant of invitation with invited role. Invitation must be sent to email of current user and can be used once. Tenant becomes active when user has none.
+// @Tags        invitations
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.InvitationTokenRequest true "Invitation token"
+// @Success     200 {object} model.TenantMembership "Joined tenant"
+// @Failure     400 {object} map[string]string "Invitation invalid or expired, or seat limit reached"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Invitation was sent to another email"
+// @Failure     409 {object} map[string]string "Already member"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /invitations/accept [post]
+func (c *TenantController) AcceptInvitation(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	req, ok := c.invitationTokenRequest(ctx)
+	if !ok {
+		return nil
+	}
+	req.UserID = userID
+
+	membership, err := c.tenantService.AcceptInvitation(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to accept invitation", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to accept invitation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Invitation accepted",
+		"data":    membership,
+	})
+}
+
+// DeclineInvitation declines invitation
+// @Summary     Decline invitation
+// @Description Decline pending invitation of emailed token, its link stops working.
+// @Tags        invitations
+// @Accept      json
+// @Produce     json
+// @Param       request body model.InvitationTokenRequest true "Invitation token"
+// @Success     200 {object} map[string]interface{} "Invitation declined"
+// @Failure     400 {object} map[string]string "Invitation invalid or expired"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /invitations/decline [post]
+func (c *TenantController) DeclineInvitation(ctx *fiber.Ctx) error {
+	req, ok := c.invitationTokenRequest(ctx)
+	if !ok {
+		return nil
+	}
+
+	if err := c.tenantService.DeclineInvitation(ctx.Context(), req); err != nil {
+		c.logger.Error("Failed to decline invitation", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to decline invitation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Invitation declined",
+	})
+}
+
+// invitationTokenRequest parses invitation token body, responds with bad request when token is missing
+func (c *TenantController) invitationTokenRequest(ctx *fiber.Ctx) (model.InvitationTokenRequest, bool) {
+	var req model.InvitationTokenRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Token == "" {
+		_ = ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Token is required",
+		})
+		return req, false
+	}
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+	return req, true
+}
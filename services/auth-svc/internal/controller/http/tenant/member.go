@@ -0,0 +1,151 @@
+package tenant
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ListMembers lists members of tenant
+// @Summary     List tenant members
+// @Description List members of tenant with their roles. Only members can list them.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Success     200 {array}  model.TenantMember "Members"
+// @Failure     400 {object} map[string]string "Tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/members [get]
+func (c *TenantController) ListMembers(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	members, err := c.tenantService.ListMembers(ctx.Context(), tenantCtx.UserID, tenantCtx.TenantID)
+	if err != nil {
+		c.logger.Error("Failed to list tenant members", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to list tenant members")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    members,
+	})
+}
+
+// UpdateMemberRole changes role of tenant member
+// @Summary     Change tenant member role
+// @Description Change role of member to owner, admin, member or viewer. Admins change only members and viewers and can't grant roles above member. Tenant always keeps an owner.
+// @Tags        tenants
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string                        true  "Tenant ID"
+// @Param       X-Company-ID header string                        false "Tenant ID, must match tenant_id when sent"
+// @Param       user_id      path   int                           true  "User ID"
+// @Param       request      body   model.TenantMemberRoleRequest true  "Role"
+// @Success     200 {object} map[string]interface{} "Role changed"
+// @Failure     400 {object} map[string]string "Invalid role or last owner, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant or member not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/members/{user_id} [put]
+func (c *TenantController) UpdateMemberRole(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return c.invalidUserID(ctx)
+	}
+
+	var req model.TenantMemberRoleRequest
+	if err := ctx.BodyParser(&req); err != nil || req.Role == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Role is required",
+		})
+	}
+	req.ActorID = tenantCtx.UserID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	if err := c.tenantService.UpdateMemberRole(ctx.Context(), tenantCtx.TenantID, userID, req); err != nil {
+		c.logger.Error("Failed to change tenant member role", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to change tenant member role")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Role changed",
+	})
+}
+
+// RemoveMember removes member from tenant
+// @Summary     Remove tenant member
+// @Description Remove member from tenant, or leave tenant with own user ID. Admins remove only members and viewers. Tenant always keeps an owner.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Param       user_id      path   int    true  "User ID"
+// @Success     200 {object} map[string]interface{} "Member removed"
+// @Failure     400 {object} map[string]string "Last owner, or tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Tenant role doesn't allow this action"
+// @Failure     404 {object} map[string]string "Tenant or member not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/members/{user_id} [delete]
+func (c *TenantController) RemoveMember(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	userID, err := parseUserID(ctx)
+	if err != nil {
+		return c.invalidUserID(ctx)
+	}
+
+	if err := c.tenantService.RemoveMember(ctx.Context(), tenantCtx.TenantID, userID, model.TenantActionRequest{
+		ActorID:   tenantCtx.UserID,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	}); err != nil {
+		c.logger.Error("Failed to remove tenant member", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to remove tenant member")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Member removed",
+	})
+}
+
+// invalidUserID responds to invalid user ID path param
+func (c *TenantController) invalidUserID(ctx *fiber.Ctx) error {
+	return ctx.Status(400).JSON(fiber.Map{
+		"code":    _const.CodeBadRequest.Code(),
+		"message": "Invalid user ID",
+	})
+}
+
+// parseUserID parses user_id path param
+func parseUserID(ctx *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Params("user_id"), 10, 32)
+	return uint(id), err
+}
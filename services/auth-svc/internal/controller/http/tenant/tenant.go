@@ -0,0 +1,226 @@
+package tenant
+
+import (
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/middleware"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// CreateTenant creates tenant
+// @Summary     Create tenant
+// @Description Create tenant owned by current user. Slug is derived from name when empty. It becomes active tenant when user has none.
+// @Tags        tenants
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body model.CreateTenantRequest true "Name and optional slug"
+// @Success     200 {object} entity.Tenant "Tenant created"
+// @Failure     400 {object} map[string]string "Bad request, invalid slug or tenant limit reached"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     403 {object} map[string]string "Email not verified"
+// @Failure     409 {object} map[string]string "Slug already taken"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants [post]
+func (c *TenantController) CreateTenant(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	var req model.CreateTenantRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		c.logger.Error("Failed to parse request body", util.Error(err))
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Invalid request body",
+		})
+	}
+	if req.Name == "" {
+		return ctx.Status(400).JSON(fiber.Map{
+			"code":    _const.CodeBadRequest.Code(),
+			"message": "Name is required",
+		})
+	}
+
+	req.UserID = userID
+	req.IPAddress = ctx.IP()
+	req.UserAgent = ctx.Get("User-Agent")
+
+	tenant, err := c.tenantService.CreateTenant(ctx.Context(), req)
+	if err != nil {
+		c.logger.Error("Failed to create tenant", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to create tenant")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Tenant created",
+		"data":    tenant,
+	})
+}
+
+// ListMyTenants lists tenants of current user
+// @Summary     List my tenants
+// @Description List tenants current user is member of with its role there. Active tenant is marked active.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {array}  model.TenantMembership "Tenants"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants [get]
+func (c *TenantController) ListMyTenants(ctx *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	tenants, err := c.tenantService.ListMyTenants(ctx.Context(), userID)
+	if err != nil {
+		c.logger.Error("Failed to list tenants", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to list tenants")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    tenants,
+	})
+}
+
+// GetTenant gets tenant
+// @Summary     Get tenant
+// @Description Get tenant with role of current user there. Only members can see tenant.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Success     200 {object} model.TenantMembership "Tenant"
+// @Failure     400 {object} map[string]string "Tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id} [get]
+func (c *TenantController) GetTenant(ctx *fiber.Ctx) error {
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	tenant, err := c.tenantService.GetTenant(ctx.Context(), tenantCtx.UserID, tenantCtx.TenantID)
+	if err != nil {
+		c.logger.Error("Failed to get tenant", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to get tenant")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": _const.CodeSuccess.Message(),
+		"data":    tenant,
+	})
+}
+
+// SwitchTenant switches active tenant of current user
+// @Summary     Switch tenant
+// @Description Make tenant active for current user and reissue tokens of current session with tenant ID and role. Presented access token and current refresh token stop working.
+// @Tags        tenants
+// @Produce     json
+// @Security    BearerAuth
+// @Param       tenant_id    path   string true  "Tenant ID"
+// @Param       X-Company-ID header string false "Tenant ID, must match tenant_id when sent"
+// @Success     200 {object} model.TokenPair "New tokens"
+// @Failure     400 {object} map[string]string "Tenant in header and path mismatch"
+// @Failure     401 {object} map[string]string "Unauthorized"
+// @Failure     404 {object} map[string]string "Tenant not found"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /tenants/{tenant_id}/switch [post]
+func (c *TenantController) SwitchTenant(ctx *fiber.Ctx) error {
+	claims, ok := middleware.GetTokenClaims(ctx)
+	if !ok {
+		return c.userCtxNotFound(ctx)
+	}
+
+	tenantCtx, ok := middleware.GetTenantContext(ctx)
+	if !ok {
+		return c.tenantCtxNotFound(ctx)
+	}
+
+	tokens, err := c.tenantService.SwitchTenant(ctx.Context(), claims, tenantCtx.TenantID, model.SessionClient{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get("User-Agent"),
+	})
+	if err != nil {
+		c.logger.Error("Failed to switch tenant", util.Error(err))
+		return c.tenantError(ctx, err, "Failed to switch tenant")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"code":    _const.CodeSuccess.Code(),
+		"message": "Tenant switched",
+		"token":   tokens,
+	})
+}
+
+// tenantError maps tenant errors to response
+func (c *TenantController) tenantError(ctx *fiber.Ctx, err error, fallback string) error {
+	code, ok := _const.CodeFromError(err,
+		_const.CodeBadRequest,
+		_const.CodeInvalidToken,
+		_const.CodeUserNotFound,
+		_const.CodeTenantExisted,
+		_const.CodeTenantNotFound,
+		_const.CodeUserNotInTenant,
+		_const.CodeTenantSlugInvalid,
+		_const.CodeTenantRoleInvalid,
+		_const.CodeTenantForbidden,
+		_const.CodeTenantLastOwner,
+		_const.CodeTenantLimit,
+		_const.CodeTenantSeatLimit,
+		_const.CodeInvitationInvalid,
+		_const.CodeInvitationNotFound,
+		_const.CodeInvitationEmail,
+		_const.CodeInvitationPending,
+		_const.CodeAlreadyTenantMember,
+		_const.CodeAPIKeyNotFound,
+		_const.CodeAPIKeyScope,
+		_const.CodeAPIKeyLimit,
+		_const.CodeAPIKeyNotGranted,
+	)
+	if !ok {
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": fallback,
+		})
+	}
+
+	status := code.HttpStatus()
+	switch code {
+	case _const.CodeTenantExisted:
+		status = 409
+	case _const.CodeTenantNotFound, _const.CodeUserNotInTenant, _const.CodeUserNotFound:
+		status = 404
+	}
+	return ctx.Status(status).JSON(fiber.Map{
+		"code":    code.Code(),
+		"message": code.Message(),
+	})
+}
+
+// tenantCtxNotFound responds when tenant of request is missing in context
+func (c *TenantController) tenantCtxNotFound(ctx *fiber.Ctx) error {
+	return ctx.Status(_const.CodeCompanyCtxNotFound.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeCompanyCtxNotFound.Code(),
+		"message": _const.CodeCompanyCtxNotFound.Message(),
+	})
+}
+
+// userCtxNotFound responds when authenticated user is missing in context
+func (c *TenantController) userCtxNotFound(ctx *fiber.Ctx) error {
+	return ctx.Status(_const.CodeUserCtxNotFound.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeUserCtxNotFound.Code(),
+		"message": _const.CodeUserCtxNotFound.Message(),
+	})
+}
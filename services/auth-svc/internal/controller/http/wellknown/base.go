@@ -0,0 +1,20 @@
+package wellknown
+
+import (
+	"github.com/taititans/bitzap/auth-svc/internal/service"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// WellKnownController handles public key discovery HTTP requests
+type WellKnownController struct {
+	wellKnownService service.WellKnownService
+	logger           util.Logger
+}
+
+// NewWellKnownController creates a new well-known controller
+func NewWellKnownController(wellKnownService service.WellKnownService, logger util.Logger) WellKnownControllerInterface {
+	return &WellKnownController{
+		wellKnownService: wellKnownService,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,9 @@
+package wellknown
+
+import "github.com/gofiber/fiber/v2"
+
+// WellKnownControllerInterface defines the interface for well-known controller
+type WellKnownControllerInterface interface {
+	JWKS(ctx *fiber.Ctx) error
+	OpenIDConfiguration(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,44 @@
+package wellknown
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// JWKS returns public keys for verifying access tokens
+// @Summary     JSON Web Key Set
+// @Description Public RS256 / ES256 keys, selected by token kid header. Includes keys scheduled for activation and keys not yet retired.
+// @Tags        well-known
+// @Produce     json
+// @Success     200 {object} model.JWKS "Public keys"
+// @Failure     500 {object} map[string]string "Internal server error"
+// @Router      /.well-known/jwks.json [get]
+func (c *WellKnownController) JWKS(ctx *fiber.Ctx) error {
+	jwks, err := c.wellKnownService.JWKS()
+	if err != nil {
+		c.logger.Error("Failed to build JWKS", util.Error(err))
+		return ctx.Status(500).JSON(fiber.Map{
+			"code":    _const.CodeInternalError.Code(),
+			"message": "Failed to get signing keys",
+		})
+	}
+
+	if cacheSecond := c.wellKnownService.JWKSCacheSecond(); cacheSecond > 0 {
+		ctx.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", cacheSecond))
+	}
+	return ctx.JSON(jwks)
+}
+
+// OpenIDConfiguration returns OpenID discovery metadata
+// @Summary     OpenID discovery
+// @Description Issuer metadata pointing token verifiers to JWKS
+// @Tags        well-known
+// @Produce     json
+// @Success     200 {object} model.OpenIDConfiguration "Discovery metadata"
+// @Router      /.well-known/openid-configuration [get]
+func (c *WellKnownController) OpenIDConfiguration(ctx *fiber.Ctx) error {
+	return ctx.JSON(c.wellKnownService.OpenIDConfiguration())
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyScope is resource/action pair granted to API key, same shape as UserPermission
+type APIKeyScope struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// APIKeyScopes is a custom type for JSON scope list
+type APIKeyScopes []APIKeyScope
+
+// Value implements driver.Valuer interface
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner interface
+func (s *APIKeyScopes) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported API key scopes type %T", value)
+	}
+}
+
+// Has reports whether scopes grant action on resource
+func (s APIKeyScopes) Has(resource, action string) bool {
+	for _, scope := range s {
+		if scope.Resource == resource && scope.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is key of user, or of tenant when TenantID is set. Keys of tenant are managed by
+// its admins and owners, UserID is admin who issued or last rotated the key.
+type APIKey struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	UserID     uint           `json:"user_id" gorm:"not null;index"`
+	TenantID   *string        `json:"tenant_id" gorm:"type:uuid;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Prefix     string         `json:"prefix" gorm:"uniqueIndex;not null"`
+	KeyHash    string         `json:"-" gorm:"not null"`
+	Scopes     APIKeyScopes   `json:"scopes" gorm:"type:jsonb;not null"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	LastUsedIP string         `json:"last_used_ip"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
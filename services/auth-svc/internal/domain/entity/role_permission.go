@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// RolePermission grants resource/action to every user having the role.
+// "*" as resource or action matches any value.
+type RolePermission struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	RoleID    uint      `json:"role_id" gorm:"not null;uniqueIndex:idx_role_permissions_unique"`
+	Resource  string    `json:"resource" gorm:"not null;uniqueIndex:idx_role_permissions_unique"`
+	Action    string    `json:"action" gorm:"not null;uniqueIndex:idx_role_permissions_unique"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Tenant is organization users belong to. Slug is unique and can be used as subdomain.
+type Tenant struct {
+	ID           string    `json:"id" gorm:"type:uuid;primaryKey"`
+	Name         string    `json:"name" gorm:"not null"`
+	Slug         string    `json:"slug" gorm:"uniqueIndex;not null"`
+	Plan         string    `json:"plan"`
+	CustomDomain string    `json:"custom_domain"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"-"`
+}
+
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// TenantMember is membership of user in tenant with per-tenant role
+type TenantMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  string    `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_tenant_members_user"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_tenant_members_user"`
+	Role      string    `json:"role" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	User   *User   `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+func (TenantMember) TableName() string {
+	return "tenant_members"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// TenantInvitation is single-use invitation to join tenant sent by email.
+// Only hash of its token is stored.
+type TenantInvitation struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	TenantID   string     `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	Email      string     `json:"email" gorm:"not null"`
+	Role       string     `json:"role" gorm:"not null"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;not null"`
+	InvitedBy  *uint      `json:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	DeclinedAt *time.Time `json:"declined_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"-"`
+
+	// Relationships
+	Tenant  *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	Inviter *User   `json:"-" gorm:"foreignKey:InvitedBy"`
+}
+
+func (TenantInvitation) TableName() string {
+	return "tenant_invitations"
+}
@@ -3,20 +3,26 @@ package entity
 import "time"
 
 type User struct {
-	ID              uint       `json:"id" gorm:"primaryKey"`
-	Email           string     `json:"email" gorm:"uniqueIndex;not null"`
-	Username        string     `json:"username" gorm:"uniqueIndex;not null"`
-	PasswordHash    string     `json:"-" gorm:"not null"`
-	Firstname       string     `json:"firstname"`
-	Lastname        string     `json:"lastname"`
-	Phone           string     `json:"phone"`
-	AvatarURL       string     `json:"avatar_url"`
-	IsActive        bool       `json:"is_active"`
-	IsVerified      bool       `json:"is_verified"`
-	EmailVerifiedAt *time.Time `json:"email_verified_at"`
-	LastLoginAt     *time.Time `json:"last_login_at"`
-	CreatedAt       *time.Time `json:"created_at"`
-	UpdatedAt       *time.Time `json:"updated_at"`
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	Email              string     `json:"email" gorm:"uniqueIndex;not null"`
+	Username           string     `json:"username" gorm:"uniqueIndex;not null"`
+	PasswordHash       string     `json:"-" gorm:"not null"`
+	Firstname          string     `json:"firstname"`
+	Lastname           string     `json:"lastname"`
+	Phone              string     `json:"phone"`
+	AvatarURL          string     `json:"avatar_url"`
+	IsActive           bool       `json:"is_active"`
+	IsVerified         bool       `json:"is_verified"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt    *time.Time `json:"phone_verified_at"`
+	LastLoginAt        *time.Time `json:"last_login_at"`
+	TwoFactorEnabled   bool       `json:"two_factor_enabled"`
+	TwoFactorSecret    string     `json:"-"`
+	TwoFactorEnabledAt *time.Time `json:"two_factor_enabled_at"`
+	DeletionDueAt      *time.Time `json:"deletion_due_at"`
+	ActiveTenantID     *string    `json:"active_tenant_id" gorm:"type:uuid"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
 
 	// Relationships
 	Roles        []UserRole        `json:"roles,omitempty" gorm:"foreignKey:UserID"`
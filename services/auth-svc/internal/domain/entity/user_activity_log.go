@@ -34,9 +34,12 @@ func (j *JSONMap) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, j)
 }
 
+// UserActivityLog records action of user. UserID is nil once user is purged,
+// logs of purged user share Pseudonym instead.
 type UserActivityLog struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserID    uint           `json:"user_id" gorm:"not null;index"`
+	UserID    *uint          `json:"user_id" gorm:"index"`
+	Pseudonym string         `json:"pseudonym,omitempty" gorm:"index"`
 	Action    string         `json:"action" gorm:"not null"`
 	Resource  string         `json:"resource" gorm:"not null"`
 	IPAddress string         `json:"ip_address"`
@@ -46,9 +49,20 @@ type UserActivityLog struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationship
-	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	User *UserSummary `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
 func (UserActivityLog) TableName() string {
 	return "user_activity_logs"
 }
+
+// UserSummary identifies user in activity logs without exposing rest of its account
+type UserSummary struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (UserSummary) TableName() string {
+	return "users"
+}
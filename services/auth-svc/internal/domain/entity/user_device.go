@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// UserDevice is device a user has logged in from, identified by
+// fingerprint of user agent and IP subnet
+type UserDevice struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_devices_fingerprint"`
+	Fingerprint string    `json:"-" gorm:"not null;uniqueIndex:idx_user_devices_fingerprint"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"-"`
+}
+
+func (UserDevice) TableName() string {
+	return "user_devices"
+}
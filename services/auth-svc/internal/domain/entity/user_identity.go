@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// UserIdentity is an OAuth2 / OIDC account linked to user
+type UserIdentity struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"not null"`
+	Subject   string    `json:"subject" gorm:"not null"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -11,6 +11,7 @@ type UserRole struct {
 
 	// Relationship
 	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Role Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
 }
 
 func (UserRole) TableName() string {
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// Basic operations
+	Create(ctx context.Context, apiKey *entity.APIKey) error
+	GetByID(ctx context.Context, id uint) (*entity.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+
+	// User API key operations, keys of tenants are excluded
+	ListByUserID(ctx context.Context, userID uint) ([]*entity.APIKey, error)
+	CountActiveByUserID(ctx context.Context, userID uint) (int64, error)
+
+	// Tenant API key operations
+	ListByTenantID(ctx context.Context, tenantID string) ([]*entity.APIKey, error)
+	CountActiveByTenantID(ctx context.Context, tenantID string) (int64, error)
+
+	// Lifecycle
+	Revoke(ctx context.Context, id uint) error
+	RevokeByUserID(ctx context.Context, userID uint) error
+	SetExpiresAt(ctx context.Context, id uint, expiresAt time.Time) error
+	UpdateLastUsed(ctx context.Context, id uint, ip string, usedAt time.Time) error
+}
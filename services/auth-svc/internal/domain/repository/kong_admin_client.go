@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// KongAdminClient defines operations on Kong Admin API
+type KongAdminClient interface {
+	// Consumers
+	UpsertConsumer(ctx context.Context, consumer model.KongConsumer) error
+	DeleteConsumer(ctx context.Context, username string) error
+	ListConsumers(ctx context.Context, tag string) ([]model.KongConsumer, error)
+
+	// JWT credentials
+	ListJWTCredentials(ctx context.Context, username string) ([]model.KongJWTCredential, error)
+	UpsertJWTCredential(ctx context.Context, username string, credential model.KongJWTCredential) error
+	DeleteJWTCredential(ctx context.Context, username, key string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// OAuthProvider defines the interface for OAuth2 / OIDC identity provider
+type OAuthProvider interface {
+	// Build authorize URL with state, PKCE verifier and OIDC nonce
+	AuthCodeURL(ctx context.Context, state, codeVerifier, nonce string) (string, error)
+
+	// Exchange authorization code and fetch user profile, OIDC ID token must carry nonce
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.OAuthProfile, error)
+}
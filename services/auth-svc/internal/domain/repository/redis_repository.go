@@ -13,6 +13,13 @@ type RedisRepository interface {
 	// Get gets a value by key
 	Get(ctx context.Context, key string) (string, error)
 
+	// GetDel gets a value by key and deletes it atomically
+	GetDel(ctx context.Context, key string) (string, error)
+
+	// SetIfGreater atomically sets key to value with expiration when key is missing or
+	// holds a smaller number, and reports whether key was set
+	SetIfGreater(ctx context.Context, key string, value int64, expiration time.Duration) (bool, error)
+
 	// Del deletes a key
 	Del(ctx context.Context, key string) error
 
@@ -21,4 +28,28 @@ type RedisRepository interface {
 
 	// Expire sets expiration for a key
 	Expire(ctx context.Context, key string, expiration time.Duration) error
+
+	// Incr increments counter by one
+	Incr(ctx context.Context, key string) (int64, error)
+
+	// TTL gets remaining time to live of a key
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// SAdd adds members to a set
+	SAdd(ctx context.Context, key string, members ...string) error
+
+	// SMembers gets all members of a set
+	SMembers(ctx context.Context, key string) ([]string, error)
+
+	// SRem removes members from a set
+	SRem(ctx context.Context, key string, members ...string) error
+
+	// ZAdd adds member with score to a sorted set
+	ZAdd(ctx context.Context, key string, score float64, member string) error
+
+	// ZRemRangeByScore removes members of a sorted set within score range
+	ZRemRangeByScore(ctx context.Context, key, min, max string) error
+
+	// ZCard gets number of members in a sorted set
+	ZCard(ctx context.Context, key string) (int64, error)
 }
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements APIKeyRepository
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create creates a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *entity.APIKey) error {
+	return r.db.WithContext(ctx).Create(apiKey).Error
+}
+
+// GetByID gets API key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	err := r.db.WithContext(ctx).First(&apiKey, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetByPrefix gets API key by its public prefix
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&apiKey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// ListByUserID lists API keys of user without keys of tenants, newest first
+func (r *apiKeyRepository) ListByUserID(ctx context.Context, userID uint) ([]*entity.APIKey, error) {
+	var apiKeys []*entity.APIKey
+	err := r.db.WithContext(ctx).Where("user_id = ? AND tenant_id IS NULL", userID).Order("id DESC").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// CountActiveByUserID counts API keys of user which are not revoked nor expired, without keys of tenants
+func (r *apiKeyRepository) CountActiveByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("user_id = ? AND tenant_id IS NULL AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// ListByTenantID lists API keys of tenant, newest first
+func (r *apiKeyRepository) ListByTenantID(ctx context.Context, tenantID string) ([]*entity.APIKey, error) {
+	var apiKeys []*entity.APIKey
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("id DESC").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// CountActiveByTenantID counts API keys of tenant which are not revoked nor expired
+func (r *apiKeyRepository) CountActiveByTenantID(ctx context.Context, tenantID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("tenant_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())", tenantID).
+		Count(&count).Error
+	return count, err
+}
+
+// Revoke marks API key as revoked
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// RevokeByUserID revokes every API key of user
+func (r *apiKeyRepository) RevokeByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// SetExpiresAt sets expiry time of API key
+func (r *apiKeyRepository) SetExpiresAt(ctx context.Context, id uint, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Update("expires_at", expiresAt).Error
+}
+
+// UpdateLastUsed records last use time and client IP of API key
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id uint, ip string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ip,
+		}).Error
+}
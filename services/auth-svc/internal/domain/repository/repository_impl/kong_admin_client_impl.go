@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// kongPageSize is page size used when listing Kong entities
+const kongPageSize = 1000
+
+// kongAdminClient implements KongAdminClient over Kong Admin API
+type kongAdminClient struct {
+	baseURL    string
+	adminToken string
+	httpClient *http.Client
+	logger     util.Logger
+}
+
+// kongPage represents paginated Kong Admin API list response
+type kongPage struct {
+	Data   json.RawMessage `json:"data"`
+	Offset string          `json:"offset"`
+}
+
+// NewKongAdminClient creates Kong Admin API client
+func NewKongAdminClient(kongConfig config.KongConfig, logger util.Logger) repository.KongAdminClient {
+	return &kongAdminClient{
+		baseURL:    strings.TrimRight(kongConfig.AdminURL, "/"),
+		adminToken: kongConfig.AdminToken,
+		httpClient: &http.Client{Timeout: time.Duration(kongConfig.TimeoutSecond) * time.Second},
+		logger:     logger,
+	}
+}
+
+// UpsertConsumer creates or updates consumer by username
+func (c *kongAdminClient) UpsertConsumer(ctx context.Context, consumer model.KongConsumer) error {
+	return c.do(ctx, http.MethodPut, "/consumers/"+url.PathEscape(consumer.Username), consumer, nil)
+}
+
+// DeleteConsumer deletes consumer with its credentials, missing consumer is not an error
+func (c *kongAdminClient) DeleteConsumer(ctx context.Context, username string) error {
+	return c.do(ctx, http.MethodDelete, "/consumers/"+url.PathEscape(username), nil, nil)
+}
+
+// ListConsumers lists consumers having tag
+func (c *kongAdminClient) ListConsumers(ctx context.Context, tag string) ([]model.KongConsumer, error) {
+	var consumers []model.KongConsumer
+	err := c.list(ctx, "/consumers", url.Values{"tags": {tag}}, func(data json.RawMessage) error {
+		var page []model.KongConsumer
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		consumers = append(consumers, page...)
+		return nil
+	})
+	return consumers, err
+}
+
+// ListJWTCredentials lists JWT credentials of consumer
+func (c *kongAdminClient) ListJWTCredentials(ctx context.Context, username string) ([]model.KongJWTCredential, error) {
+	var credentials []model.KongJWTCredential
+	err := c.list(ctx, "/consumers/"+url.PathEscape(username)+"/jwt", url.Values{}, func(data json.RawMessage) error {
+		var page []model.KongJWTCredential
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		credentials = append(credentials, page...)
+		return nil
+	})
+	return credentials, err
+}
+
+// UpsertJWTCredential creates or updates JWT credential of consumer by key
+func (c *kongAdminClient) UpsertJWTCredential(ctx context.Context, username string, credential model.KongJWTCredential) error {
+	path := "/consumers/" + url.PathEscape(username) + "/jwt/" + url.PathEscape(credential.Key)
+	return c.do(ctx, http.MethodPut, path, credential, nil)
+}
+
+// DeleteJWTCredential deletes JWT credential of consumer by key
+func (c *kongAdminClient) DeleteJWTCredential(ctx context.Context, username, key string) error {
+	path := "/consumers/" + url.PathEscape(username) + "/jwt/" + url.PathEscape(key)
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// list walks all pages of list endpoint
+func (c *kongAdminClient) list(ctx context.Context, path string, query url.Values, handle func(data json.RawMessage) error) error {
+	query.Set("size", fmt.Sprint(kongPageSize))
+	for {
+		var page kongPage
+		if err := c.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &page); err != nil {
+			return err
+		}
+		if err := handle(page.Data); err != nil {
+			return err
+		}
+		if page.Offset == "" {
+			return nil
+		}
+		query.Set("offset", page.Offset)
+	}
+}
+
+// do sends JSON request to Admin API and decodes response into dest when set
+func (c *kongAdminClient) do(ctx context.Context, method, path string, body, dest interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Kong-Admin-Token", c.adminToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if method == http.MethodDelete && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kong admin %s %s failed with status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if dest == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
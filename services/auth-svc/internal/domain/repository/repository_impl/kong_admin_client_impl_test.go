@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/kongtest"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+func newTestKongAdminClient(server *kongtest.Server, adminToken string) repository.KongAdminClient {
+	return NewKongAdminClient(config.KongConfig{
+		AdminURL:      server.URL + "/",
+		AdminToken:    adminToken,
+		TimeoutSecond: 5,
+	}, util.NewZapLogger(zap.NewNop()))
+}
+
+func TestKongAdminClientConsumers(t *testing.T) {
+	server := kongtest.NewServer()
+	defer server.Close()
+	client := newTestKongAdminClient(server, "")
+	ctx := context.Background()
+
+	for _, username := range []string{"user-1", "user-2", "user-3"} {
+		if err := client.UpsertConsumer(ctx, model.KongConsumer{Username: username, Tags: []string{"auth-svc"}}); err != nil {
+			t.Fatalf("UpsertConsumer(%s) error = %v", username, err)
+		}
+	}
+	server.AddConsumer(model.KongConsumer{Username: "bitzap-app"})
+
+	// Stand-in returns 2 consumers per page, so listing follows offset
+	consumers, err := client.ListConsumers(ctx, "auth-svc")
+	if err != nil {
+		t.Fatalf("ListConsumers() error = %v", err)
+	}
+	var usernames []string
+	for _, consumer := range consumers {
+		usernames = append(usernames, consumer.Username)
+	}
+	if want := []string{"user-1", "user-2", "user-3"}; !reflect.DeepEqual(usernames, want) {
+		t.Errorf("ListConsumers() = %v, want %v", usernames, want)
+	}
+
+	if err := client.DeleteConsumer(ctx, "user-2"); err != nil {
+		t.Fatalf("DeleteConsumer() error = %v", err)
+	}
+	if err := client.DeleteConsumer(ctx, "user-2"); err != nil {
+		t.Errorf("DeleteConsumer() of missing consumer error = %v, want nil", err)
+	}
+	if want := []string{"bitzap-app", "user-1", "user-3"}; !reflect.DeepEqual(server.Consumers(), want) {
+		t.Errorf("consumers = %v, want %v", server.Consumers(), want)
+	}
+}
+
+func TestKongAdminClientJWTCredentials(t *testing.T) {
+	server := kongtest.NewServer()
+	defer server.Close()
+	client := newTestKongAdminClient(server, "")
+	ctx := context.Background()
+
+	if err := client.UpsertConsumer(ctx, model.KongConsumer{Username: "user-1"}); err != nil {
+		t.Fatalf("UpsertConsumer() error = %v", err)
+	}
+	for _, key := range []string{"user-1:a", "user-1:b", "user-1:c"} {
+		credential := model.KongJWTCredential{Key: key, Algorithm: "RS256", RSAPublicKey: "pem-" + key}
+		if err := client.UpsertJWTCredential(ctx, "user-1", credential); err != nil {
+			t.Fatalf("UpsertJWTCredential(%s) error = %v", key, err)
+		}
+	}
+
+	if err := client.DeleteJWTCredential(ctx, "user-1", "user-1:b"); err != nil {
+		t.Fatalf("DeleteJWTCredential() error = %v", err)
+	}
+
+	credentials, err := client.ListJWTCredentials(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("ListJWTCredentials() error = %v", err)
+	}
+	var keys []string
+	for _, credential := range credentials {
+		keys = append(keys, credential.Key)
+	}
+	if want := []string{"user-1:a", "user-1:c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ListJWTCredentials() keys = %v, want %v", keys, want)
+	}
+	if credential, _ := server.Credential("user-1", "user-1:c"); credential.RSAPublicKey != "pem-user-1:c" {
+		t.Errorf("rsa_public_key = %q, want %q", credential.RSAPublicKey, "pem-user-1:c")
+	}
+}
+
+func TestKongAdminClientErrors(t *testing.T) {
+	server := kongtest.NewServer()
+	defer server.Close()
+	server.AdminToken = "admin-token"
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		adminToken string
+		call       func(client repository.KongAdminClient) error
+		wantErr    string
+	}{
+		{
+			name:       "missing admin token",
+			adminToken: "",
+			call: func(client repository.KongAdminClient) error {
+				return client.UpsertConsumer(ctx, model.KongConsumer{Username: "user-1"})
+			},
+			wantErr: "status 401",
+		},
+		{
+			name:       "credential of unknown consumer",
+			adminToken: "admin-token",
+			call: func(client repository.KongAdminClient) error {
+				return client.UpsertJWTCredential(ctx, "user-404", model.KongJWTCredential{Key: "user-404"})
+			},
+			wantErr: "status 404",
+		},
+		{
+			name:       "valid admin token",
+			adminToken: "admin-token",
+			call: func(client repository.KongAdminClient) error {
+				return client.UpsertConsumer(ctx, model.KongConsumer{Username: "user-1"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(newTestKongAdminClient(server, tt.adminToken))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
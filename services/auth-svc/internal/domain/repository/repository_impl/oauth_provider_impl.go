@@ -0,0 +1,509 @@
+package repository
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	oauthHTTPTimeout = 10 * time.Second
+
+	// oidcJWKSRefreshInterval limits refetching JWKS of provider for unknown key IDs
+	oidcJWKSRefreshInterval = time.Minute
+	// oidcClockSkew is leeway allowed for time claims of ID tokens
+	oidcClockSkew = time.Minute
+
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubAPIURL   = "https://api.github.com"
+)
+
+// NewOAuthProviders creates enabled OAuth providers keyed by provider name
+func NewOAuthProviders(oauthConfig config.OAuthConfig, logger util.Logger) map[string]repository.OAuthProvider {
+	httpClient := &http.Client{Timeout: oauthHTTPTimeout}
+	providers := make(map[string]repository.OAuthProvider)
+
+	for _, providerConfig := range oauthConfig.Providers {
+		if !providerConfig.Enabled {
+			continue
+		}
+
+		redirectURL := fmt.Sprintf("%s/auth/oauth/%s/callback", strings.TrimRight(oauthConfig.RedirectBaseURL, "/"), providerConfig.Name)
+
+		switch providerConfig.Type {
+		case config.OAuthProviderOIDC:
+			providers[providerConfig.Name] = &oidcProvider{
+				config:      providerConfig,
+				redirectURL: redirectURL,
+				httpClient:  httpClient,
+				logger:      logger,
+			}
+		case config.OAuthProviderGitHub:
+			providers[providerConfig.Name] = &githubProvider{
+				config:      providerConfig,
+				redirectURL: redirectURL,
+				httpClient:  httpClient,
+				logger:      logger,
+			}
+		default:
+			logger.Warn("Unknown OAuth provider type, provider disabled",
+				util.String("provider", providerConfig.Name),
+				util.String("type", providerConfig.Type),
+			)
+		}
+	}
+
+	return providers
+}
+
+// oidcProvider implements generic OpenID Connect provider. ID token returned with
+// access token is verified against JWKS, issuer, client ID and nonce of login,
+// profile is read from userinfo endpoint for the same subject.
+type oidcProvider struct {
+	config      config.OAuthProviderConfig
+	redirectURL string
+	httpClient  *http.Client
+	logger      util.Logger
+
+	mu            sync.Mutex
+	resolved      bool
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// oidcDiscovery represents fields used from OpenID provider metadata
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcIDTokenClaims represents claims of ID token checked on login
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce           string `json:"nonce"`
+	AuthorizedParty string `json:"azp"`
+}
+
+// oidcUserInfo represents standard claims returned by userinfo endpoint
+type oidcUserInfo struct {
+	Subject       string      `json:"sub"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	GivenName     string      `json:"given_name"`
+	FamilyName    string      `json:"family_name"`
+	Name          string      `json:"name"`
+	Picture       string      `json:"picture"`
+}
+
+// AuthCodeURL builds authorize URL with state, PKCE challenge and nonce
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, codeVerifier, nonce string) (string, error) {
+	if err := p.resolve(ctx); err != nil {
+		return "", err
+	}
+	return p.oauth2Config().AuthCodeURL(state,
+		oauth2.S256ChallengeOption(codeVerifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), nil
+}
+
+// Exchange exchanges code for token, verifies ID token and fetches user info of its subject
+func (p *oidcProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.OAuthProfile, error) {
+	if err := p.resolve(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2Config().Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		p.logger.Error("Failed to exchange OIDC code", util.String("provider", p.config.Name), util.Error(err))
+		return nil, err
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("oidc provider %s returned no id_token", p.config.Name)
+	}
+	idToken, err := p.verifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		p.logger.Warn("Invalid OIDC ID token", util.String("provider", p.config.Name), util.Error(err))
+		return nil, err
+	}
+
+	var info oidcUserInfo
+	if err := getOAuthJSON(ctx, p.oauth2Config().Client(ctx, token), p.config.UserInfoURL, &info); err != nil {
+		p.logger.Error("Failed to get OIDC user info", util.String("provider", p.config.Name), util.Error(err))
+		return nil, err
+	}
+	// Userinfo must describe subject of ID token (OIDC Core 5.3.2)
+	if info.Subject == "" || info.Subject != idToken.Subject {
+		return nil, fmt.Errorf("oidc provider %s returned userinfo of another subject", p.config.Name)
+	}
+
+	firstName, lastName := info.GivenName, info.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName = splitName(info.Name)
+	}
+
+	return &model.OAuthProfile{
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: parseEmailVerified(info.EmailVerified),
+		FirstName:     firstName,
+		LastName:      lastName,
+		AvatarURL:     info.Picture,
+	}, nil
+}
+
+// verifyIDToken checks signature, issuer, audience, expiry and nonce of ID token
+func (p *oidcProvider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (*oidcIDTokenClaims, error) {
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.verificationKey(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Token issued to several clients must name this one as authorized party
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("id_token azp %q doesn't match client", claims.AuthorizedParty)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("id_token nonce doesn't match login")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id_token has empty subject")
+	}
+
+	return claims, nil
+}
+
+// verificationKey returns public key of provider by key ID. JWKS is refetched
+// for unknown key IDs so provider key rotations are picked up.
+func (p *oidcProvider) verificationKey(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < oidcJWKSRefreshInterval {
+		return nil, fmt.Errorf("unknown id_token key %q", kid)
+	}
+
+	var jwks model.JWKS
+	if err := getOAuthJSON(ctx, p.httpClient, p.config.JWKSURL, &jwks); err != nil {
+		p.logger.Error("Failed to get OIDC JWKS", util.String("provider", p.config.Name), util.Error(err))
+		return nil, err
+	}
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			p.logger.Warn("Skipping unsupported OIDC JWK",
+				util.String("provider", p.config.Name),
+				util.String("kid", jwk.KeyID),
+				util.Error(err),
+			)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown id_token key %q", kid)
+}
+
+// lookupKey finds cached key by ID, single key of JWKS matches tokens without kid
+func (p *oidcProvider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// resolve fills missing endpoints from issuer discovery document once
+func (p *oidcProvider) resolve(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config.Issuer == "" {
+		return fmt.Errorf("oidc provider %s has no issuer to validate id_token", p.config.Name)
+	}
+	if p.resolved || (p.config.AuthURL != "" && p.config.TokenURL != "" && p.config.UserInfoURL != "" && p.config.JWKSURL != "") {
+		p.resolved = true
+		return nil
+	}
+
+	var discovery oidcDiscovery
+	discoveryURL := strings.TrimRight(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getOAuthJSON(ctx, p.httpClient, discoveryURL, &discovery); err != nil {
+		p.logger.Error("Failed to discover OIDC provider", util.String("provider", p.config.Name), util.Error(err))
+		return err
+	}
+
+	// Discovery document must be issued for configured issuer (OIDC Discovery 4.3)
+	if discovery.Issuer != p.config.Issuer {
+		p.logger.Error("OIDC discovery issuer mismatch",
+			util.String("provider", p.config.Name),
+			util.String("issuer", p.config.Issuer),
+			util.String("discovered", discovery.Issuer),
+		)
+		return fmt.Errorf("oidc provider %s discovery issuer %q doesn't match configured issuer %q", p.config.Name, discovery.Issuer, p.config.Issuer)
+	}
+
+	if p.config.AuthURL == "" {
+		p.config.AuthURL = discovery.AuthorizationEndpoint
+	}
+	if p.config.TokenURL == "" {
+		p.config.TokenURL = discovery.TokenEndpoint
+	}
+	if p.config.UserInfoURL == "" {
+		p.config.UserInfoURL = discovery.UserInfoEndpoint
+	}
+	if p.config.JWKSURL == "" {
+		p.config.JWKSURL = discovery.JWKSURI
+	}
+	p.resolved = true
+
+	return nil
+}
+
+func (p *oidcProvider) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.redirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.config.AuthURL,
+			TokenURL: p.config.TokenURL,
+		},
+	}
+}
+
+// githubProvider implements GitHub OAuth2 provider which doesn't support OIDC
+type githubProvider struct {
+	config      config.OAuthProviderConfig
+	redirectURL string
+	httpClient  *http.Client
+	logger      util.Logger
+}
+
+// githubUser represents GitHub user response
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// githubEmail represents GitHub user email response
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// AuthCodeURL builds authorize URL with state and PKCE challenge, GitHub doesn't support nonce
+func (p *githubProvider) AuthCodeURL(ctx context.Context, state, codeVerifier, nonce string) (string, error) {
+	return p.oauth2Config().AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+// Exchange exchanges code for token and fetches user with primary email
+func (p *githubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.OAuthProfile, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2Config().Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		p.logger.Error("Failed to exchange GitHub code", util.Error(err))
+		return nil, err
+	}
+	client := p.oauth2Config().Client(ctx, token)
+
+	var user githubUser
+	if err := getOAuthJSON(ctx, client, p.apiURL()+"/user", &user); err != nil {
+		p.logger.Error("Failed to get GitHub user", util.Error(err))
+		return nil, err
+	}
+
+	var emails []githubEmail
+	if err := getOAuthJSON(ctx, client, p.apiURL()+"/user/emails", &emails); err != nil {
+		p.logger.Error("Failed to get GitHub emails", util.Error(err))
+		return nil, err
+	}
+
+	profile := &model.OAuthProfile{
+		Subject:   strconv.FormatInt(user.ID, 10),
+		AvatarURL: user.AvatarURL,
+	}
+	profile.FirstName, profile.LastName = splitName(user.Name)
+
+	for _, email := range emails {
+		if email.Primary {
+			profile.Email = email.Email
+			profile.EmailVerified = email.Verified
+			break
+		}
+	}
+
+	return profile, nil
+}
+
+// apiURL returns GitHub API base URL, UserInfoURL overrides it for local mocks
+func (p *githubProvider) apiURL() string {
+	if p.config.UserInfoURL != "" {
+		return strings.TrimRight(p.config.UserInfoURL, "/")
+	}
+	return githubAPIURL
+}
+
+func (p *githubProvider) oauth2Config() *oauth2.Config {
+	authURL, tokenURL := p.config.AuthURL, p.config.TokenURL
+	if authURL == "" {
+		authURL = githubAuthURL
+	}
+	if tokenURL == "" {
+		tokenURL = githubTokenURL
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.redirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+	}
+}
+
+// getOAuthJSON sends GET request and decodes JSON response
+func getOAuthJSON(ctx context.Context, client *http.Client, url string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth request to %s failed with status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+// parseJWK converts RSA or EC JSON Web Key to public key
+func parseJWK(jwk model.JWK) (interface{}, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("EC point isn't on curve")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.KeyType)
+	}
+}
+
+// parseEmailVerified accepts boolean or string email_verified claim
+func parseEmailVerified(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+// splitName splits full name into first and last name
+func splitName(name string) (string, string) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", ""
+	}
+	return parts[0], strings.Join(parts[1:], " ")
+}
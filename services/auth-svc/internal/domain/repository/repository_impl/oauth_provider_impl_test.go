@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// oidcTestServer serves discovery, token, userinfo and JWKS endpoints of OIDC provider.
+// Token endpoint returns idToken built from claims of test case.
+type oidcTestServer struct {
+	*httptest.Server
+	key             *rsa.PrivateKey
+	idToken         func(issuer string) string
+	userInfoSubject string
+	// discoveryIssuer overrides issuer in discovery document
+	discoveryIssuer string
+}
+
+func newOIDCTestServer(t *testing.T) *oidcTestServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	s := &oidcTestServer{key: key, userInfoSubject: "subject-1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := s.URL
+		if s.discoveryIssuer != "" {
+			issuer = s.discoveryIssuer
+		}
+		_ = json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                issuer,
+			AuthorizationEndpoint: s.URL + "/authorize",
+			TokenEndpoint:         s.URL + "/token",
+			UserInfoEndpoint:      s.URL + "/userinfo",
+			JWKSURI:               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{"access_token": "access-token", "token_type": "Bearer"}
+		if idToken := s.idToken(s.URL); idToken != "" {
+			body["id_token"] = idToken
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"sub":            s.userInfoSubject,
+			"email":          "alice@example.com",
+			"email_verified": true,
+			"name":           "Alice Example",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(model.JWKS{Keys: []model.JWK{{
+			KeyType:   "RSA",
+			KeyID:     "key-1",
+			Use:       "sig",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// sign signs claims with key of server or given key under kid
+func (s *oidcTestServer) sign(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func (s *oidcTestServer) provider() *oidcProvider {
+	return &oidcProvider{
+		config: config.OAuthProviderConfig{
+			Name:         "mock",
+			Type:         config.OAuthProviderOIDC,
+			ClientID:     "client-1",
+			ClientSecret: "secret",
+			Issuer:       s.URL,
+			AuthURL:      s.URL + "/authorize",
+			TokenURL:     s.URL + "/token",
+			UserInfoURL:  s.URL + "/userinfo",
+			JWKSURL:      s.URL + "/jwks",
+		},
+		redirectURL: "http://localhost/auth/oauth/mock/callback",
+		httpClient:  s.Client(),
+		logger:      util.NewZapLogger(zap.NewNop()),
+	}
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	server := newOIDCTestServer(t)
+	defer server.Close()
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	validClaims := func(issuer string) jwt.MapClaims {
+		now := time.Now()
+		return jwt.MapClaims{
+			"iss":   issuer,
+			"sub":   "subject-1",
+			"aud":   "client-1",
+			"exp":   now.Add(time.Hour).Unix(),
+			"iat":   now.Unix(),
+			"nonce": "nonce-1",
+		}
+	}
+
+	tests := []struct {
+		name            string
+		idToken         func(issuer string) string
+		userInfoSubject string
+		wantErr         string
+	}{
+		{
+			name: "valid id token",
+			idToken: func(issuer string) string {
+				return server.sign(t, server.key, "key-1", validClaims(issuer))
+			},
+		},
+		{
+			name: "several audiences with matching azp",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["aud"] = []string{"client-1", "client-2"}
+				claims["azp"] = "client-1"
+				return server.sign(t, server.key, "key-1", claims)
+			},
+		},
+		{
+			name:    "missing id token",
+			idToken: func(issuer string) string { return "" },
+			wantErr: "no id_token",
+		},
+		{
+			name: "signed by another key",
+			idToken: func(issuer string) string {
+				return server.sign(t, otherKey, "key-1", validClaims(issuer))
+			},
+			wantErr: "signature is invalid",
+		},
+		{
+			name: "unknown key ID",
+			idToken: func(issuer string) string {
+				return server.sign(t, otherKey, "key-2", validClaims(issuer))
+			},
+			wantErr: "unknown id_token key",
+		},
+		{
+			name: "wrong issuer",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["iss"] = "https://evil.example.com"
+				return server.sign(t, server.key, "key-1", claims)
+			},
+			wantErr: "issuer",
+		},
+		{
+			name: "issued to another client",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["aud"] = "client-2"
+				return server.sign(t, server.key, "key-1", claims)
+			},
+			wantErr: "audience",
+		},
+		{
+			name: "several audiences without azp",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["aud"] = []string{"client-1", "client-2"}
+				return server.sign(t, server.key, "key-1", claims)
+			},
+			wantErr: "azp",
+		},
+		{
+			name: "expired",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return server.sign(t, server.key, "key-1", claims)
+			},
+			wantErr: "expired",
+		},
+		{
+			name: "nonce of another login",
+			idToken: func(issuer string) string {
+				claims := validClaims(issuer)
+				claims["nonce"] = "nonce-2"
+				return server.sign(t, server.key, "key-1", claims)
+			},
+			wantErr: "nonce",
+		},
+		{
+			name: "unsigned",
+			idToken: func(issuer string) string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims(issuer)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				return token
+			},
+			wantErr: "signing method",
+		},
+		{
+			name: "userinfo of another subject",
+			idToken: func(issuer string) string {
+				return server.sign(t, server.key, "key-1", validClaims(issuer))
+			},
+			userInfoSubject: "subject-2",
+			wantErr:         "another subject",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.idToken = tt.idToken
+			server.userInfoSubject = "subject-1"
+			if tt.userInfoSubject != "" {
+				server.userInfoSubject = tt.userInfoSubject
+			}
+
+			profile, err := server.provider().Exchange(context.Background(), "code", "verifier", "nonce-1")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Exchange() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			want := &model.OAuthProfile{
+				Subject:       "subject-1",
+				Email:         "alice@example.com",
+				EmailVerified: true,
+				FirstName:     "Alice",
+				LastName:      "Example",
+			}
+			if *profile != *want {
+				t.Errorf("Exchange() = %+v, want %+v", profile, want)
+			}
+		})
+	}
+}
+
+func TestOIDCProviderAuthCodeURL(t *testing.T) {
+	server := newOIDCTestServer(t)
+	defer server.Close()
+
+	authURL, err := server.provider().AuthCodeURL(context.Background(), "state-1", "verifier", "nonce-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	for _, param := range []string{"state=state-1", "nonce=nonce-1", "code_challenge_method=S256"} {
+		if !strings.Contains(authURL, param) {
+			t.Errorf("AuthCodeURL() = %s, want containing %s", authURL, param)
+		}
+	}
+}
+
+func TestOIDCProviderDiscovery(t *testing.T) {
+	tests := []struct {
+		name string
+		// discoveryIssuer returns issuer of discovery document from server URL
+		discoveryIssuer func(url string) string
+		wantErr         bool
+	}{
+		{name: "issuer matches", discoveryIssuer: func(url string) string { return url }},
+		{name: "issuer of other provider", discoveryIssuer: func(string) string { return "https://attacker.example.com" }, wantErr: true},
+		{name: "issuer with trailing slash", discoveryIssuer: func(url string) string { return url + "/" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newOIDCTestServer(t)
+			defer server.Close()
+			server.discoveryIssuer = tt.discoveryIssuer(server.URL)
+
+			provider := server.provider()
+			provider.config.AuthURL, provider.config.TokenURL = "", ""
+			provider.config.UserInfoURL, provider.config.JWKSURL = "", ""
+
+			err := provider.resolve(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("resolve() error = nil, want issuer mismatch")
+				}
+				if provider.config.Issuer != server.URL || provider.config.TokenURL != "" {
+					t.Errorf("config after mismatch = %+v, want unchanged", provider.config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve() error = %v", err)
+			}
+			if provider.config.TokenURL != server.URL+"/token" || provider.config.JWKSURL != server.URL+"/jwks" {
+				t.Errorf("resolved endpoints = %+v", provider.config)
+			}
+		})
+	}
+}
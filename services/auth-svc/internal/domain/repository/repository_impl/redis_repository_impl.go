@@ -9,6 +9,17 @@ import (
 	"github.com/taititans/bitzap/auth-svc/internal/util"
 )
 
+// setIfGreaterScript sets KEYS[1] to ARGV[1] with ARGV[2] milliseconds expiration unless
+// it already holds a number equal to or above ARGV[1]
+var setIfGreaterScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]))
+if current and current >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
 // redisRepository implements repository.RedisRepository
 type redisRepository struct {
 	client *redis.Client
@@ -60,6 +71,40 @@ func (r *redisRepository) Get(ctx context.Context, key string) (string, error) {
 	return value, nil
 }
 
+// GetDel gets a value by key and deletes it atomically
+func (r *redisRepository) GetDel(ctx context.Context, key string) (string, error) {
+	value, err := r.client.GetDel(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			// Key doesn't exist
+			return "", nil
+		}
+		r.logger.Error("Failed to get and delete Redis key",
+			util.String("key", key),
+			util.Error(err))
+		return "", err
+	}
+
+	r.logger.Info("Successfully got and deleted Redis key",
+		util.String("key", key))
+
+	return value, nil
+}
+
+// SetIfGreater atomically sets key to value with expiration when key is missing or
+// holds a smaller number, and reports whether key was set
+func (r *redisRepository) SetIfGreater(ctx context.Context, key string, value int64, expiration time.Duration) (bool, error) {
+	set, err := setIfGreaterScript.Run(ctx, r.client, []string{key}, value, expiration.Milliseconds()).Int()
+	if err != nil {
+		r.logger.Error("Failed to set Redis key if greater",
+			util.String("key", key),
+			util.Error(err))
+		return false, err
+	}
+
+	return set == 1, nil
+}
+
 // Del deletes a key
 func (r *redisRepository) Del(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
@@ -105,3 +150,116 @@ func (r *redisRepository) Expire(ctx context.Context, key string, expiration tim
 
 	return nil
 }
+
+// Incr increments counter by one
+func (r *redisRepository) Incr(ctx context.Context, key string) (int64, error) {
+	value, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		r.logger.Error("Failed to increment Redis key",
+			util.String("key", key),
+			util.Error(err))
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// TTL gets remaining time to live of a key
+func (r *redisRepository) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		r.logger.Error("Failed to get TTL of Redis key",
+			util.String("key", key),
+			util.Error(err))
+		return 0, err
+	}
+
+	return ttl, nil
+}
+
+// SAdd adds members to a set
+func (r *redisRepository) SAdd(ctx context.Context, key string, members ...string) error {
+	err := r.client.SAdd(ctx, key, toInterfaces(members)...).Err()
+	if err != nil {
+		r.logger.Error("Failed to add members to Redis set",
+			util.String("key", key),
+			util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// SMembers gets all members of a set
+func (r *redisRepository) SMembers(ctx context.Context, key string) ([]string, error) {
+	members, err := r.client.SMembers(ctx, key).Result()
+	if err != nil {
+		r.logger.Error("Failed to get members of Redis set",
+			util.String("key", key),
+			util.Error(err))
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// SRem removes members from a set
+func (r *redisRepository) SRem(ctx context.Context, key string, members ...string) error {
+	err := r.client.SRem(ctx, key, toInterfaces(members)...).Err()
+	if err != nil {
+		r.logger.Error("Failed to remove members from Redis set",
+			util.String("key", key),
+			util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ZAdd adds member with score to a sorted set
+func (r *redisRepository) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	err := r.client.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+	if err != nil {
+		r.logger.Error("Failed to add member to Redis sorted set",
+			util.String("key", key),
+			util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ZRemRangeByScore removes members of a sorted set within score range
+func (r *redisRepository) ZRemRangeByScore(ctx context.Context, key, min, max string) error {
+	err := r.client.ZRemRangeByScore(ctx, key, min, max).Err()
+	if err != nil {
+		r.logger.Error("Failed to remove members from Redis sorted set",
+			util.String("key", key),
+			util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ZCard gets number of members in a sorted set
+func (r *redisRepository) ZCard(ctx context.Context, key string) (int64, error) {
+	count, err := r.client.ZCard(ctx, key).Result()
+	if err != nil {
+		r.logger.Error("Failed to count members of Redis sorted set",
+			util.String("key", key),
+			util.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// toInterfaces converts string slice to interface slice for Redis commands
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
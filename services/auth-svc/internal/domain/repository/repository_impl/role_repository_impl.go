@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// roleRepository implements RoleRepository
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(db *gorm.DB) repository.RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// Create creates a new role
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+// GetByID gets role by ID
+func (r *roleRepository) GetByID(ctx context.Context, id uint) (*entity.Role, error) {
+	var role entity.Role
+	err := r.db.WithContext(ctx).First(&role, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetByName gets role by name
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetPermissions gets permissions of role
+func (r *roleRepository) GetPermissions(ctx context.Context, roleID uint) ([]*entity.RolePermission, error) {
+	var permissions []*entity.RolePermission
+	err := r.db.WithContext(ctx).Where("role_id = ?", roleID).Order("resource, action").Find(&permissions).Error
+	return permissions, err
+}
+
+// GetPermissionsByUserID gets permissions of all roles assigned to user
+func (r *roleRepository) GetPermissionsByUserID(ctx context.Context, userID uint) ([]*entity.RolePermission, error) {
+	var permissions []*entity.RolePermission
+	err := r.db.WithContext(ctx).
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Find(&permissions).Error
+	return permissions, err
+}
+
+// AddPermission grants permission to role, existing permission is kept
+func (r *roleRepository) AddPermission(ctx context.Context, roleID uint, resource, action string) error {
+	permission := &entity.RolePermission{
+		RoleID:   roleID,
+		Resource: resource,
+		Action:   action,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(permission).Error
+}
+
+// RemovePermission removes permission from role
+func (r *roleRepository) RemovePermission(ctx context.Context, roleID uint, resource, action string) error {
+	return r.db.WithContext(ctx).Where("role_id = ? AND resource = ? AND action = ?", roleID, resource, action).Delete(&entity.RolePermission{}).Error
+}
+
+// List gets all roles with their permissions
+func (r *roleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	var roles []*entity.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Order("id").Find(&roles).Error
+	return roles, err
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// NewSMSProvider creates SMS provider configured by provider name
+func NewSMSProvider(smsConfig config.SMSConfig, logger util.Logger) repository.SMSProvider {
+	switch smsConfig.Provider {
+	case "", config.SMSProviderConsole:
+		return &consoleSMSProvider{config: smsConfig, logger: logger}
+	case config.SMSProviderFile:
+		return &fileSMSProvider{config: smsConfig, logger: logger}
+	default:
+		logger.Warn("Unknown SMS provider, falling back to console",
+			util.String("provider", smsConfig.Provider),
+		)
+		return &consoleSMSProvider{config: smsConfig, logger: logger}
+	}
+}
+
+// consoleSMSProvider writes SMS messages to log for local development
+type consoleSMSProvider struct {
+	config config.SMSConfig
+	logger util.Logger
+}
+
+// SendSMS logs SMS message instead of sending it
+func (p *consoleSMSProvider) SendSMS(ctx context.Context, phone, message string) error {
+	p.logger.Info("SMS message (console provider)",
+		util.String("sender", p.config.Sender),
+		util.String("phone", phone),
+		util.String("message", message),
+	)
+	return nil
+}
+
+// fileSMSProvider appends SMS messages to a file for local development
+type fileSMSProvider struct {
+	config config.SMSConfig
+	logger util.Logger
+	mu     sync.Mutex
+}
+
+// SendSMS appends SMS message to configured file
+func (p *fileSMSProvider) SendSMS(ctx context.Context, phone, message string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(p.config.FilePath), 0o755); err != nil {
+		p.logger.Error("Failed to create SMS file directory", util.Error(err))
+		return err
+	}
+
+	file, err := os.OpenFile(p.config.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		p.logger.Error("Failed to open SMS file", util.Error(err))
+		return err
+	}
+	defer file.Close()
+
+	line := fmt.Sprintf("%s\tfrom=%s\tto=%s\t%s\n", time.Now().Format(time.RFC3339), p.config.Sender, phone, message)
+	if _, err := file.WriteString(line); err != nil {
+		p.logger.Error("Failed to write SMS file", util.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pendingInvitation matches invitations not accepted, declined nor revoked
+const pendingInvitation = "accepted_at IS NULL AND declined_at IS NULL AND revoked_at IS NULL"
+
+// tenantInvitationRepository implements TenantInvitationRepository
+type tenantInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantInvitationRepository creates a new tenant invitation repository
+func NewTenantInvitationRepository(db *gorm.DB) repository.TenantInvitationRepository {
+	return &tenantInvitationRepository{db: db}
+}
+
+// CreateWithinSeats creates invitation unless members and pending invitations take all
+// seats of tenant, returns ErrSeatLimit then. Tenant row is locked until commit so
+// concurrent invitations can't overbook it. Seats below one mean no limit.
+func (r *tenantInvitationRepository) CreateWithinSeats(ctx context.Context, invitation *entity.TenantInvitation, seats int, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		members, err := lockSeats(tx, invitation.TenantID)
+		if err != nil {
+			return err
+		}
+
+		var pending int64
+		err = tx.Model(&entity.TenantInvitation{}).
+			Where("tenant_id = ? AND expires_at > ? AND "+pendingInvitation, invitation.TenantID, now).
+			Count(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		if seats > 0 && members+pending >= int64(seats) {
+			return repository.ErrSeatLimit
+		}
+		return tx.Create(invitation).Error
+	})
+}
+
+// AcceptWithinSeats accepts pending invitation and creates membership in one transaction,
+// returns ErrInvitationClosed when invitation isn't pending anymore and ErrSeatLimit when
+// members take all seats of tenant. Tenant row is locked until commit like in CreateWithinSeats.
+func (r *tenantInvitationRepository) AcceptWithinSeats(ctx context.Context, invitationID uint, member *entity.TenantMember, seats int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		members, err := lockSeats(tx, member.TenantID)
+		if err != nil {
+			return err
+		}
+
+		// Invitation already holds seat, plan could be downgraded since it was sent
+		if seats > 0 && members >= int64(seats) {
+			return repository.ErrSeatLimit
+		}
+
+		result := tx.Model(&entity.TenantInvitation{}).
+			Where("id = ? AND tenant_id = ? AND expires_at > NOW() AND "+pendingInvitation, invitationID, member.TenantID).
+			Update("accepted_at", gorm.Expr("NOW()"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrInvitationClosed
+		}
+
+		return tx.Create(member).Error
+	})
+}
+
+// lockSeats locks tenant row with SELECT ... FOR UPDATE and counts its members
+func lockSeats(tx *gorm.DB, tenantID string) (int64, error) {
+	var tenant entity.Tenant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", tenantID).First(&tenant).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var members int64
+	err = tx.Model(&entity.TenantMember{}).Where("tenant_id = ?", tenantID).Count(&members).Error
+	return members, err
+}
+
+// GetByID gets invitation of tenant by ID
+func (r *tenantInvitationRepository) GetByID(ctx context.Context, tenantID string, id uint) (*entity.TenantInvitation, error) {
+	var invitation entity.TenantInvitation
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetByTokenHash gets invitation by token hash with its tenant and inviter
+func (r *tenantInvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.TenantInvitation, error) {
+	var invitation entity.TenantInvitation
+	err := r.db.WithContext(ctx).Preload("Tenant").Preload("Inviter").Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingByEmail gets pending invitation of tenant sent to email
+func (r *tenantInvitationRepository) GetPendingByEmail(ctx context.Context, tenantID, email string, now time.Time) (*entity.TenantInvitation, error) {
+	var invitation entity.TenantInvitation
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND email = ? AND expires_at > ? AND "+pendingInvitation, tenantID, email, now).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListByTenant gets invitations of tenant, newest first
+func (r *tenantInvitationRepository) ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantInvitation, error) {
+	var invitations []*entity.TenantInvitation
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// MarkDeclined declines pending invitation, returns false when it's no longer pending
+func (r *tenantInvitationRepository) MarkDeclined(ctx context.Context, id uint) (bool, error) {
+	return r.close(ctx, id, "declined_at")
+}
+
+// MarkRevoked revokes pending invitation, returns false when it's no longer pending
+func (r *tenantInvitationRepository) MarkRevoked(ctx context.Context, id uint) (bool, error) {
+	return r.close(ctx, id, "revoked_at")
+}
+
+// close sets closing time column of pending invitation so token can be used only once
+func (r *tenantInvitationRepository) close(ctx context.Context, id uint, column string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.TenantInvitation{}).
+		Where("id = ? AND "+pendingInvitation, id).
+		Update(column, gorm.Expr("NOW()"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tenantMemberRepository implements TenantMemberRepository
+type tenantMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantMemberRepository creates a new tenant member repository
+func NewTenantMemberRepository(db *gorm.DB) repository.TenantMemberRepository {
+	return &tenantMemberRepository{db: db}
+}
+
+// Create creates a new tenant membership
+func (r *tenantMemberRepository) Create(ctx context.Context, member *entity.TenantMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+// Get gets membership of user in tenant
+func (r *tenantMemberRepository) Get(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error) {
+	var member entity.TenantMember
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND user_id = ?", tenantID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListByUser gets memberships of user with their tenants, oldest first
+func (r *tenantMemberRepository) ListByUser(ctx context.Context, userID uint) ([]*entity.TenantMember, error) {
+	var members []*entity.TenantMember
+	err := r.db.WithContext(ctx).Preload("Tenant").Where("user_id = ?", userID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+// ListByTenant gets members of tenant with their users, oldest first
+func (r *tenantMemberRepository) ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantMember, error) {
+	var members []*entity.TenantMember
+	err := r.db.WithContext(ctx).Preload("User").Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+// CountByUserRole counts tenants where user has role
+func (r *tenantMemberRepository) CountByUserRole(ctx context.Context, userID uint, role string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.TenantMember{}).
+		Where("user_id = ? AND role = ?", userID, role).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateRole changes role of user in tenant
+func (r *tenantMemberRepository) UpdateRole(ctx context.Context, tenantID string, userID uint, role string) error {
+	return r.db.WithContext(ctx).Model(&entity.TenantMember{}).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		Update("role", role).Error
+}
+
+// Delete removes user from tenant
+func (r *tenantMemberRepository) Delete(ctx context.Context, tenantID string, userID uint) error {
+	return r.db.WithContext(ctx).Where("tenant_id = ? AND user_id = ?", tenantID, userID).Delete(&entity.TenantMember{}).Error
+}
+
+// UpdateRoleKeepingOwner changes role of user in tenant, ErrLastOwner when user is its only owner
+func (r *tenantMemberRepository) UpdateRoleKeepingOwner(ctx context.Context, tenantID string, userID uint, role string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if role != _const.TenantRoleOwner {
+			if err := lockOtherOwner(tx, tenantID, userID); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&entity.TenantMember{}).
+			Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+			Update("role", role).Error
+	})
+}
+
+// DeleteKeepingOwner removes user from tenant, ErrLastOwner when user is its only owner
+func (r *tenantMemberRepository) DeleteKeepingOwner(ctx context.Context, tenantID string, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockOtherOwner(tx, tenantID, userID); err != nil {
+			return err
+		}
+		return tx.Where("tenant_id = ? AND user_id = ?", tenantID, userID).Delete(&entity.TenantMember{}).Error
+	})
+}
+
+// lockOtherOwner locks tenant row with SELECT ... FOR UPDATE and returns ErrLastOwner
+// when user is owner and tenant has no other owner
+func lockOtherOwner(tx *gorm.DB, tenantID string, userID uint) error {
+	var tenant entity.Tenant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", tenantID).First(&tenant).Error
+	if err != nil {
+		return err
+	}
+
+	var owners []uint
+	err = tx.Model(&entity.TenantMember{}).
+		Where("tenant_id = ? AND role = ?", tenantID, _const.TenantRoleOwner).
+		Pluck("user_id", &owners).Error
+	if err != nil {
+		return err
+	}
+
+	for _, owner := range owners {
+		if owner == userID {
+			if len(owners) <= 1 {
+				return repository.ErrLastOwner
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// tenantRepository implements TenantRepository
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *gorm.DB) repository.TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// CreateWithMember creates tenant and its first membership in one transaction
+func (r *tenantRepository) CreateWithMember(ctx context.Context, tenant *entity.Tenant, member *entity.TenantMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(tenant).Error; err != nil {
+			return err
+		}
+		member.TenantID = tenant.ID
+		return tx.Create(member).Error
+	})
+}
+
+// GetByID gets tenant by ID
+func (r *tenantRepository) GetByID(ctx context.Context, id string) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetBySlug gets tenant by slug
+func (r *tenantRepository) GetBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetByCustomDomain gets tenant by custom domain
+func (r *tenantRepository) GetByCustomDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	err := r.db.WithContext(ctx).Where("LOWER(custom_domain) = ?", domain).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// List lists tenants ordered by creation
+func (r *tenantRepository) List(ctx context.Context, offset, limit int) ([]*entity.Tenant, error) {
+	var tenants []*entity.Tenant
+	err := r.db.WithContext(ctx).Order("created_at, id").Offset(offset).Limit(limit).Find(&tenants).Error
+	return tenants, err
+}
+
+// Delete deletes tenant, its memberships, invitations and API keys are deleted by cascade
+func (r *tenantRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Tenant{}).Error
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
 	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
 	"gorm.io/gorm"
 )
 
@@ -43,16 +44,18 @@ func (r *userActivityLogRepository) GetByUserID(ctx context.Context, userID uint
 	return logs, err
 }
 
-// LogActivity logs user activity
+// LogActivity logs user activity, zero userID is stored as NULL
 func (r *userActivityLogRepository) LogActivity(ctx context.Context, userID uint, action, resource, ipAddress, userAgent string, metadata entity.JSONMap) error {
 	log := &entity.UserActivityLog{
-		UserID:    userID,
 		Action:    action,
 		Resource:  resource,
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
 		Metadata:  metadata,
 	}
+	if userID != 0 {
+		log.UserID = &userID
+	}
 	return r.db.WithContext(ctx).Create(log).Error
 }
 
@@ -69,3 +72,54 @@ func (r *userActivityLogRepository) GetRecentActivity(ctx context.Context, limit
 	err := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Find(&logs).Error
 	return logs, err
 }
+
+// ListByFilter gets activity logs matching filter ordered by ID descending,
+// starting after cursor. ID, username and email of user are preloaded when withUser is set,
+// logs of purged users have no user to preload.
+func (r *userActivityLogRepository) ListByFilter(ctx context.Context, filter model.ActivityFilter, withUser bool) ([]*entity.UserActivityLog, error) {
+	query := r.db.WithContext(ctx).Model(&entity.UserActivityLog{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Pseudonym != "" {
+		query = query.Where("pseudonym = ?", filter.Pseudonym)
+	}
+	if len(filter.Actions) > 0 {
+		query = query.Where("action IN ?", filter.Actions)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.Cursor != 0 {
+		query = query.Where("id < ?", filter.Cursor)
+	}
+	if withUser {
+		query = query.Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "email")
+		})
+	}
+
+	var logs []*entity.UserActivityLog
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&logs).Error
+	return logs, err
+}
+
+// Pseudonymize detaches logs from user, replacing user ID with pseudonym and
+// dropping IP address, user agent and metadata that may identify user
+func (r *userActivityLogRepository) Pseudonymize(ctx context.Context, userID uint, pseudonym string) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&entity.UserActivityLog{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"user_id":    gorm.Expr("NULL"),
+			"pseudonym":  pseudonym,
+			"ip_address": gorm.Expr("NULL"),
+			"user_agent": gorm.Expr("NULL"),
+			"metadata":   gorm.Expr("NULL"),
+		}).Error
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// userDeviceRepository implements UserDeviceRepository
+type userDeviceRepository struct {
+	db *gorm.DB
+}
+
+// NewUserDeviceRepository creates a new user device repository
+func NewUserDeviceRepository(db *gorm.DB) repository.UserDeviceRepository {
+	return &userDeviceRepository{db: db}
+}
+
+// Create creates a new user device
+func (r *userDeviceRepository) Create(ctx context.Context, device *entity.UserDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+
+// GetByID gets device by ID
+func (r *userDeviceRepository) GetByID(ctx context.Context, id uint) (*entity.UserDevice, error) {
+	var device entity.UserDevice
+	err := r.db.WithContext(ctx).First(&device, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+// GetByFingerprint gets device of user by fingerprint
+func (r *userDeviceRepository) GetByFingerprint(ctx context.Context, userID uint, fingerprint string) (*entity.UserDevice, error) {
+	var device entity.UserDevice
+	err := r.db.WithContext(ctx).Where("user_id = ? AND fingerprint = ?", userID, fingerprint).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+// ListByUserID gets devices of user, most recently seen first
+func (r *userDeviceRepository) ListByUserID(ctx context.Context, userID uint) ([]*entity.UserDevice, error) {
+	var devices []*entity.UserDevice
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&devices).Error
+	return devices, err
+}
+
+// CountByUserID counts devices of user
+func (r *userDeviceRepository) CountByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.UserDevice{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Touch records latest use of device
+func (r *userDeviceRepository) Touch(ctx context.Context, id uint, ipAddress string, seenAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.UserDevice{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"ip_address":   ipAddress,
+			"last_seen_at": seenAt,
+		}).Error
+}
+
+// Delete deletes device
+func (r *userDeviceRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.UserDevice{}, id).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// userRecoveryCodeRepository implements UserRecoveryCodeRepository
+type userRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRecoveryCodeRepository creates a new user recovery code repository
+func NewUserRecoveryCodeRepository(db *gorm.DB) repository.UserRecoveryCodeRepository {
+	return &userRecoveryCodeRepository{db: db}
+}
+
+// ReplaceForUser removes old codes of user and stores new code hashes
+func (r *userRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uint, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]entity.UserRecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, entity.UserRecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseCode marks unused code as used, returns false when code doesn't match
+func (r *userRecoveryCodeRepository) UseCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", gorm.Expr("NOW()"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnused counts recovery codes of user which are not used yet
+func (r *userRecoveryCodeRepository) CountUnused(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByUserID deletes all recovery codes of user
+func (r *userRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.UserRecoveryCode{}).Error
+}
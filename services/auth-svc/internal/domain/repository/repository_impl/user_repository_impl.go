@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
 	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
 	"gorm.io/gorm"
 )
 
@@ -37,10 +40,10 @@ func (r *userRepository) GetByID(ctx context.Context, id uint) (*entity.User, er
 	return &user, nil
 }
 
-// GetByEmail gets user by email
+// GetByEmail gets user by email, case-insensitively
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("LOWER(email) = ?", strings.ToLower(strings.TrimSpace(email))).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -53,7 +56,20 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 // GetByUsername gets user by username
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("LOWER(username) = ?", strings.ToLower(username)).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByPhone gets user by verified phone
+func (r *userRepository) GetByPhone(ctx context.Context, phone string) (*entity.User, error) {
+	var user entity.User
+	err := r.db.WithContext(ctx).Where("phone = ? AND phone_verified_at IS NOT NULL", phone).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -76,7 +92,7 @@ func (r *userRepository) Delete(ctx context.Context, id uint) error {
 // List gets users with pagination
 func (r *userRepository) List(ctx context.Context, offset, limit int) ([]*entity.User, error) {
 	var users []*entity.User
-	err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Find(&users).Error
+	err := r.db.WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&users).Error
 	return users, err
 }
 
@@ -97,10 +113,46 @@ func (r *userRepository) Search(ctx context.Context, query string, offset, limit
 	return users, err
 }
 
+// ListByFilter gets users matching filter with pagination and total count
+func (r *userRepository) ListByFilter(ctx context.Context, filter model.UserFilter, offset, limit int) ([]*entity.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.User{})
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("email ILIKE ? OR username ILIKE ? OR firstname ILIKE ? OR lastname ILIKE ?", like, like, like, like)
+	}
+	if filter.IsVerified != nil {
+		query = query.Where("is_verified = ?", *filter.IsVerified)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Role != "" {
+		query = query.Where("id IN (?)", r.db.Model(&entity.UserRole{}).
+			Select("user_roles.user_id").
+			Joins("JOIN roles ON roles.id = user_roles.role_id").
+			Where("roles.name = ?", filter.Role))
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*entity.User
+	err := query.Preload("Roles.Role").Order("id DESC").Offset(offset).Limit(limit).Find(&users).Error
+	return users, total, err
+}
+
 // GetWithRoles gets user with roles
 func (r *userRepository) GetWithRoles(ctx context.Context, id uint) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Preload("Roles").First(&user, id).Error
+	err := r.db.WithContext(ctx).Preload("Roles.Role").First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -146,6 +198,30 @@ func (r *userRepository) UpdateLastLogin(ctx context.Context, id uint) error {
 		Update("last_login_at", gorm.Expr("NOW()")).Error
 }
 
+// SetActive activates or locks user
+func (r *userRepository) SetActive(ctx context.Context, id uint, active bool) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("is_active", active).Error
+}
+
+// SetActiveTenant sets tenant included in tokens of user, nil clears it
+func (r *userRepository) SetActiveTenant(ctx context.Context, id uint, tenantID *string) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("active_tenant_id", tenantID).Error
+}
+
+// SetDeletionDueAt schedules deletion of user, nil cancels it
+func (r *userRepository) SetDeletionDueAt(ctx context.Context, id uint, dueAt *time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("deletion_due_at", dueAt).Error
+}
+
+// ListDeletionDue gets users whose deletion is due before time, ordered by ID after afterID
+func (r *userRepository) ListDeletionDue(ctx context.Context, before time.Time, afterID uint, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).
+		Where("deletion_due_at IS NOT NULL AND deletion_due_at <= ? AND id > ?", before, afterID).
+		Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
 // VerifyEmail verifies user's email
 func (r *userRepository) VerifyEmail(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
@@ -155,6 +231,36 @@ func (r *userRepository) VerifyEmail(ctx context.Context, id uint) error {
 		}).Error
 }
 
+// UpdateEmail changes user's email to address confirmed by user
+func (r *userRepository) UpdateEmail(ctx context.Context, id uint, email string) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"email":             email,
+			"is_verified":       true,
+			"email_verified_at": gorm.Expr("NOW()"),
+		}).Error
+}
+
+// EnableTwoFactor enables TOTP two-factor authentication with secret
+func (r *userRepository) EnableTwoFactor(ctx context.Context, id uint, secret string) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"two_factor_enabled":    true,
+			"two_factor_secret":     secret,
+			"two_factor_enabled_at": gorm.Expr("NOW()"),
+		}).Error
+}
+
+// DisableTwoFactor disables two-factor authentication and clears secret
+func (r *userRepository) DisableTwoFactor(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"two_factor_enabled":    false,
+			"two_factor_secret":     "",
+			"two_factor_enabled_at": nil,
+		}).Error
+}
+
 // VerifyPhone verifies user's phone
 func (r *userRepository) VerifyPhone(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
@@ -54,28 +54,60 @@ func (r *userRoleRepository) GetByUserID(ctx context.Context, userID uint) ([]*e
 	return userRoles, err
 }
 
-// AddRoleToUser adds role to user
+// AddRoleToUser adds role to user by role name, does nothing when user already has it
 func (r *userRoleRepository) AddRoleToUser(ctx context.Context, userID uint, role string) error {
-	// Note: This is a simplified implementation. In a real app, you'd need to get role_id from roles table
+	var roleEntity entity.Role
+	if err := r.db.WithContext(ctx).Where("name = ?", role).First(&roleEntity).Error; err != nil {
+		return err
+	}
+
 	userRole := &entity.UserRole{
 		UserID: userID,
-		RoleID: 1, // Default role ID - you should implement proper role lookup
+		RoleID: roleEntity.ID,
 	}
-	return r.db.WithContext(ctx).Create(userRole).Error
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id = ?", userID, roleEntity.ID).
+		FirstOrCreate(userRole).Error
 }
 
-// RemoveRoleFromUser removes role from user
+// RemoveRoleFromUser removes role from user by role name
 func (r *userRoleRepository) RemoveRoleFromUser(ctx context.Context, userID uint, role string) error {
-	return r.db.WithContext(ctx).Where("user_id = ? AND role = ?", userID, role).Delete(&entity.UserRole{}).Error
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id IN (?)", userID, r.db.Model(&entity.Role{}).Select("id").Where("name = ?", role)).
+		Delete(&entity.UserRole{}).Error
 }
 
 // HasRole checks if user has role
 func (r *userRoleRepository) HasRole(ctx context.Context, userID uint, role string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&entity.UserRole{}).Where("user_id = ? AND role = ?", userID, role).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&entity.UserRole{}).
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ? AND roles.name = ?", userID, role).
+		Count(&count).Error
 	return count > 0, err
 }
 
+// GetRoleNamesByUserID gets names of roles assigned to user
+func (r *userRoleRepository) GetRoleNamesByUserID(ctx context.Context, userID uint) ([]string, error) {
+	var names []string
+	err := r.db.WithContext(ctx).Model(&entity.UserRole{}).
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Pluck("roles.name", &names).Error
+	return names, err
+}
+
+// GetUserIDsByRoleID gets IDs of users having role
+func (r *userRoleRepository) GetUserIDsByRoleID(ctx context.Context, roleID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).Model(&entity.UserRole{}).
+		Where("role_id = ?", roleID).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
 // List gets user roles with pagination
 func (r *userRoleRepository) List(ctx context.Context, offset, limit int) ([]*entity.UserRole, error) {
 	var userRoles []*entity.UserRole
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// RoleRepository defines the interface for role and role permission data access
+type RoleRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, role *entity.Role) error
+	GetByID(ctx context.Context, id uint) (*entity.Role, error)
+	GetByName(ctx context.Context, name string) (*entity.Role, error)
+
+	// Role permission operations
+	GetPermissions(ctx context.Context, roleID uint) ([]*entity.RolePermission, error)
+	GetPermissionsByUserID(ctx context.Context, userID uint) ([]*entity.RolePermission, error)
+	AddPermission(ctx context.Context, roleID uint, resource, action string) error
+	RemovePermission(ctx context.Context, roleID uint, resource, action string) error
+
+	// List operations
+	List(ctx context.Context) ([]*entity.Role, error)
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// SMSProvider defines the interface for sending SMS messages
+type SMSProvider interface {
+	// Send SMS message to phone number
+	SendSMS(ctx context.Context, phone, message string) error
+}
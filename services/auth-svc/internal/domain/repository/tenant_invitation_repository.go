@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+var (
+	// ErrSeatLimit is returned when members and pending invitations take all seats of tenant
+	ErrSeatLimit = errors.New("tenant seat limit reached")
+
+	// ErrInvitationClosed is returned when invitation is no longer pending
+	ErrInvitationClosed = errors.New("invitation is no longer pending")
+)
+
+// TenantInvitationRepository defines the interface for tenant invitation data access.
+// Invitation is pending until accepted, declined, revoked or expired.
+type TenantInvitationRepository interface {
+	CreateWithinSeats(ctx context.Context, invitation *entity.TenantInvitation, seats int, now time.Time) error
+	AcceptWithinSeats(ctx context.Context, invitationID uint, member *entity.TenantMember, seats int) error
+	GetByID(ctx context.Context, tenantID string, id uint) (*entity.TenantInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.TenantInvitation, error)
+	GetPendingByEmail(ctx context.Context, tenantID, email string, now time.Time) (*entity.TenantInvitation, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantInvitation, error)
+	MarkDeclined(ctx context.Context, id uint) (bool, error)
+	MarkRevoked(ctx context.Context, id uint) (bool, error)
+}
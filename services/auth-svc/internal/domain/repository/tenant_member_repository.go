@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// ErrLastOwner is returned when change would leave tenant without owner
+var ErrLastOwner = errors.New("tenant must keep an owner")
+
+// TenantMemberRepository defines the interface for tenant membership data access
+type TenantMemberRepository interface {
+	Create(ctx context.Context, member *entity.TenantMember) error
+	Get(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error)
+	ListByUser(ctx context.Context, userID uint) ([]*entity.TenantMember, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantMember, error)
+	CountByUserRole(ctx context.Context, userID uint, role string) (int64, error)
+	UpdateRole(ctx context.Context, tenantID string, userID uint, role string) error
+	Delete(ctx context.Context, tenantID string, userID uint) error
+
+	// UpdateRoleKeepingOwner and DeleteKeepingOwner lock tenant while they change member,
+	// so concurrent changes can't remove the last owner
+	UpdateRoleKeepingOwner(ctx context.Context, tenantID string, userID uint, role string) error
+	DeleteKeepingOwner(ctx context.Context, tenantID string, userID uint) error
+}
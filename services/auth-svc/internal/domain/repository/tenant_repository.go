@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	CreateWithMember(ctx context.Context, tenant *entity.Tenant, member *entity.TenantMember) error
+	GetByID(ctx context.Context, id string) (*entity.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Tenant, error)
+	GetByCustomDomain(ctx context.Context, domain string) (*entity.Tenant, error)
+	List(ctx context.Context, offset, limit int) ([]*entity.Tenant, error)
+	Delete(ctx context.Context, id string) error
+}
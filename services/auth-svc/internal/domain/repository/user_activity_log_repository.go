@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
 )
 
 // UserActivityLogRepository defines the interface for user activity log data access
@@ -19,4 +20,8 @@ type UserActivityLogRepository interface {
 	// List operations
 	List(ctx context.Context, offset, limit int) ([]*entity.UserActivityLog, error)
 	GetRecentActivity(ctx context.Context, limit int) ([]*entity.UserActivityLog, error)
+	ListByFilter(ctx context.Context, filter model.ActivityFilter, withUser bool) ([]*entity.UserActivityLog, error)
+
+	// Privacy operations
+	Pseudonymize(ctx context.Context, userID uint, pseudonym string) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// UserDeviceRepository defines the interface for known login device data access
+type UserDeviceRepository interface {
+	Create(ctx context.Context, device *entity.UserDevice) error
+	GetByID(ctx context.Context, id uint) (*entity.UserDevice, error)
+	GetByFingerprint(ctx context.Context, userID uint, fingerprint string) (*entity.UserDevice, error)
+	ListByUserID(ctx context.Context, userID uint) ([]*entity.UserDevice, error)
+	CountByUserID(ctx context.Context, userID uint) (int64, error)
+	Touch(ctx context.Context, id uint, ipAddress string, seenAt time.Time) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// UserIdentityRepository defines the interface for linked OAuth identity data access
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*entity.UserIdentity, error)
+}
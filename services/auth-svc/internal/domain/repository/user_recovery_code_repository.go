@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+)
+
+// UserRecoveryCodeRepository defines the interface for two-factor recovery code data access
+type UserRecoveryCodeRepository interface {
+	// ReplaceForUser removes old codes of user and stores new code hashes
+	ReplaceForUser(ctx context.Context, userID uint, codeHashes []string) error
+	// UseCode marks unused code as used, returns false when code doesn't match
+	UseCode(ctx context.Context, userID uint, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, userID uint) (int64, error)
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
 )
 
 type UserRepository interface {
@@ -12,6 +14,7 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uint) (*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	GetByPhone(ctx context.Context, phone string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id uint) error
 
@@ -21,6 +24,7 @@ type UserRepository interface {
 
 	// Search operations
 	Search(ctx context.Context, query string, offset, limit int) ([]*entity.User, error)
+	ListByFilter(ctx context.Context, filter model.UserFilter, offset, limit int) ([]*entity.User, int64, error)
 
 	// Relationship operations
 	GetWithRoles(ctx context.Context, id uint) (*entity.User, error)
@@ -29,5 +33,19 @@ type UserRepository interface {
 
 	// Authentication related
 	UpdateLastLogin(ctx context.Context, id uint) error
+	SetActive(ctx context.Context, id uint, active bool) error
 	VerifyEmail(ctx context.Context, id uint) error
+	UpdateEmail(ctx context.Context, id uint, email string) error
+	VerifyPhone(ctx context.Context, id uint) error
+
+	// Account deletion
+	SetDeletionDueAt(ctx context.Context, id uint, dueAt *time.Time) error
+	ListDeletionDue(ctx context.Context, before time.Time, afterID uint, limit int) ([]*entity.User, error)
+
+	// Tenants
+	SetActiveTenant(ctx context.Context, id uint, tenantID *string) error
+
+	// Two-factor authentication
+	EnableTwoFactor(ctx context.Context, id uint, secret string) error
+	DisableTwoFactor(ctx context.Context, id uint) error
 }
@@ -19,6 +19,8 @@ type UserRoleRepository interface {
 	AddRoleToUser(ctx context.Context, userID uint, role string) error
 	RemoveRoleFromUser(ctx context.Context, userID uint, role string) error
 	HasRole(ctx context.Context, userID uint, role string) (bool, error)
+	GetRoleNamesByUserID(ctx context.Context, userID uint) ([]string, error)
+	GetUserIDsByRoleID(ctx context.Context, roleID uint) ([]uint, error)
 
 	// List operations
 	List(ctx context.Context, offset, limit int) ([]*entity.UserRole, error)
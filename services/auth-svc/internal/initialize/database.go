@@ -52,9 +52,15 @@ func InitDatabase(config DatabaseConfig) *gorm.DB {
 // func AutoMigrate(db *gorm.DB) error {
 // 	return db.AutoMigrate(
 // 		&entity.User{},
+// 		&entity.Role{},
+// 		&entity.RolePermission{},
 // 		&entity.UserRole{},
 // 		&entity.UserPermission{},
 // 		&entity.UserActivityLog{},
+// 		&entity.UserRecoveryCode{},
+// 		&entity.UserIdentity{},
+// 		&entity.APIKey{},
+// 		&entity.UserDevice{},
 // 	)
 // }
 
@@ -0,0 +1,226 @@
+// Package kongtest provides in-memory stand-in for Kong Admin API used by tests
+package kongtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// Server serves consumers and JWT credentials endpoints of Kong Admin API from memory.
+// List endpoints return PageSize entities per page so clients walk offsets.
+type Server struct {
+	*httptest.Server
+
+	PageSize   int
+	AdminToken string
+
+	mu          sync.Mutex
+	consumers   map[string]model.KongConsumer
+	credentials map[string]map[string]model.KongJWTCredential
+	requests    []string
+}
+
+// NewServer starts stand-in server, it must be closed by caller
+func NewServer() *Server {
+	s := &Server{
+		PageSize:    2,
+		consumers:   make(map[string]model.KongConsumer),
+		credentials: make(map[string]map[string]model.KongJWTCredential),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// AddConsumer stores consumer as if created earlier
+func (s *Server) AddConsumer(consumer model.KongConsumer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumers[consumer.Username] = consumer
+}
+
+// AddCredential stores JWT credential of consumer as if created earlier
+func (s *Server) AddCredential(username string, credential model.KongJWTCredential) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.credentials[username] == nil {
+		s.credentials[username] = make(map[string]model.KongJWTCredential)
+	}
+	s.credentials[username][credential.Key] = credential
+}
+
+// Consumers returns sorted usernames of stored consumers
+func (s *Server) Consumers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usernames := make([]string, 0, len(s.consumers))
+	for username := range s.consumers {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// Consumer returns stored consumer by username
+func (s *Server) Consumer(username string) (model.KongConsumer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	consumer, ok := s.consumers[username]
+	return consumer, ok
+}
+
+// CredentialKeys returns sorted keys of JWT credentials of consumer
+func (s *Server) CredentialKeys(username string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.credentials[username]))
+	for key := range s.credentials[username] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Credential returns stored JWT credential of consumer by key
+func (s *Server) Credential(username, key string) (model.KongJWTCredential, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	credential, ok := s.credentials[username][key]
+	return credential, ok
+}
+
+// Requests returns "METHOD path" of received requests in order
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if s.AdminToken != "" && r.Header.Get("Kong-Admin-Token") != s.AdminToken {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Invalid credentials"})
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "consumers" && r.Method == http.MethodGet:
+		s.listConsumers(w, r)
+	case len(parts) == 2 && parts[0] == "consumers" && r.Method == http.MethodPut:
+		var consumer model.KongConsumer
+		if err := json.NewDecoder(r.Body).Decode(&consumer); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		consumer.Username = parts[1]
+		s.consumers[parts[1]] = consumer
+		writeJSON(w, http.StatusOK, consumer)
+	case len(parts) == 2 && parts[0] == "consumers" && r.Method == http.MethodDelete:
+		if _, ok := s.consumers[parts[1]]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+			return
+		}
+		delete(s.consumers, parts[1])
+		delete(s.credentials, parts[1])
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 3 && parts[0] == "consumers" && parts[2] == "jwt" && r.Method == http.MethodGet:
+		if _, ok := s.consumers[parts[1]]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+			return
+		}
+		credentials := make([]model.KongJWTCredential, 0, len(s.credentials[parts[1]]))
+		for _, credential := range s.credentials[parts[1]] {
+			credentials = append(credentials, credential)
+		}
+		sort.Slice(credentials, func(i, j int) bool { return credentials[i].Key < credentials[j].Key })
+		s.writePage(w, r, len(credentials), func(from, to int) interface{} { return credentials[from:to] })
+	case len(parts) == 4 && parts[0] == "consumers" && parts[2] == "jwt" && r.Method == http.MethodPut:
+		if _, ok := s.consumers[parts[1]]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+			return
+		}
+		var credential model.KongJWTCredential
+		if err := json.NewDecoder(r.Body).Decode(&credential); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		credential.Key = parts[3]
+		if s.credentials[parts[1]] == nil {
+			s.credentials[parts[1]] = make(map[string]model.KongJWTCredential)
+		}
+		s.credentials[parts[1]][parts[3]] = credential
+		writeJSON(w, http.StatusOK, credential)
+	case len(parts) == 4 && parts[0] == "consumers" && parts[2] == "jwt" && r.Method == http.MethodDelete:
+		if _, ok := s.credentials[parts[1]][parts[3]]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+			return
+		}
+		delete(s.credentials[parts[1]], parts[3])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+	}
+}
+
+// listConsumers lists consumers having all requested tags
+func (s *Server) listConsumers(w http.ResponseWriter, r *http.Request) {
+	var tags []string
+	if value := r.URL.Query().Get("tags"); value != "" {
+		tags = strings.Split(value, ",")
+	}
+
+	consumers := make([]model.KongConsumer, 0, len(s.consumers))
+	for _, consumer := range s.consumers {
+		if hasTags(consumer.Tags, tags) {
+			consumers = append(consumers, consumer)
+		}
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Username < consumers[j].Username })
+	s.writePage(w, r, len(consumers), func(from, to int) interface{} { return consumers[from:to] })
+}
+
+// writePage writes page of list starting at offset query param
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, total int, slice func(from, to int) interface{}) {
+	from, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	to := from + s.PageSize
+	offset := strconv.Itoa(to)
+	if to >= total {
+		to, offset = total, ""
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   slice(from, to),
+		"offset": offset,
+	})
+}
+
+func hasTags(have, want []string) bool {
+	for _, tag := range want {
+		found := false
+		for _, t := range have {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
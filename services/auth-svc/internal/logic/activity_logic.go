@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ActivityLogic lists activity and security logs of users
+type ActivityLogic struct {
+	userActivityRepo repository.UserActivityLogRepository
+	logger           util.Logger
+}
+
+// NewActivityLogic creates new ActivityLogic instance
+func NewActivityLogic(userActivityRepo repository.UserActivityLogRepository, logger util.Logger) *ActivityLogic {
+	return &ActivityLogic{
+		userActivityRepo: userActivityRepo,
+		logger:           logger,
+	}
+}
+
+// ListUserActivity returns activity of user, newest first
+func (l *ActivityLogic) ListUserActivity(ctx context.Context, userID uint, filter model.ActivityFilter) (*model.ActivityPage, error) {
+	filter.UserID = userID
+	return l.list(ctx, filter, false)
+}
+
+// ListActivity returns activity of all users or user set in filter, with user preloaded
+func (l *ActivityLogic) ListActivity(ctx context.Context, filter model.ActivityFilter) (*model.ActivityPage, error) {
+	return l.list(ctx, filter, true)
+}
+
+// list loads one entry more than limit to know whether next page exists
+func (l *ActivityLogic) list(ctx context.Context, filter model.ActivityFilter, withUser bool) (*model.ActivityPage, error) {
+	if filter.Limit < 1 {
+		filter.Limit = model.DefaultPageSize
+	}
+	if filter.Limit > model.MaxPageSize {
+		filter.Limit = model.MaxPageSize
+	}
+	limit := filter.Limit
+
+	actions := make([]string, 0, len(filter.Actions))
+	for _, action := range filter.Actions {
+		if action = strings.ToLower(strings.TrimSpace(action)); action != "" {
+			actions = append(actions, action)
+		}
+	}
+	filter.Actions = actions
+	filter.IPAddress = strings.TrimSpace(filter.IPAddress)
+
+	filter.Limit = limit + 1
+	logs, err := l.userActivityRepo.ListByFilter(ctx, filter, withUser)
+	if err != nil {
+		l.logger.Error("Failed to list activity logs", util.Error(err))
+		return nil, err
+	}
+
+	page := &model.ActivityPage{Items: logs}
+	if len(logs) > limit {
+		page.Items = logs[:limit]
+		page.NextCursor = strconv.FormatUint(uint64(page.Items[limit-1].ID), 10)
+	}
+	if page.Items == nil {
+		page.Items = []*entity.UserActivityLog{}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,248 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// AdminUserLogic contains user management actions of admins.
+// Every action is logged on the target user with acting admin in metadata.
+type AdminUserLogic struct {
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	tokenLogic       *TokenLogic
+	permissions      *PermissionLogic
+	kong             *KongLogic
+	logger           util.Logger
+}
+
+// NewAdminUserLogic creates new AdminUserLogic instance
+func NewAdminUserLogic(
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	tokenLogic *TokenLogic,
+	permissions *PermissionLogic,
+	kong *KongLogic,
+	logger util.Logger,
+) *AdminUserLogic {
+	return &AdminUserLogic{
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		tokenLogic:       tokenLogic,
+		permissions:      permissions,
+		kong:             kong,
+		logger:           logger,
+	}
+}
+
+// ListUsers returns page of users matching filters
+func (l *AdminUserLogic) ListUsers(ctx context.Context, req model.ListUsersRequest) (*model.UserList, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = model.DefaultPageSize
+	}
+	if req.PageSize > model.MaxPageSize {
+		req.PageSize = model.MaxPageSize
+	}
+	req.Query = strings.TrimSpace(req.Query)
+
+	users, total, err := l.userRepo.ListByFilter(ctx, req.UserFilter, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		l.logger.Error("Failed to list users", util.Error(err))
+		return nil, err
+	}
+	if users == nil {
+		users = []*entity.User{}
+	}
+
+	return &model.UserList{
+		Users:    users,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetUser returns user with roles
+func (l *AdminUserLogic) GetUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := l.userRepo.GetWithRoles(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user with roles", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+	return user, nil
+}
+
+// LockUser deactivates user, revokes its tokens and removes its Kong consumer
+func (l *AdminUserLogic) LockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	if userID == req.AdminID {
+		return util.NewError(_const.CodeActionNotAllowed.Message())
+	}
+
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return util.NewError(_const.CodeUserHaveBeenLock.Message())
+	}
+
+	if err := l.userRepo.SetActive(ctx, userID, false); err != nil {
+		l.logger.Error("Failed to lock user", util.Error(err))
+		return err
+	}
+
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke tokens of locked user", util.Int("user_id", int(userID)), util.Error(err))
+		return err
+	}
+
+	l.kong.RemoveUser(ctx, userID)
+
+	l.logAction(ctx, userID, "admin_lock", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"reason": req.Reason,
+	})
+
+	return nil
+}
+
+// UnlockUser activates locked user and provisions its Kong consumer again
+func (l *AdminUserLogic) UnlockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsActive {
+		return util.NewError(_const.CodeUserHaveBeenUnlock.Message())
+	}
+
+	if err := l.userRepo.SetActive(ctx, userID, true); err != nil {
+		l.logger.Error("Failed to unlock user", util.Error(err))
+		return err
+	}
+	user.IsActive = true
+
+	l.kong.SyncUser(ctx, user)
+
+	l.logAction(ctx, userID, "admin_unlock", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"reason": req.Reason,
+	})
+
+	return nil
+}
+
+// RevokeSessions revokes every session of user without locking it
+func (l *AdminUserLogic) RevokeSessions(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	if _, err := l.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke user sessions", util.Int("user_id", int(userID)), util.Error(err))
+		return err
+	}
+
+	l.logAction(ctx, userID, "admin_revoke_sessions", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"reason": req.Reason,
+	})
+
+	return nil
+}
+
+// VerifyEmail marks email of user verified without verification link
+func (l *AdminUserLogic) VerifyEmail(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsVerified {
+		return nil
+	}
+
+	if err := l.userRepo.VerifyEmail(ctx, userID); err != nil {
+		l.logger.Error("Failed to verify email", util.Error(err))
+		return err
+	}
+
+	l.logAction(ctx, userID, "admin_verify_email", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"email":  user.Email,
+		"reason": req.Reason,
+	})
+
+	return nil
+}
+
+// AssignRole assigns role to user
+func (l *AdminUserLogic) AssignRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error {
+	if _, err := l.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := l.permissions.AssignRole(ctx, userID, req.Role); err != nil {
+		return err
+	}
+
+	l.logAction(ctx, userID, "admin_assign_role", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"role": req.Role,
+	})
+
+	return nil
+}
+
+// RemoveRole removes role from user. Admins can't remove admin role from themselves.
+func (l *AdminUserLogic) RemoveRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error {
+	if userID == req.AdminID && req.Role == _const.RoleAdmin {
+		return util.NewError(_const.CodeActionNotAllowed.Message())
+	}
+
+	if _, err := l.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := l.permissions.RemoveRole(ctx, userID, req.Role); err != nil {
+		return err
+	}
+
+	l.logAction(ctx, userID, "admin_remove_role", req.AdminID, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"role": req.Role,
+	})
+
+	return nil
+}
+
+// getUser gets user by ID, returns CodeUserNotFound when missing
+func (l *AdminUserLogic) getUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+	return user, nil
+}
+
+// logAction logs admin action on user with acting admin in metadata
+func (l *AdminUserLogic) logAction(ctx context.Context, userID uint, action string, adminID uint, ipAddress, userAgent string, metadata entity.JSONMap) {
+	metadata["admin_id"] = adminID
+
+	l.logger.Info("Admin action on user",
+		util.String("action", action),
+		util.Int("user_id", int(userID)),
+		util.Int("admin_id", int(adminID)),
+	)
+
+	l.userActivityRepo.LogActivity(ctx, userID, action, "user", ipAddress, userAgent, metadata)
+}
@@ -0,0 +1,466 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	apiKeyIDLength     = 8
+	apiKeySecretLength = 48
+)
+
+// APIKeyLogic contains business logic for scoped API keys of users and tenants.
+// Keys look like <prefix>_<id>_<secret>, only the SHA-256 hash is stored
+// and <prefix>_<id> identifies the key in listings and lookups.
+// Keys never grant more than their owner: scopes must be granted to owner when
+// key is issued and are intersected with current permissions of owner on verification.
+type APIKeyLogic struct {
+	config           config.APIKeyConfig
+	apiKeyRepo       repository.APIKeyRepository
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	permissions      *PermissionLogic
+	tenants          *TenantLogic
+	logger           util.Logger
+}
+
+// NewAPIKeyLogic creates new APIKeyLogic instance
+func NewAPIKeyLogic(
+	config config.APIKeyConfig,
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	permissions *PermissionLogic,
+	tenants *TenantLogic,
+	logger util.Logger,
+) *APIKeyLogic {
+	return &APIKeyLogic{
+		config:           config,
+		apiKeyRepo:       apiKeyRepo,
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		permissions:      permissions,
+		tenants:          tenants,
+		logger:           logger,
+	}
+}
+
+// Create issues new API key for user, or for tenant when req.TenantID is set, and returns
+// its secret once. Keys of tenant are issued by its admins and owners.
+func (l *APIKeyLogic) Create(ctx context.Context, userID uint, req model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error) {
+	if req.TenantID != "" {
+		if err := l.checkTenantManager(ctx, req.TenantID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	scopes, err := l.normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkGranted(ctx, userID, scopes); err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := l.expiresAt(req.ExpiresInDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.checkLimit(ctx, userID, req.TenantID); err != nil {
+		return nil, err
+	}
+
+	var tenantID *string
+	if req.TenantID != "" {
+		tenantID = &req.TenantID
+	}
+	created, err := l.issue(ctx, userID, tenantID, strings.TrimSpace(req.Name), scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "api_key_create", "api_key", req.IPAddress, req.UserAgent, apiKeyActivity(created.APIKey, nil))
+
+	return created, nil
+}
+
+// List returns API keys of user, or of tenant when tenantID is set, without secrets
+func (l *APIKeyLogic) List(ctx context.Context, userID uint, tenantID string) ([]*entity.APIKey, error) {
+	var apiKeys []*entity.APIKey
+	var err error
+	if tenantID != "" {
+		if err := l.checkTenantManager(ctx, tenantID, userID); err != nil {
+			return nil, err
+		}
+		apiKeys, err = l.apiKeyRepo.ListByTenantID(ctx, tenantID)
+	} else {
+		apiKeys, err = l.apiKeyRepo.ListByUserID(ctx, userID)
+	}
+	if err != nil {
+		l.logger.Error("Failed to list API keys", util.Error(err))
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+// Rotate issues replacement key with same name, scopes and expiry.
+// Old key keeps working for the configured grace period. Scopes must still be
+// granted to user, rotated key of tenant is owned by user rotating it.
+func (l *APIKeyLogic) Rotate(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) (*model.CreatedAPIKey, error) {
+	apiKey, err := l.getActive(ctx, userID, req.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkGranted(ctx, userID, apiKey.Scopes); err != nil {
+		return nil, err
+	}
+
+	created, err := l.issue(ctx, userID, apiKey.TenantID, apiKey.Name, apiKey.Scopes, apiKey.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.config.RotateGraceMinute > 0 {
+		graceEnd := time.Now().Add(time.Duration(l.config.RotateGraceMinute) * time.Minute)
+		if apiKey.ExpiresAt == nil || graceEnd.Before(*apiKey.ExpiresAt) {
+			err = l.apiKeyRepo.SetExpiresAt(ctx, apiKey.ID, graceEnd)
+		}
+	} else {
+		err = l.apiKeyRepo.Revoke(ctx, apiKey.ID)
+	}
+	if err != nil {
+		l.logger.Error("Failed to retire rotated API key", util.Error(err))
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "api_key_rotate", "api_key", req.IPAddress, req.UserAgent, apiKeyActivity(created.APIKey, entity.JSONMap{
+		"rotated_key_id": apiKey.ID,
+	}))
+
+	return created, nil
+}
+
+// Revoke revokes API key of user, or of tenant when req.TenantID is set, immediately
+func (l *APIKeyLogic) Revoke(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) error {
+	if req.TenantID != "" {
+		if err := l.checkTenantManager(ctx, req.TenantID, userID); err != nil {
+			return err
+		}
+	}
+
+	apiKey, err := l.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		l.logger.Error("Failed to get API key", util.Error(err))
+		return err
+	}
+	if apiKey == nil || !apiKeyOwnedBy(apiKey, userID, req.TenantID) {
+		return util.NewError(_const.CodeAPIKeyNotFound.Message())
+	}
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	if err := l.apiKeyRepo.Revoke(ctx, apiKey.ID); err != nil {
+		l.logger.Error("Failed to revoke API key", util.Error(err))
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "api_key_revoke", "api_key", req.IPAddress, req.UserAgent, apiKeyActivity(apiKey, nil))
+
+	return nil
+}
+
+// Verify checks API key and optional required scope, then records its use.
+// Key only grants its scopes still granted to owner, keys of tenant also need
+// owner to still be admin or owner of tenant.
+func (l *APIKeyLogic) Verify(ctx context.Context, req model.VerifyAPIKeyRequest) (*model.VerifyAPIKeyResponse, error) {
+	key := strings.TrimSpace(req.Key)
+	sep := strings.LastIndex(key, "_")
+	if sep <= 0 {
+		return nil, util.NewError(_const.CodeAPIKeyInvalid.Message())
+	}
+
+	apiKey, err := l.apiKeyRepo.GetByPrefix(ctx, key[:sep])
+	if err != nil {
+		l.logger.Error("Failed to get API key", util.Error(err))
+		return nil, err
+	}
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashAPIKey(key))) != 1 {
+		return nil, util.NewError(_const.CodeAPIKeyInvalid.Message())
+	}
+
+	now := time.Now()
+	if !apiKeyActive(apiKey, now) {
+		return nil, util.NewError(_const.CodeAPIKeyInvalid.Message())
+	}
+
+	user, err := l.userRepo.GetByID(ctx, apiKey.UserID)
+	if err != nil {
+		l.logger.Error("Failed to get API key owner", util.Error(err))
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, util.NewError(_const.CodeAPIKeyInvalid.Message())
+	}
+
+	if apiKey.TenantID != nil {
+		if err := l.checkTenantManager(ctx, *apiKey.TenantID, apiKey.UserID); err != nil {
+			if _, ok := _const.CodeFromError(err, _const.CodeTenantNotFound, _const.CodeTenantForbidden); !ok {
+				return nil, err
+			}
+			l.logger.Warn("Owner of tenant API key no longer manages tenant",
+				util.String("prefix", apiKey.Prefix),
+				util.String("tenant_id", *apiKey.TenantID),
+			)
+			return nil, util.NewError(_const.CodeAPIKeyInvalid.Message())
+		}
+	}
+
+	scopes, err := l.grantedScopes(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Resource != "" || req.Action != "" {
+		if !scopes.Has(strings.ToLower(req.Resource), strings.ToLower(req.Action)) {
+			l.logger.Warn("API key used without required scope",
+				util.String("prefix", apiKey.Prefix),
+				util.String("resource", req.Resource),
+				util.String("action", req.Action),
+			)
+			return nil, util.NewError(_const.CodeAPIKeyForbidden.Message())
+		}
+	}
+
+	// Throttle last used writes for busy keys
+	interval := time.Duration(l.config.LastUsedIntervalSecond) * time.Second
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= interval || apiKey.LastUsedIP != req.ClientIP {
+		if err := l.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID, req.ClientIP, now); err != nil {
+			l.logger.Error("Failed to update API key last used", util.Error(err))
+		}
+	}
+
+	response := &model.VerifyAPIKeyResponse{
+		KeyID:  apiKey.ID,
+		UserID: apiKey.UserID,
+		Prefix: apiKey.Prefix,
+		Scopes: scopes,
+	}
+	if apiKey.TenantID != nil {
+		response.TenantID = *apiKey.TenantID
+	}
+
+	return response, nil
+}
+
+// issue generates key secret and stores its hash
+func (l *APIKeyLogic) issue(ctx context.Context, userID uint, tenantID *string, name string, scopes entity.APIKeyScopes, expiresAt *time.Time) (*model.CreatedAPIKey, error) {
+	prefix := l.config.Prefix + "_" + util.GenerateRandomString(apiKeyIDLength)
+	key := prefix + "_" + util.GenerateRandomString(apiKeySecretLength)
+
+	apiKey := &entity.APIKey{
+		UserID:    userID,
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := l.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		l.logger.Error("Failed to create API key", util.Error(err))
+		return nil, err
+	}
+
+	l.logger.Info("API key issued",
+		util.Int("user_id", int(userID)),
+		util.String("prefix", prefix),
+	)
+
+	return &model.CreatedAPIKey{Key: key, APIKey: apiKey}, nil
+}
+
+// getActive gets API key of user, or of tenant when tenantID is set, which isn't revoked nor expired
+func (l *APIKeyLogic) getActive(ctx context.Context, userID uint, tenantID string, id uint) (*entity.APIKey, error) {
+	if tenantID != "" {
+		if err := l.checkTenantManager(ctx, tenantID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	apiKey, err := l.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		l.logger.Error("Failed to get API key", util.Error(err))
+		return nil, err
+	}
+	if apiKey == nil || !apiKeyOwnedBy(apiKey, userID, tenantID) || !apiKeyActive(apiKey, time.Now()) {
+		return nil, util.NewError(_const.CodeAPIKeyNotFound.Message())
+	}
+	return apiKey, nil
+}
+
+// checkTenantManager returns error unless user is admin or owner of tenant
+func (l *APIKeyLogic) checkTenantManager(ctx context.Context, tenantID string, userID uint) error {
+	member, err := l.tenants.membership(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if tenantRoleRank[member.Role] < tenantRoleRank[_const.TenantRoleAdmin] {
+		return util.NewError(_const.CodeTenantForbidden.Message())
+	}
+	return nil
+}
+
+// checkGranted returns CodeAPIKeyNotGranted when user lacks permission of any scope
+func (l *APIKeyLogic) checkGranted(ctx context.Context, userID uint, scopes entity.APIKeyScopes) error {
+	effective, err := l.permissions.GetEffective(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get permissions of API key owner", util.Error(err))
+		return err
+	}
+	for _, scope := range scopes {
+		if !effective.Has(scope.Resource, scope.Action) {
+			return util.NewError(_const.CodeAPIKeyNotGranted.Message())
+		}
+	}
+	return nil
+}
+
+// grantedScopes returns scopes of key still granted to its owner
+func (l *APIKeyLogic) grantedScopes(ctx context.Context, apiKey *entity.APIKey) (entity.APIKeyScopes, error) {
+	effective, err := l.permissions.GetEffective(ctx, apiKey.UserID)
+	if err != nil {
+		l.logger.Error("Failed to get permissions of API key owner", util.Error(err))
+		return nil, err
+	}
+	return intersectScopes(apiKey.Scopes, effective), nil
+}
+
+// checkLimit returns CodeAPIKeyLimit when user, or tenant when tenantID is set, has no key left
+func (l *APIKeyLogic) checkLimit(ctx context.Context, userID uint, tenantID string) error {
+	limit := l.config.MaxPerUser
+	if tenantID != "" {
+		limit = l.config.MaxPerTenant
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	var count int64
+	var err error
+	if tenantID != "" {
+		count, err = l.apiKeyRepo.CountActiveByTenantID(ctx, tenantID)
+	} else {
+		count, err = l.apiKeyRepo.CountActiveByUserID(ctx, userID)
+	}
+	if err != nil {
+		l.logger.Error("Failed to count API keys", util.Error(err))
+		return err
+	}
+	if count >= int64(limit) {
+		return util.NewError(_const.CodeAPIKeyLimit.Message())
+	}
+	return nil
+}
+
+// normalizeScopes lowercases, dedupes and checks scopes against allowed list
+func (l *APIKeyLogic) normalizeScopes(scopes []entity.APIKeyScope) (entity.APIKeyScopes, error) {
+	allowed := make(map[string]bool, len(l.config.AllowedScopes))
+	for _, scope := range l.config.AllowedScopes {
+		allowed[scope] = true
+	}
+
+	normalized := make(entity.APIKeyScopes, 0, len(scopes))
+	for _, scope := range scopes {
+		scope.Resource = strings.ToLower(strings.TrimSpace(scope.Resource))
+		scope.Action = strings.ToLower(strings.TrimSpace(scope.Action))
+		if !allowed[scope.Resource+":"+scope.Action] {
+			return nil, util.NewError(_const.CodeAPIKeyScope.Message())
+		}
+		if !normalized.Has(scope.Resource, scope.Action) {
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, util.NewError(_const.CodeAPIKeyScope.Message())
+	}
+
+	return normalized, nil
+}
+
+// expiresAt returns expiry time of new key, maxExpireDays is used when not set
+func (l *APIKeyLogic) expiresAt(days int) (*time.Time, error) {
+	if days < 0 || (l.config.MaxExpireDays > 0 && days > l.config.MaxExpireDays) {
+		return nil, util.NewError(_const.CodeBadRequest.Message())
+	}
+	if days == 0 {
+		days = l.config.MaxExpireDays
+	}
+	if days == 0 {
+		return nil, nil
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, days)
+	return &expiresAt, nil
+}
+
+// apiKeyOwnedBy reports whether key belongs to tenant, or to user when tenantID is empty
+func apiKeyOwnedBy(apiKey *entity.APIKey, userID uint, tenantID string) bool {
+	if tenantID != "" {
+		return apiKey.TenantID != nil && *apiKey.TenantID == tenantID
+	}
+	return apiKey.TenantID == nil && apiKey.UserID == userID
+}
+
+// intersectScopes returns scopes granted by effective permissions
+func intersectScopes(scopes entity.APIKeyScopes, effective *model.EffectivePermissions) entity.APIKeyScopes {
+	granted := make(entity.APIKeyScopes, 0, len(scopes))
+	for _, scope := range scopes {
+		if effective.Has(scope.Resource, scope.Action) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+// apiKeyActivity returns activity metadata of key merged with extra fields
+func apiKeyActivity(apiKey *entity.APIKey, extra entity.JSONMap) entity.JSONMap {
+	metadata := entity.JSONMap{
+		"api_key_id": apiKey.ID,
+		"prefix":     apiKey.Prefix,
+	}
+	if apiKey.TenantID != nil {
+		metadata["tenant_id"] = *apiKey.TenantID
+	}
+	for key, value := range extra {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// apiKeyActive reports whether key isn't revoked nor expired
+func apiKeyActive(apiKey *entity.APIKey, now time.Time) bool {
+	return apiKey.RevokedAt == nil && (apiKey.ExpiresAt == nil || now.Before(*apiKey.ExpiresAt))
+}
+
+// hashAPIKey returns SHA-256 hex digest of API key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+func TestIntersectScopes(t *testing.T) {
+	scopes := entity.APIKeyScopes{
+		{Resource: "url", Action: "create"},
+		{Resource: "url", Action: "read"},
+		{Resource: "analytics", Action: "read"},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []model.Permission
+		want        entity.APIKeyScopes
+	}{
+		{
+			name:        "owner has every scope",
+			permissions: []model.Permission{{Resource: "url", Action: "create"}, {Resource: "url", Action: "read"}, {Resource: "analytics", Action: "read"}},
+			want:        scopes,
+		},
+		{
+			name:        "owner lost some scopes",
+			permissions: []model.Permission{{Resource: "url", Action: "read"}},
+			want:        entity.APIKeyScopes{{Resource: "url", Action: "read"}},
+		},
+		{
+			name:        "wildcard action of owner",
+			permissions: []model.Permission{{Resource: "url", Action: model.PermissionWildcard}},
+			want:        entity.APIKeyScopes{{Resource: "url", Action: "create"}, {Resource: "url", Action: "read"}},
+		},
+		{
+			name:        "owner has no permission",
+			permissions: nil,
+			want:        entity.APIKeyScopes{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := intersectScopes(scopes, &model.EffectivePermissions{Permissions: tt.permissions})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("intersectScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyOwnedBy(t *testing.T) {
+	tenantID := "t1"
+	userKey := &entity.APIKey{UserID: 7}
+	tenantKey := &entity.APIKey{UserID: 7, TenantID: &tenantID}
+
+	tests := []struct {
+		name     string
+		apiKey   *entity.APIKey
+		userID   uint
+		tenantID string
+		want     bool
+	}{
+		{name: "key of user", apiKey: userKey, userID: 7, want: true},
+		{name: "key of other user", apiKey: userKey, userID: 8, want: false},
+		{name: "key of tenant isn't personal key of issuer", apiKey: tenantKey, userID: 7, want: false},
+		{name: "key of tenant managed by other admin", apiKey: tenantKey, userID: 8, tenantID: "t1", want: true},
+		{name: "key of other tenant", apiKey: tenantKey, userID: 7, tenantID: "t2", want: false},
+		{name: "key of user isn't key of tenant", apiKey: userKey, userID: 7, tenantID: "t1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := apiKeyOwnedBy(tt.apiKey, tt.userID, tt.tenantID); got != tt.want {
+				t.Errorf("apiKeyOwnedBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
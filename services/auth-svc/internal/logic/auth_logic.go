@@ -2,6 +2,9 @@ package logic
 
 import (
 	"context"
+	"errors"
+	"net/mail"
+	"time"
 
 	_const "github.com/taititans/bitzap/auth-svc/internal/const"
 	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
@@ -16,6 +19,14 @@ type EmailServiceInterface interface {
 	SendEmailVerification(ctx context.Context, req model.EmailVerificationRequest) error
 	SendPasswordReset(ctx context.Context, req model.PasswordResetRequest) error
 	SendWelcomeEmail(ctx context.Context, email, name string) error
+	SendLoginOTP(ctx context.Context, email, name, code string, expireMinute int) error
+	SendNewDeviceAlert(ctx context.Context, email, name string, alert model.NewDeviceAlert) error
+	SendEmailChangeConfirmation(ctx context.Context, req model.EmailChangeConfirmation) error
+	SendEmailChangeNotice(ctx context.Context, email, name, newEmail string) error
+	VerifyEmailChangeToken(ctx context.Context, token string) (*model.EmailChangeConfirmation, error)
+	SendDataExportReady(ctx context.Context, email, name, token string, expireHour int) error
+	SendAccountDeletionScheduled(ctx context.Context, email, name string, dueAt time.Time) error
+	SendTenantInvitation(ctx context.Context, req model.TenantInvitationEmail) error
 	SendEmail(ctx context.Context, data model.EmailData) error
 	VerifyEmailToken(ctx context.Context, token string) (uint, error)
 	VerifyPasswordResetToken(ctx context.Context, token string) (string, error)
@@ -28,6 +39,19 @@ type AuthLogic struct {
 	userPermissionRepo repository.UserPermissionRepository
 	userActivityRepo   repository.UserActivityLogRepository
 	emailService       EmailServiceInterface
+	tokenLogic         *TokenLogic
+	loginGuard         *LoginGuardLogic
+	passwordPolicy     *PasswordPolicy
+	usernamePolicy     *UsernamePolicy
+	twoFactor          *TwoFactorLogic
+	otp                *OTPLogic
+	oauth              *OAuthLogic
+	kong               *KongLogic
+	permissions        *PermissionLogic
+	devices            *DeviceLogic
+	verification       *EmailVerificationLogic
+	invitations        *InvitationLogic
+	reauth             *ReauthLogic
 	logger             util.Logger
 }
 
@@ -38,6 +62,19 @@ func NewAuthLogic(
 	userPermissionRepo repository.UserPermissionRepository,
 	userActivityRepo repository.UserActivityLogRepository,
 	emailService EmailServiceInterface,
+	tokenLogic *TokenLogic,
+	loginGuard *LoginGuardLogic,
+	passwordPolicy *PasswordPolicy,
+	usernamePolicy *UsernamePolicy,
+	twoFactor *TwoFactorLogic,
+	otp *OTPLogic,
+	oauth *OAuthLogic,
+	kong *KongLogic,
+	permissions *PermissionLogic,
+	devices *DeviceLogic,
+	verification *EmailVerificationLogic,
+	invitations *InvitationLogic,
+	reauth *ReauthLogic,
 	logger util.Logger,
 ) *AuthLogic {
 	return &AuthLogic{
@@ -46,6 +83,19 @@ func NewAuthLogic(
 		userPermissionRepo: userPermissionRepo,
 		userActivityRepo:   userActivityRepo,
 		emailService:       emailService,
+		tokenLogic:         tokenLogic,
+		loginGuard:         loginGuard,
+		passwordPolicy:     passwordPolicy,
+		usernamePolicy:     usernamePolicy,
+		twoFactor:          twoFactor,
+		otp:                otp,
+		oauth:              oauth,
+		kong:               kong,
+		permissions:        permissions,
+		devices:            devices,
+		verification:       verification,
+		invitations:        invitations,
+		reauth:             reauth,
 		logger:             logger,
 	}
 }
@@ -57,6 +107,40 @@ func (l *AuthLogic) RegisterUser(ctx context.Context, req model.RegisterRequest)
 		util.String("username", req.Username),
 	)
 
+	// Emails are stored lowercased so they match regardless of case
+	req.Email = normalizeEmail(req.Email)
+
+	// Check username format
+	req.Username = l.usernamePolicy.Normalize(req.Username)
+	if err := l.usernamePolicy.Validate(req.Username); err != nil {
+		return nil, err
+	}
+
+	// Check password strength
+	if err := l.passwordPolicy.Validate(req.Password, req.Email, req.Username); err != nil {
+		return nil, err
+	}
+
+	// Invitation link proves ownership of email it was sent to
+	if req.InvitationToken != "" {
+		invitation, err := l.invitations.Pending(ctx, req.InvitationToken)
+		if err != nil {
+			return nil, err
+		}
+		if req.Email != invitation.Email {
+			return nil, util.NewError(_const.CodeInvitationEmail.Message())
+		}
+	}
+
+	// Phones are stored in E.164 format
+	if req.Phone != "" {
+		phone, err := l.otp.NormalizePhone(req.Phone)
+		if err != nil {
+			return nil, err
+		}
+		req.Phone = phone
+	}
+
 	// Check if email already exists
 	existingUser, err := l.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
@@ -95,30 +179,56 @@ func (l *AuthLogic) RegisterUser(ctx context.Context, req model.RegisterRequest)
 		IsActive:     true,
 		IsVerified:   false,
 	}
+	if req.InvitationToken != "" {
+		now := time.Now()
+		user.IsVerified = true
+		user.EmailVerifiedAt = &now
+	}
 
 	if err := l.userRepo.Create(ctx, user); err != nil {
 		l.logger.Error("Failed to create user", util.Error(err))
 		return nil, err
 	}
 
-	// Send welcome email
-	if err := l.emailService.SendWelcomeEmail(ctx, user.Email, user.Firstname); err != nil {
-		l.logger.Error("Failed to send welcome email", util.Error(err))
-		// Don't fail registration if email fails
+	// Assign default role, can be assigned again by admin when it fails
+	if err := l.permissions.AssignDefaultRole(ctx, user.ID); err != nil {
+		l.logger.Error("Failed to assign default role", util.Int("user_id", int(user.ID)), util.Error(err))
 	}
 
-	// Send email verification
-	if err := l.emailService.SendEmailVerification(ctx, model.EmailVerificationRequest{
-		UserID: user.ID,
-		Email:  user.Email,
-	}); err != nil {
-		l.logger.Error("Failed to send email verification", util.Error(err))
-		// Don't fail registration if email fails
+	l.kong.SyncUser(ctx, user)
+
+	// Send welcome email, registration doesn't fail or wait for it
+	email, name := user.Email, user.Firstname
+	sendAsync(l.logger, "Failed to send welcome email", func(ctx context.Context) error {
+		return l.emailService.SendWelcomeEmail(ctx, email, name)
+	})
+
+	if req.InvitationToken == "" {
+		// Send email verification, can be resent when it fails
+		verification := model.EmailVerificationRequest{
+			UserID: user.ID,
+			Email:  user.Email,
+		}
+		sendAsync(l.logger, "Failed to send email verification", func(ctx context.Context) error {
+			return l.emailService.SendEmailVerification(ctx, verification)
+		})
 	}
 
 	// Log activity
 	l.userActivityRepo.LogActivity(ctx, user.ID, "register", "user", req.IPAddress, req.UserAgent, nil)
 
+	if req.InvitationToken != "" {
+		if _, err := l.invitations.Accept(ctx, model.InvitationTokenRequest{
+			Token:     req.InvitationToken,
+			UserID:    user.ID,
+			IPAddress: req.IPAddress,
+			UserAgent: req.UserAgent,
+		}); err != nil {
+			l.logger.Error("Failed to accept tenant invitation", util.Int("user_id", int(user.ID)), util.Error(err))
+			// Don't fail registration, invitation can be accepted again while pending
+		}
+	}
+
 	l.logger.Info("User registered successfully",
 		util.Int("user_id", int(user.ID)),
 		util.String("email", user.Email),
@@ -127,19 +237,29 @@ func (l *AuthLogic) RegisterUser(ctx context.Context, req model.RegisterRequest)
 	return user, nil
 }
 
-// LoginUser authenticates a user
-func (l *AuthLogic) LoginUser(ctx context.Context, req model.LoginRequest) (*entity.User, error) {
+// LoginUser authenticates a user and issues tokens.
+// When two-factor is enabled only challenge is returned and tokens are issued by VerifyTwoFactorLogin.
+func (l *AuthLogic) LoginUser(ctx context.Context, req model.LoginRequest) (*model.LoginResult, error) {
 	l.logger.Info("User login attempt",
 		util.String("email", req.Email),
 	)
 
+	// Check brute-force blocks
+	if err := l.loginGuard.CheckBlocked(ctx, req); err != nil {
+		l.logger.Warn("Blocked login attempt",
+			util.String("email", req.Email),
+			util.String("ip", req.IPAddress),
+		)
+		return nil, err
+	}
+
 	user, err := l.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		l.logger.Error("Failed to get user by email", util.Error(err))
 		return nil, err
 	}
 	if user == nil {
-		return nil, util.NewError(_const.CodeWrongPassword.Message())
+		return nil, l.loginFailed(ctx, nil, req)
 	}
 
 	// Check password
@@ -147,7 +267,7 @@ func (l *AuthLogic) LoginUser(ctx context.Context, req model.LoginRequest) (*ent
 		l.logger.Warn("Invalid password for user",
 			util.String("email", req.Email),
 		)
-		return nil, util.NewError(_const.CodeWrongPassword.Message())
+		return nil, l.loginFailed(ctx, user, req)
 	}
 
 	// Check if user is active
@@ -155,20 +275,321 @@ func (l *AuthLogic) LoginUser(ctx context.Context, req model.LoginRequest) (*ent
 		return nil, util.NewError(_const.CodeLockingAccount.Message())
 	}
 
+	result, err := l.secondFactorOrComplete(ctx, user, req.IPAddress, req.UserAgent, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failed attempts are kept until second factor passes, so it can't be guessed
+	// by repeating password step
+	if result.Tokens != nil {
+		l.clearFailedLogins(ctx, user)
+	}
+
+	return result, nil
+}
+
+// RequestLoginOTP sends one-time passcode for passwordless login
+func (l *AuthLogic) RequestLoginOTP(ctx context.Context, req model.OTPRequest) (*model.OTPResponse, error) {
+	l.logger.Info("Login OTP requested",
+		util.Int("type", req.Type),
+		util.String("ip", req.IPAddress),
+	)
+
+	return l.otp.RequestOTP(ctx, req)
+}
+
+// LoginWithOTP authenticates user with one-time passcode
+func (l *AuthLogic) LoginWithOTP(ctx context.Context, req model.OTPLoginRequest) (*model.LoginResult, error) {
+	destination, err := l.otp.NormalizeDestination(req.Type, req.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrong codes count toward login block of destination and IP like wrong passwords
+	guardReq := model.LoginRequest{
+		Email:     destination,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	}
+	if err := l.loginGuard.CheckBlocked(ctx, guardReq); err != nil {
+		return nil, err
+	}
+
+	user, err := l.otp.VerifyOTP(ctx, req)
+	if err != nil {
+		if _, ok := _const.CodeFromError(err, _const.CodeOTPInvalid, _const.CodeExceedLoginAttempts); ok {
+			if guardErr := l.loginGuard.RegisterFailure(ctx, nil, guardReq); guardErr != nil {
+				return nil, guardErr
+			}
+		}
+		return nil, err
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		return nil, util.NewError(_const.CodeLockingAccount.Message())
+	}
+
+	result, err := l.secondFactorOrComplete(ctx, user, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"method": "otp",
+		"type":   req.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Tokens != nil {
+		if err := l.loginGuard.RegisterSuccess(ctx, guardReq); err != nil {
+			l.logger.Error("Failed to clear failed OTP logins", util.Error(err))
+		}
+	}
+
+	return result, nil
+}
+
+// RequestPhoneVerification sends verification code to phone of user
+func (l *AuthLogic) RequestPhoneVerification(ctx context.Context, userID uint) (*model.OTPResponse, error) {
+	return l.otp.RequestPhoneVerification(ctx, userID)
+}
+
+// VerifyPhone verifies phone of user with code sent to it, verified phone can log in with OTP
+func (l *AuthLogic) VerifyPhone(ctx context.Context, userID uint, req model.PhoneVerificationRequest) error {
+	if err := l.otp.ConfirmPhoneVerification(ctx, userID, req.Code); err != nil {
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "verify_phone", "user", req.IPAddress, req.UserAgent, nil)
+
+	return nil
+}
+
+// OAuthAuthorize returns provider authorize URL for social login
+func (l *AuthLogic) OAuthAuthorize(ctx context.Context, provider string) (*model.OAuthAuthorization, error) {
+	return l.oauth.Authorize(ctx, provider)
+}
+
+// LoginWithOAuth authenticates user returned to OAuth callback
+func (l *AuthLogic) LoginWithOAuth(ctx context.Context, req model.OAuthCallbackRequest) (*model.LoginResult, error) {
+	user, err := l.oauth.Callback(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		return nil, util.NewError(_const.CodeLockingAccount.Message())
+	}
+
+	return l.secondFactorOrComplete(ctx, user, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"method":   "oauth",
+		"provider": req.Provider,
+	})
+}
+
+// secondFactorOrComplete returns two-factor challenge when enabled, otherwise completes login.
+// Unverified users are checked against email verification policy first.
+func (l *AuthLogic) secondFactorOrComplete(ctx context.Context, user *entity.User, ipAddress, userAgent string, metadata entity.JSONMap) (*model.LoginResult, error) {
+	if err := l.verification.CheckLogin(user); err != nil {
+		l.logger.Warn("Login of unverified user rejected",
+			util.Int("user_id", int(user.ID)),
+		)
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled {
+		return l.completeLogin(ctx, user, ipAddress, userAgent, metadata)
+	}
+
+	challenge, err := l.twoFactor.CreateChallenge(ctx, user)
+	if err != nil {
+		l.logger.Error("Failed to create two-factor challenge", util.Error(err))
+		return nil, err
+	}
+
+	l.logger.Info("Two-factor required for login",
+		util.Int("user_id", int(user.ID)),
+	)
+
+	return &model.LoginResult{User: user, TwoFactor: challenge}, nil
+}
+
+// VerifyTwoFactorLogin completes login with TOTP or recovery code and issues tokens
+func (l *AuthLogic) VerifyTwoFactorLogin(ctx context.Context, req model.TwoFactorVerifyRequest) (*model.LoginResult, error) {
+	user, err := l.twoFactor.VerifyChallenge(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		return nil, util.NewError(_const.CodeLockingAccount.Message())
+	}
+
+	result, err := l.completeLogin(ctx, user, req.IPAddress, req.UserAgent, entity.JSONMap{
+		"two_factor": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.clearFailedLogins(ctx, user)
+
+	return result, nil
+}
+
+// completeLogin updates last login, issues tokens and logs activity
+func (l *AuthLogic) completeLogin(ctx context.Context, user *entity.User, ipAddress, userAgent string, metadata entity.JSONMap) (*model.LoginResult, error) {
 	// Update last login
 	if err := l.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
 		l.logger.Error("Failed to update last login", util.Error(err))
 	}
 
+	// Issue tokens
+	tokens, err := l.tokenLogic.GenerateTokenPair(ctx, user, model.SessionClient{
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		l.logger.Error("Failed to generate tokens", util.Error(err))
+		return nil, err
+	}
+
+	// Alert about login from new device, login proceeds when check fails
+	newDevice, err := l.devices.CheckLogin(ctx, user, ipAddress, userAgent)
+	if err != nil {
+		l.logger.Error("Failed to check login device", util.Int("user_id", int(user.ID)), util.Error(err))
+	}
+	if newDevice {
+		if metadata == nil {
+			metadata = entity.JSONMap{}
+		}
+		metadata["new_device"] = true
+	}
+
 	// Log activity
-	l.userActivityRepo.LogActivity(ctx, user.ID, "login", "user", req.IPAddress, req.UserAgent, nil)
+	l.userActivityRepo.LogActivity(ctx, user.ID, "login", "user", ipAddress, userAgent, metadata)
 
 	l.logger.Info("User logged in successfully",
 		util.Int("user_id", int(user.ID)),
 		util.String("email", user.Email),
 	)
 
-	return user, nil
+	return &model.LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// clearFailedLogins clears failed login attempts of user after all factors passed
+func (l *AuthLogic) clearFailedLogins(ctx context.Context, user *entity.User) {
+	if err := l.loginGuard.RegisterSuccess(ctx, model.LoginRequest{Email: user.Email}); err != nil {
+		l.logger.Error("Failed to clear failed login attempts", util.Error(err))
+	}
+}
+
+// loginFailed records failed login attempt and returns error for client
+func (l *AuthLogic) loginFailed(ctx context.Context, user *entity.User, req model.LoginRequest) error {
+	if err := l.loginGuard.RegisterFailure(ctx, user, req); err != nil {
+		return err
+	}
+	return util.NewError(_const.CodeWrongPassword.Message())
+}
+
+// RefreshToken rotates refresh token and issues a new token pair
+func (l *AuthLogic) RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (*model.TokenPair, error) {
+	claims, err := l.tokenLogic.ParseToken(req.RefreshToken, _const.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.Info("Refreshing token",
+		util.Int("user_id", int(claims.UserID)),
+		util.String("family_id", claims.FamilyID),
+	)
+
+	if err := l.tokenLogic.ConsumeRefreshToken(ctx, claims); err != nil {
+		if errors.Is(err, _const.ErrorRefreshTokenReuse) {
+			// Log activity
+			l.userActivityRepo.LogActivity(ctx, claims.UserID, "refresh_token_reuse", "token", req.IPAddress, req.UserAgent, entity.JSONMap{
+				"family_id": claims.FamilyID,
+				"token_id":  claims.ID,
+			})
+			return nil, util.NewError(_const.CodeInvalidToken.Message())
+		}
+		return nil, err
+	}
+
+	user, err := l.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		if err := l.tokenLogic.RevokeTokenFamily(ctx, claims.FamilyID); err != nil {
+			l.logger.Error("Failed to revoke token family", util.Error(err))
+		}
+		return nil, util.NewError(_const.CodeLockingAccount.Message())
+	}
+
+	// Grace period may be over or policy changed since login
+	if err := l.verification.CheckLogin(user); err != nil {
+		if err := l.tokenLogic.RevokeTokenFamily(ctx, claims.FamilyID); err != nil {
+			l.logger.Error("Failed to revoke token family", util.Error(err))
+		}
+		return nil, err
+	}
+
+	tokens, err := l.tokenLogic.RotateTokenPair(ctx, user, claims.FamilyID, model.SessionClient{
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+	})
+	if err != nil {
+		l.logger.Error("Failed to rotate tokens", util.Error(err))
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes current access token and its token family
+func (l *AuthLogic) Logout(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error {
+	l.logger.Info("User logout",
+		util.Int("user_id", int(claims.UserID)),
+	)
+
+	if err := l.tokenLogic.RevokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+	if err := l.tokenLogic.RevokeTokenFamily(ctx, claims.FamilyID); err != nil {
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, claims.UserID, "logout", "token", req.IPAddress, req.UserAgent, nil)
+
+	return nil
+}
+
+// LogoutAll revokes every token held by current user
+func (l *AuthLogic) LogoutAll(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error {
+	l.logger.Info("User logout from all sessions",
+		util.Int("user_id", int(claims.UserID)),
+	)
+
+	if err := l.tokenLogic.RevokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, claims.UserID); err != nil {
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, claims.UserID, "logout_all", "token", req.IPAddress, req.UserAgent, nil)
+
+	return nil
 }
 
 // GetUserProfile gets user profile with roles and permissions
@@ -217,10 +638,21 @@ func (l *AuthLogic) UpdateUserProfile(ctx context.Context, userID uint, req mode
 		return nil, util.NewError(_const.CodeUserNotFound.Message())
 	}
 
+	phone := req.Phone
+	if phone != "" {
+		if phone, err = l.otp.NormalizePhone(phone); err != nil {
+			return nil, err
+		}
+	}
+	// Changed phone must be verified again before it can log in
+	if phone != user.Phone {
+		user.PhoneVerifiedAt = nil
+	}
+
 	// Update fields
 	user.Firstname = req.FirstName
 	user.Lastname = req.LastName
-	user.Phone = req.Phone
+	user.Phone = phone
 	user.AvatarURL = req.AvatarURL
 
 	if err := l.userRepo.Update(ctx, user); err != nil {
@@ -238,6 +670,135 @@ func (l *AuthLogic) UpdateUserProfile(ctx context.Context, userID uint, req mode
 	return user, nil
 }
 
+// RequestEmailChange sends confirmation link to new email and notice to current one.
+// Request is confirmed with ReauthProof, email is changed only after link is confirmed.
+func (l *AuthLogic) RequestEmailChange(ctx context.Context, userID uint, req model.ChangeEmailRequest) error {
+	l.logger.Info("Requesting email change",
+		util.Int("user_id", int(userID)),
+	)
+
+	newEmail := normalizeEmail(req.NewEmail)
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return util.NewError(_const.CodeInvalidEmail.Message())
+	}
+
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return err
+	}
+	if user == nil {
+		return util.NewError(_const.CodeUserNotFound.Message())
+	}
+
+	if err := l.reauth.Check(ctx, user, req.ReauthProof); err != nil {
+		return err
+	}
+
+	if newEmail == normalizeEmail(user.Email) {
+		return util.NewError(_const.CodeEmailUnchanged.Message())
+	}
+	if err := l.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+
+	if err := l.emailService.SendEmailChangeConfirmation(ctx, model.EmailChangeConfirmation{
+		UserID:   userID,
+		FamilyID: req.FamilyID,
+		Name:     user.Firstname,
+		NewEmail: newEmail,
+	}); err != nil {
+		l.logger.Error("Failed to send email change confirmation", util.Error(err))
+		return err
+	}
+
+	// Owner of current address learns about change even if account is taken over
+	if err := l.emailService.SendEmailChangeNotice(ctx, user.Email, user.Firstname, newEmail); err != nil {
+		l.logger.Error("Failed to send email change notice", util.Error(err))
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "email_change_requested", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"new_email": newEmail,
+	})
+
+	return nil
+}
+
+// ConfirmEmailChange switches email of user to new address confirmed by token.
+// Sessions other than the one that requested change are revoked.
+func (l *AuthLogic) ConfirmEmailChange(ctx context.Context, req model.ConfirmEmailChangeRequest) error {
+	change, err := l.emailService.VerifyEmailChangeToken(ctx, req.Token)
+	if err != nil {
+		l.logger.Error("Failed to verify email change token", util.Error(err))
+		return err
+	}
+
+	user, err := l.userRepo.GetByID(ctx, change.UserID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return err
+	}
+	if user == nil {
+		return util.NewError(_const.CodeUserNotFound.Message())
+	}
+
+	// Email may have been taken since change was requested
+	if err := l.checkEmailAvailable(ctx, change.NewEmail); err != nil {
+		return err
+	}
+
+	// Other sessions are revoked first so email isn't changed while they stay signed in
+	if err := l.tokenLogic.RevokeOtherUserTokens(ctx, user.ID, change.FamilyID); err != nil {
+		l.logger.Error("Failed to revoke user sessions", util.Error(err))
+		return err
+	}
+
+	if err := l.userRepo.UpdateEmail(ctx, user.ID, change.NewEmail); err != nil {
+		l.logger.Error("Failed to update user email", util.Error(err))
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, user.ID, "email_changed", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"old_email": user.Email,
+		"new_email": change.NewEmail,
+	})
+
+	l.logger.Info("User email changed successfully",
+		util.Int("user_id", int(user.ID)),
+	)
+
+	return nil
+}
+
+// RequestReauthCode sends code confirming sensitive actions to email of user
+func (l *AuthLogic) RequestReauthCode(ctx context.Context, userID uint) (*model.OTPResponse, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+
+	return l.reauth.RequestCode(ctx, user)
+}
+
+// checkEmailAvailable returns CodeEmailExists when email belongs to another user
+func (l *AuthLogic) checkEmailAvailable(ctx context.Context, email string) error {
+	existing, err := l.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		l.logger.Error("Failed to check existing email", util.Error(err))
+		return err
+	}
+	if existing != nil {
+		return util.NewError(_const.CodeEmailExists.Message())
+	}
+	return nil
+}
+
 // ChangePassword changes user password
 func (l *AuthLogic) ChangePassword(ctx context.Context, userID uint, req model.ChangePasswordRequest) error {
 	l.logger.Info("Changing user password",
@@ -261,6 +822,11 @@ func (l *AuthLogic) ChangePassword(ctx context.Context, userID uint, req model.C
 		return util.NewError(_const.CodeWrongOldPassword.Message())
 	}
 
+	// Check password strength
+	if err := l.passwordPolicy.Validate(req.NewPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -274,6 +840,12 @@ func (l *AuthLogic) ChangePassword(ctx context.Context, userID uint, req model.C
 		return err
 	}
 
+	// Revoke all existing sessions
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke user sessions", util.Error(err))
+		return err
+	}
+
 	// Log activity
 	l.userActivityRepo.LogActivity(ctx, userID, "change_password", "user", req.IPAddress, req.UserAgent, nil)
 
@@ -343,8 +915,7 @@ func (l *AuthLogic) VerifyEmail(ctx context.Context, token string) error {
 		return util.NewError("User not found")
 	}
 
-	user.IsVerified = true
-	if err := l.userRepo.Update(ctx, user); err != nil {
+	if err := l.userRepo.VerifyEmail(ctx, userID); err != nil {
 		l.logger.Error("Failed to update user verification status", util.Error(err))
 		return err
 	}
@@ -359,6 +930,11 @@ func (l *AuthLogic) VerifyEmail(ctx context.Context, token string) error {
 	return nil
 }
 
+// ResendVerification sends new email verification link
+func (l *AuthLogic) ResendVerification(ctx context.Context, req model.ResendVerificationRequest) (*model.ResendVerificationResponse, error) {
+	return l.verification.Resend(ctx, req)
+}
+
 // ResetPassword resets user password with token
 func (l *AuthLogic) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
 	l.logger.Info("Resetting password with token",
@@ -382,6 +958,11 @@ func (l *AuthLogic) ResetPassword(ctx context.Context, req model.ResetPasswordRe
 		return util.NewError("User not found")
 	}
 
+	// Check password strength
+	if err := l.passwordPolicy.Validate(req.NewPassword, user.Email, user.Username); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -396,6 +977,12 @@ func (l *AuthLogic) ResetPassword(ctx context.Context, req model.ResetPasswordRe
 		return err
 	}
 
+	// Revoke all existing sessions
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, user.ID); err != nil {
+		l.logger.Error("Failed to revoke user sessions", util.Error(err))
+		return err
+	}
+
 	// Log activity
 	l.userActivityRepo.LogActivity(ctx, user.ID, "password_reset", "user", req.IPAddress, req.UserAgent, nil)
 
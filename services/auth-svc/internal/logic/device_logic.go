@@ -0,0 +1,253 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const deviceReportTokenLength = 48
+
+// DeviceLogic tracks devices users log in from and alerts them about new ones.
+// Device is identified by user agent and IP subnet, so address changes inside
+// the same network don't trigger alerts.
+type DeviceLogic struct {
+	config           config.DeviceAlertConfig
+	userRepo         repository.UserRepository
+	deviceRepo       repository.UserDeviceRepository
+	userActivityRepo repository.UserActivityLogRepository
+	redisRepo        repository.RedisRepository
+	emailService     EmailServiceInterface
+	tokenLogic       *TokenLogic
+	kong             *KongLogic
+	logger           util.Logger
+}
+
+// NewDeviceLogic creates new DeviceLogic instance
+func NewDeviceLogic(
+	config config.DeviceAlertConfig,
+	userRepo repository.UserRepository,
+	deviceRepo repository.UserDeviceRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	redisRepo repository.RedisRepository,
+	emailService EmailServiceInterface,
+	tokenLogic *TokenLogic,
+	kong *KongLogic,
+	logger util.Logger,
+) *DeviceLogic {
+	return &DeviceLogic{
+		config:           config,
+		userRepo:         userRepo,
+		deviceRepo:       deviceRepo,
+		userActivityRepo: userActivityRepo,
+		redisRepo:        redisRepo,
+		emailService:     emailService,
+		tokenLogic:       tokenLogic,
+		kong:             kong,
+		logger:           logger,
+	}
+}
+
+// CheckLogin records device of login and reports whether it is new for user.
+// First device of user is recorded without alert.
+func (l *DeviceLogic) CheckLogin(ctx context.Context, user *entity.User, ipAddress, userAgent string) (bool, error) {
+	now := time.Now()
+	fingerprint := l.fingerprint(ipAddress, userAgent)
+
+	device, err := l.deviceRepo.GetByFingerprint(ctx, user.ID, fingerprint)
+	if err != nil {
+		l.logger.Error("Failed to get user device", util.Error(err))
+		return false, err
+	}
+	if device != nil {
+		if err := l.deviceRepo.Touch(ctx, device.ID, ipAddress, now); err != nil {
+			l.logger.Error("Failed to update user device", util.Error(err))
+			return false, err
+		}
+		return false, nil
+	}
+
+	known, err := l.deviceRepo.CountByUserID(ctx, user.ID)
+	if err != nil {
+		l.logger.Error("Failed to count user devices", util.Error(err))
+		return false, err
+	}
+
+	device = &entity.UserDevice{
+		UserID:      user.ID,
+		Fingerprint: fingerprint,
+		UserAgent:   userAgent,
+		IPAddress:   ipAddress,
+		LastSeenAt:  now,
+	}
+	if err := l.deviceRepo.Create(ctx, device); err != nil {
+		l.logger.Error("Failed to create user device", util.Error(err))
+		return false, err
+	}
+
+	if known == 0 {
+		return false, nil
+	}
+
+	if l.config.Enabled {
+		l.sendAlert(user, device)
+	}
+
+	return true, nil
+}
+
+// PreviewReport shows reported login of token without using it, so link
+// scanners opening alert emails can't lock accounts
+func (l *DeviceLogic) PreviewReport(ctx context.Context, token string) (*model.DeviceReportPreview, error) {
+	value, err := l.redisRepo.Get(ctx, _const.RedisKeyDeviceReport.Key(token))
+	if err != nil {
+		return nil, err
+	}
+	userID, deviceID, ok := parseDeviceReport(value)
+	if !ok {
+		return nil, util.NewError(_const.CodeDeviceReportInvalid.Message())
+	}
+
+	device, err := l.deviceRepo.GetByID(ctx, deviceID)
+	if err != nil {
+		l.logger.Error("Failed to get reported device", util.Error(err))
+		return nil, err
+	}
+	if device == nil || device.UserID != userID {
+		return nil, util.NewError(_const.CodeDeviceReportInvalid.Message())
+	}
+
+	return &model.DeviceReportPreview{
+		IPAddress: device.IPAddress,
+		UserAgent: device.UserAgent,
+		LoginAt:   device.CreatedAt,
+	}, nil
+}
+
+// ReportLogin handles confirmed "this wasn't me" report: locks account, revokes
+// sessions and forgets reported device
+func (l *DeviceLogic) ReportLogin(ctx context.Context, req model.DeviceReportRequest) error {
+	// Token is single use, it's dropped once account is locked and sessions are revoked
+	// so report can be retried when that fails
+	reportKey := _const.RedisKeyDeviceReport.Key(req.Token)
+	value, err := l.redisRepo.Get(ctx, reportKey)
+	if err != nil {
+		return err
+	}
+	userID, deviceID, ok := parseDeviceReport(value)
+	if !ok {
+		return util.NewError(_const.CodeDeviceReportInvalid.Message())
+	}
+
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return err
+	}
+	if user == nil {
+		return util.NewError(_const.CodeDeviceReportInvalid.Message())
+	}
+
+	if user.IsActive {
+		if err := l.userRepo.SetActive(ctx, userID, false); err != nil {
+			l.logger.Error("Failed to lock user", util.Error(err))
+			return err
+		}
+	}
+
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke tokens of reported user", util.Int("user_id", int(userID)), util.Error(err))
+		return err
+	}
+
+	if err := l.redisRepo.Del(ctx, reportKey); err != nil {
+		l.logger.Error("Failed to delete device report token", util.Error(err))
+	}
+
+	l.kong.RemoveUser(ctx, userID)
+
+	if err := l.deviceRepo.Delete(ctx, deviceID); err != nil {
+		l.logger.Error("Failed to delete reported device", util.Error(err))
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "login_reported", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"device_id": deviceID,
+	})
+
+	l.logger.Warn("Login from new device reported, account locked",
+		util.Int("user_id", int(userID)),
+		util.Int("device_id", int(deviceID)),
+	)
+
+	return nil
+}
+
+// sendAlert stores report token and emails alert in background so login doesn't
+// wait for it, failures are only logged
+func (l *DeviceLogic) sendAlert(user *entity.User, device *entity.UserDevice) {
+	userID, email, name := user.ID, user.Email, user.Firstname
+	token := util.GenerateRandomString(deviceReportTokenLength)
+	value := fmt.Sprintf("%d:%d", userID, device.ID)
+	ttl := time.Duration(l.config.ReportExpireHour) * time.Hour
+	alert := model.NewDeviceAlert{
+		IPAddress:   device.IPAddress,
+		UserAgent:   device.UserAgent,
+		LoginAt:     device.LastSeenAt,
+		ReportToken: token,
+	}
+
+	sendAsync(l.logger, "Failed to send new device alert", func(ctx context.Context) error {
+		if err := l.redisRepo.Set(ctx, _const.RedisKeyDeviceReport.Key(token), value, ttl); err != nil {
+			return err
+		}
+		return l.emailService.SendNewDeviceAlert(ctx, email, name, alert)
+	})
+}
+
+// fingerprint hashes user agent with IP subnet of login
+func (l *DeviceLogic) fingerprint(ipAddress, userAgent string) string {
+	network := ipAddress
+	if addr, err := netip.ParseAddr(ipAddress); err == nil {
+		bits := l.config.IPv6PrefixBits
+		if addr.Is4() || addr.Is4In6() {
+			addr = addr.Unmap()
+			bits = l.config.IPv4PrefixBits
+		}
+		if prefix, err := addr.Prefix(bits); err == nil {
+			network = prefix.String()
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(userAgent)) + "|" + network))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseDeviceReport parses "userID:deviceID" stored for report token
+func parseDeviceReport(value string) (uint, uint, bool) {
+	userPart, devicePart, found := strings.Cut(value, ":")
+	if !found {
+		return 0, 0, false
+	}
+	userID, err := strconv.ParseUint(userPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	deviceID, err := strconv.ParseUint(devicePart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint(userID), uint(deviceID), true
+}
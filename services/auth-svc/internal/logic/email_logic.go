@@ -2,17 +2,42 @@ package logic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/google/uuid"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
 	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
 	"github.com/taititans/bitzap/auth-svc/internal/model"
 	"github.com/taititans/bitzap/auth-svc/internal/util"
 )
 
+// asyncSendTimeout bounds emails sent in background
+const asyncSendTimeout = 30 * time.Second
+
+// sendAsync sends email in background so slow mail provider doesn't hold up
+// request. Request context is recycled after response, send gets its own.
+// Failures are only logged.
+func sendAsync(logger util.Logger, message string, send func(ctx context.Context) error) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error(message, util.String("panic", fmt.Sprint(r)))
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), asyncSendTimeout)
+		defer cancel()
+		if err := send(ctx); err != nil {
+			logger.Error(message, util.Error(err))
+		}
+	}()
+}
+
 // EmailLogic contains email business logic
 type EmailLogic struct {
 	emailRepo repository.EmailRepository
@@ -48,10 +73,28 @@ func (s *EmailLogic) SendEmailVerification(ctx context.Context, req model.EmailV
 	value := fmt.Sprintf("%d", req.UserID)
 	expiration := 24 * time.Hour
 
+	// Only latest link stays valid, older token of user is invalidated
+	userKey := fmt.Sprintf("email_verification_user:%d", req.UserID)
+	oldToken, err := s.redisRepo.GetDel(ctx, userKey)
+	if err != nil {
+		s.logger.Error("Failed to get previous verification token from Redis", util.Error(err))
+		return err
+	}
+	if oldToken != "" {
+		if err := s.redisRepo.Del(ctx, fmt.Sprintf("email_verification:%s", oldToken)); err != nil {
+			s.logger.Error("Failed to delete previous verification token from Redis", util.Error(err))
+			return err
+		}
+	}
+
 	if err := s.redisRepo.Set(ctx, key, value, expiration); err != nil {
 		s.logger.Error("Failed to store verification token in Redis", util.Error(err))
 		return err
 	}
+	if err := s.redisRepo.Set(ctx, userKey, verificationToken, expiration); err != nil {
+		s.logger.Error("Failed to store verification token of user in Redis", util.Error(err))
+		return err
+	}
 
 	// Email template data
 	data := map[string]string{
@@ -145,6 +188,259 @@ func (s *EmailLogic) SendWelcomeEmail(ctx context.Context, email, name string) e
 	return s.emailRepo.SendEmail(ctx, emailData)
 }
 
+// SendLoginOTP sends one-time passcode for passwordless login
+func (s *EmailLogic) SendLoginOTP(ctx context.Context, email, name, code string, expireMinute int) error {
+	s.logger.Info("Sending login OTP email",
+		util.String("email", email),
+	)
+
+	data := map[string]string{
+		"Name":         name,
+		"Code":         code,
+		"ExpireMinute": fmt.Sprintf("%d", expireMinute),
+		"AppName":      "Bitzap",
+		"SupportEmail": "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   email,
+		ToName:    name,
+		Subject:   "Your Login Code - Bitzap",
+		HTMLBody:  s.generateLoginOTPHTML(data),
+		TextBody:  s.generateLoginOTPText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// SendNewDeviceAlert sends security alert about login from new device with "this wasn't me" link
+func (s *EmailLogic) SendNewDeviceAlert(ctx context.Context, email, name string, alert model.NewDeviceAlert) error {
+	s.logger.Info("Sending new device alert email",
+		util.String("email", email),
+	)
+
+	// Get config from repository
+	config := s.emailRepo.GetEmailConfig()
+
+	data := map[string]string{
+		"Name":         name,
+		"IPAddress":    alert.IPAddress,
+		"UserAgent":    alert.UserAgent,
+		"LoginAt":      alert.LoginAt.UTC().Format("2006-01-02 15:04 MST"),
+		"ReportURL":    fmt.Sprintf("%s/auth/security/report?token=%s", config.AppURL, alert.ReportToken),
+		"AppName":      "Bitzap",
+		"SupportEmail": "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   email,
+		ToName:    name,
+		Subject:   "New Sign-in to Your Account - Bitzap",
+		HTMLBody:  s.generateNewDeviceAlertHTML(data),
+		TextBody:  s.generateNewDeviceAlertText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// SendEmailChangeConfirmation sends link confirming email change to new address
+func (s *EmailLogic) SendEmailChangeConfirmation(ctx context.Context, req model.EmailChangeConfirmation) error {
+	s.logger.Info("Sending email change confirmation",
+		util.String("email", req.NewEmail),
+		util.Int("user_id", int(req.UserID)),
+	)
+
+	// Get config from repository
+	config := s.emailRepo.GetEmailConfig()
+
+	// Generate UUID for confirmation token
+	confirmationToken := uuid.New().String()
+	confirmationURL := fmt.Sprintf("%s/auth/confirm-email-change?token=%s", config.AppURL, confirmationToken)
+
+	// Store token in Redis with expiration (24 hours)
+	key := _const.RedisKeyEmailChange.Key(confirmationToken)
+	value, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	expiration := 24 * time.Hour
+
+	// Only latest link stays valid, older token of user is invalidated
+	userKey := _const.RedisKeyEmailChangeUser.Key(strconv.FormatUint(uint64(req.UserID), 10))
+	oldToken, err := s.redisRepo.GetDel(ctx, userKey)
+	if err != nil {
+		s.logger.Error("Failed to get previous email change token from Redis", util.Error(err))
+		return err
+	}
+	if oldToken != "" {
+		if err := s.redisRepo.Del(ctx, _const.RedisKeyEmailChange.Key(oldToken)); err != nil {
+			s.logger.Error("Failed to delete previous email change token from Redis", util.Error(err))
+			return err
+		}
+	}
+
+	if err := s.redisRepo.Set(ctx, key, string(value), expiration); err != nil {
+		s.logger.Error("Failed to store email change token in Redis", util.Error(err))
+		return err
+	}
+	if err := s.redisRepo.Set(ctx, userKey, confirmationToken, expiration); err != nil {
+		s.logger.Error("Failed to store email change token of user in Redis", util.Error(err))
+		return err
+	}
+
+	// Email template data
+	data := map[string]string{
+		"Name":            req.Name,
+		"ConfirmationURL": confirmationURL,
+		"AppName":         "Bitzap",
+		"SupportEmail":    "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   req.NewEmail,
+		ToName:    req.Name,
+		Subject:   "Confirm Your New Email - Bitzap",
+		HTMLBody:  s.generateEmailChangeHTML(data),
+		TextBody:  s.generateEmailChangeText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// SendEmailChangeNotice notifies current address that email change was requested
+func (s *EmailLogic) SendEmailChangeNotice(ctx context.Context, email, name, newEmail string) error {
+	s.logger.Info("Sending email change notice",
+		util.String("email", email),
+	)
+
+	data := map[string]string{
+		"Name":         name,
+		"NewEmail":     newEmail,
+		"AppName":      "Bitzap",
+		"SupportEmail": "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   email,
+		ToName:    name,
+		Subject:   "Email Change Requested - Bitzap",
+		HTMLBody:  s.generateEmailChangeNoticeHTML(data),
+		TextBody:  s.generateEmailChangeNoticeText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// VerifyEmailChangeToken consumes email change token and returns requested change
+func (s *EmailLogic) VerifyEmailChangeToken(ctx context.Context, token string) (*model.EmailChangeConfirmation, error) {
+	// Token is deleted as it's read so link can't be used twice
+	value, err := s.redisRepo.GetDel(ctx, _const.RedisKeyEmailChange.Key(token))
+	if err != nil {
+		s.logger.Error("Failed to get email change token from Redis", util.Error(err))
+		return nil, err
+	}
+
+	var change model.EmailChangeConfirmation
+	if value == "" || json.Unmarshal([]byte(value), &change) != nil || change.UserID == 0 || change.NewEmail == "" {
+		return nil, util.NewError(_const.CodeEmailChangeInvalid.Message())
+	}
+
+	// Only latest token of user is stored, so index points to this one
+	if err := s.redisRepo.Del(ctx, _const.RedisKeyEmailChangeUser.Key(strconv.FormatUint(uint64(change.UserID), 10))); err != nil {
+		s.logger.Error("Failed to delete email change token of user from Redis", util.Error(err))
+	}
+
+	return &change, nil
+}
+
+// SendDataExportReady sends link to download data export archive
+func (s *EmailLogic) SendDataExportReady(ctx context.Context, email, name, token string, expireHour int) error {
+	s.logger.Info("Sending data export email",
+		util.String("email", email),
+	)
+
+	// Get config from repository
+	config := s.emailRepo.GetEmailConfig()
+
+	data := map[string]string{
+		"Name":         name,
+		"DownloadURL":  fmt.Sprintf("%s/auth/export/download?token=%s", config.AppURL, token),
+		"ExpireHour":   fmt.Sprintf("%d", expireHour),
+		"AppName":      "Bitzap",
+		"SupportEmail": "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   email,
+		ToName:    name,
+		Subject:   "Your Data Export is Ready - Bitzap",
+		HTMLBody:  s.generateDataExportHTML(data),
+		TextBody:  s.generateDataExportText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// SendAccountDeletionScheduled sends notice that account will be deleted after grace period
+func (s *EmailLogic) SendAccountDeletionScheduled(ctx context.Context, email, name string, dueAt time.Time) error {
+	s.logger.Info("Sending account deletion email",
+		util.String("email", email),
+	)
+
+	data := map[string]string{
+		"Name":         name,
+		"DueAt":        dueAt.UTC().Format("2006-01-02 15:04 MST"),
+		"AppName":      "Bitzap",
+		"SupportEmail": "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   email,
+		ToName:    name,
+		Subject:   "Your Account Will Be Deleted - Bitzap",
+		HTMLBody:  s.generateAccountDeletionHTML(data),
+		TextBody:  s.generateAccountDeletionText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
+// SendTenantInvitation sends invitation to join tenant with link to accept it
+func (s *EmailLogic) SendTenantInvitation(ctx context.Context, req model.TenantInvitationEmail) error {
+	s.logger.Info("Sending tenant invitation email",
+		util.String("email", req.Email),
+	)
+
+	// Get config from repository
+	config := s.emailRepo.GetEmailConfig()
+
+	data := map[string]string{
+		"TenantName":    req.TenantName,
+		"InviterName":   req.InviterName,
+		"Role":          req.Role,
+		"InvitationURL": fmt.Sprintf("%s/invitations/preview?token=%s", config.AppURL, req.Token),
+		"ExpiresAt":     req.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		"AppName":       "Bitzap",
+		"SupportEmail":  "support@bitzap.com",
+	}
+
+	emailData := model.EmailData{
+		ToEmail:   req.Email,
+		Subject:   fmt.Sprintf("You're Invited to Join %s - Bitzap", req.TenantName),
+		HTMLBody:  s.generateTenantInvitationHTML(data),
+		TextBody:  s.generateTenantInvitationText(data),
+		Variables: data,
+	}
+
+	return s.emailRepo.SendEmail(ctx, emailData)
+}
+
 // SendEmail sends generic email using Mailjet
 func (s *EmailLogic) SendEmail(ctx context.Context, data model.EmailData) error {
 	s.logger.Info("Sending email",
@@ -182,6 +478,9 @@ func (s *EmailLogic) VerifyEmailToken(ctx context.Context, token string) (uint,
 		s.logger.Error("Failed to delete verification token from Redis", util.Error(err))
 		// Don't fail verification if deletion fails
 	}
+	if err := s.redisRepo.Del(ctx, fmt.Sprintf("email_verification_user:%d", userID)); err != nil {
+		s.logger.Error("Failed to delete verification token of user from Redis", util.Error(err))
+	}
 
 	return userID, nil
 }
@@ -396,6 +695,449 @@ Need help? Contact us at {{.SupportEmail}}`
 	return s.renderTemplate(tmpl, data)
 }
 
+// generateLoginOTPHTML generates HTML for login OTP
+func (s *EmailLogic) generateLoginOTPHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Your Login Code</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .code { font-size: 32px; font-weight: bold; letter-spacing: 8px; text-align: center; padding: 16px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Your Login Code</h2>
+            <p>Hello {{.Name}},</p>
+            <p>Use the code below to sign in to {{.AppName}}:</p>
+            <p class="code">{{.Code}}</p>
+            <p>This code will expire in {{.ExpireMinute}} minutes.</p>
+            <p>If you didn't try to sign in, you can safely ignore this email. Never share this code with anyone.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateLoginOTPText generates text for login OTP
+func (s *EmailLogic) generateLoginOTPText(data map[string]string) string {
+	tmpl := `Your Login Code
+
+Hello {{.Name}},
+
+Use the code below to sign in to {{.AppName}}:
+
+{{.Code}}
+
+This code will expire in {{.ExpireMinute}} minutes.
+
+If you didn't try to sign in, you can safely ignore this email. Never share this code with anyone.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateNewDeviceAlertHTML generates HTML for new device alert
+func (s *EmailLogic) generateNewDeviceAlertHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>New Sign-in to Your Account</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #dc3545; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>New Sign-in to Your Account</h2>
+            <p>Hello {{.Name}},</p>
+            <p>Your account was just signed in to from a device we haven't seen before:</p>
+            <p>Time: {{.LoginAt}}<br>IP address: {{.IPAddress}}<br>Device: {{.UserAgent}}</p>
+            <p>If this was you, you can ignore this email.</p>
+            <p>If this wasn't you, review the login and confirm to lock your account and sign out every session:</p>
+            <p style="text-align: center;">
+                <a href="{{.ReportURL}}" class="button">This wasn't me</a>
+            </p>
+            <p>Then reset your password and contact support to unlock your account.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	// User agent is sent by client, escape it for HTML
+	escaped := make(map[string]string, len(data))
+	for key, value := range data {
+		escaped[key] = template.HTMLEscapeString(value)
+	}
+
+	return s.renderTemplate(tmpl, escaped)
+}
+
+// generateNewDeviceAlertText generates text for new device alert
+func (s *EmailLogic) generateNewDeviceAlertText(data map[string]string) string {
+	tmpl := `New Sign-in to Your Account
+
+Hello {{.Name}},
+
+Your account was just signed in to from a device we haven't seen before:
+
+Time: {{.LoginAt}}
+IP address: {{.IPAddress}}
+Device: {{.UserAgent}}
+
+If this was you, you can ignore this email.
+
+If this wasn't you, review the login and confirm to lock your account and sign out every session:
+{{.ReportURL}}
+
+Then reset your password and contact support to unlock your account.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateEmailChangeHTML generates HTML for email change confirmation
+func (s *EmailLogic) generateEmailChangeHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Confirm Your New Email</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Confirm Your New Email</h2>
+            <p>Hello {{.Name}},</p>
+            <p>Please confirm that you want to use this address for your {{.AppName}} account:</p>
+            <p style="text-align: center;">
+                <a href="{{.ConfirmationURL}}" class="button">Confirm Email</a>
+            </p>
+            <p>Your email won't change until you confirm. This link will expire in 24 hours.</p>
+            <p>If you didn't request this change, you can safely ignore this email.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateEmailChangeText generates text for email change confirmation
+func (s *EmailLogic) generateEmailChangeText(data map[string]string) string {
+	tmpl := `Confirm Your New Email
+
+Hello {{.Name}},
+
+Please confirm that you want to use this address for your {{.AppName}} account:
+{{.ConfirmationURL}}
+
+Your email won't change until you confirm. This link will expire in 24 hours.
+
+If you didn't request this change, you can safely ignore this email.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateEmailChangeNoticeHTML generates HTML for email change notice
+func (s *EmailLogic) generateEmailChangeNoticeHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Email Change Requested</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Email Change Requested</h2>
+            <p>Hello {{.Name}},</p>
+            <p>We received a request to change the email of your {{.AppName}} account to <strong>{{.NewEmail}}</strong>.</p>
+            <p>The change takes effect only after it's confirmed from the new address.</p>
+            <p>If you didn't request this, change your password right away and contact support.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	// New email is entered by user, escape it for HTML
+	escaped := make(map[string]string, len(data))
+	for key, value := range data {
+		escaped[key] = template.HTMLEscapeString(value)
+	}
+
+	return s.renderTemplate(tmpl, escaped)
+}
+
+// generateEmailChangeNoticeText generates text for email change notice
+func (s *EmailLogic) generateEmailChangeNoticeText(data map[string]string) string {
+	tmpl := `Email Change Requested
+
+Hello {{.Name}},
+
+We received a request to change the email of your {{.AppName}} account to {{.NewEmail}}.
+
+The change takes effect only after it's confirmed from the new address.
+
+If you didn't request this, change your password right away and contact support.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateDataExportHTML generates HTML for data export
+func (s *EmailLogic) generateDataExportHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Your Data Export is Ready</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Your Data Export is Ready</h2>
+            <p>Hello {{.Name}},</p>
+            <p>The copy of your personal data you requested is ready to download:</p>
+            <p style="text-align: center;">
+                <a href="{{.DownloadURL}}" class="button">Download My Data</a>
+            </p>
+            <p>This link will expire in {{.ExpireHour}} hours. You need to be signed in to your account to download your data.</p>
+            <p>If you didn't request this export, please contact support and change your password.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateDataExportText generates text for data export
+func (s *EmailLogic) generateDataExportText(data map[string]string) string {
+	tmpl := `Your Data Export is Ready
+
+Hello {{.Name}},
+
+The copy of your personal data you requested is ready to download:
+{{.DownloadURL}}
+
+This link will expire in {{.ExpireHour}} hours. You need to be signed in to your account to download your data.
+
+If you didn't request this export, please contact support and change your password.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateAccountDeletionHTML generates HTML for account deletion scheduled
+func (s *EmailLogic) generateAccountDeletionHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Your Account Will Be Deleted</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Your Account Will Be Deleted</h2>
+            <p>Hello {{.Name}},</p>
+            <p>We received your request to delete your {{.AppName}} account. All your sessions and API keys have been revoked.</p>
+            <p>Your account and personal data will be permanently deleted on <strong>{{.DueAt}}</strong>.</p>
+            <p>Changed your mind? Sign in before then and cancel the deletion from your account settings.</p>
+            <p>If you didn't request this, sign in and cancel the deletion right away, then change your password.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateAccountDeletionText generates text for account deletion scheduled
+func (s *EmailLogic) generateAccountDeletionText(data map[string]string) string {
+	tmpl := `Your Account Will Be Deleted
+
+Hello {{.Name}},
+
+We received your request to delete your {{.AppName}} account. All your sessions and API keys have been revoked.
+
+Your account and personal data will be permanently deleted on {{.DueAt}}.
+
+Changed your mind? Sign in before then and cancel the deletion from your account settings.
+
+If you didn't request this, sign in and cancel the deletion right away, then change your password.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
+// generateTenantInvitationHTML generates HTML for tenant invitation
+func (s *EmailLogic) generateTenantInvitationHTML(data map[string]string) string {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>You're Invited to Join {{.TenantName}}</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background: #007bff; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background: #f8f9fa; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 14px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.AppName}}</h1>
+        </div>
+        <div class="content">
+            <h2>You're Invited to Join {{.TenantName}}</h2>
+            <p>Hello,</p>
+            <p>{{.InviterName}} invited you to join <strong>{{.TenantName}}</strong> on {{.AppName}} as {{.Role}}.</p>
+            <p style="text-align: center;">
+                <a href="{{.InvitationURL}}" class="button">View Invitation</a>
+            </p>
+            <p>Don't have an account yet? You can create one from the invitation with this email address.</p>
+            <p>This invitation can be used once and will expire on {{.ExpiresAt}}.</p>
+            <p>If you don't know the sender, you can safely ignore or decline this invitation.</p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	// Tenant and inviter names are chosen by users, escape them for HTML
+	escaped := make(map[string]string, len(data))
+	for key, value := range data {
+		escaped[key] = template.HTMLEscapeString(value)
+	}
+
+	return s.renderTemplate(tmpl, escaped)
+}
+
+// generateTenantInvitationText generates text for tenant invitation
+func (s *EmailLogic) generateTenantInvitationText(data map[string]string) string {
+	tmpl := `You're Invited to Join {{.TenantName}}
+
+Hello,
+
+{{.InviterName}} invited you to join {{.TenantName}} on {{.AppName}} as {{.Role}}:
+{{.InvitationURL}}
+
+Don't have an account yet? You can create one from the invitation with this email address.
+
+This invitation can be used once and will expire on {{.ExpiresAt}}.
+
+If you don't know the sender, you can safely ignore or decline this invitation.
+
+Need help? Contact us at {{.SupportEmail}}`
+
+	return s.renderTemplate(tmpl, data)
+}
+
 // renderTemplate renders template with data
 func (s *EmailLogic) renderTemplate(tmpl string, data map[string]string) string {
 	t, err := template.New("email").Parse(tmpl)
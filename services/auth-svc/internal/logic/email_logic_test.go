@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// emailTestRepo records sent emails instead of sending them
+type emailTestRepo struct {
+	sent []model.EmailData
+}
+
+func (r *emailTestRepo) SendEmail(ctx context.Context, data model.EmailData) error {
+	r.sent = append(r.sent, data)
+	return nil
+}
+
+func (r *emailTestRepo) GetEmailConfig() config.EmailConfig {
+	return config.EmailConfig{AppURL: "https://app.example.com"}
+}
+
+// lastToken returns token query param of link in last sent email
+func (r *emailTestRepo) lastToken(t *testing.T, variable string) string {
+	t.Helper()
+	link, err := url.Parse(r.sent[len(r.sent)-1].Variables[variable])
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestEmailLogicEmailChangeToken(t *testing.T) {
+	ctx := context.Background()
+	emails := &emailTestRepo{}
+	l := NewEmailLogic(emails, newFakeRedisRepo(), util.NewZapLogger(zap.NewNop()))
+
+	request := func(newEmail string) string {
+		err := l.SendEmailChangeConfirmation(ctx, model.EmailChangeConfirmation{UserID: 7, FamilyID: "family-1", NewEmail: newEmail})
+		if err != nil {
+			t.Fatalf("SendEmailChangeConfirmation() error = %v", err)
+		}
+		return emails.lastToken(t, "ConfirmationURL")
+	}
+
+	older := request("old@example.com")
+	latest := request("new@example.com")
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *model.EmailChangeConfirmation
+		wantErr bool
+	}{
+		{name: "older token is invalidated by new request", token: older, wantErr: true},
+		{name: "latest token", token: latest, want: &model.EmailChangeConfirmation{UserID: 7, FamilyID: "family-1", NewEmail: "new@example.com"}},
+		{name: "token can't be used twice", token: latest, wantErr: true},
+		{name: "unknown token", token: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.VerifyEmailChangeToken(ctx, tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("VerifyEmailChangeToken() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyEmailChangeToken() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("VerifyEmailChangeToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// EmailVerificationLogic applies policy for unverified accounts and resends verification links
+type EmailVerificationLogic struct {
+	config           config.EmailVerificationConfig
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	redisRepo        repository.RedisRepository
+	emailService     EmailServiceInterface
+	logger           util.Logger
+}
+
+// NewEmailVerificationLogic creates new EmailVerificationLogic instance
+func NewEmailVerificationLogic(
+	config config.EmailVerificationConfig,
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	redisRepo repository.RedisRepository,
+	emailService EmailServiceInterface,
+	logger util.Logger,
+) *EmailVerificationLogic {
+	return &EmailVerificationLogic{
+		config:           config,
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		redisRepo:        redisRepo,
+		emailService:     emailService,
+		logger:           logger,
+	}
+}
+
+// CheckLogin returns CodeUserNotActivated when policy doesn't allow unverified user to login
+func (l *EmailVerificationLogic) CheckLogin(user *entity.User) error {
+	if user.IsVerified {
+		return nil
+	}
+
+	switch l.config.Policy {
+	case _const.EmailPolicyBlock:
+		return util.NewError(_const.CodeUserNotActivated.Message())
+	case _const.EmailPolicyGrace:
+		if user.CreatedAt == nil || time.Now().After(user.CreatedAt.AddDate(0, 0, l.config.GraceDay)) {
+			return util.NewError(_const.CodeUserNotActivated.Message())
+		}
+	}
+
+	return nil
+}
+
+// Resend sends new verification link and invalidates older ones. Resend is throttled
+// per email and per IP before user lookup, unknown or verified emails get the same
+// response without sending so accounts can't be enumerated.
+func (l *EmailVerificationLogic) Resend(ctx context.Context, req model.ResendVerificationRequest) (*model.ResendVerificationResponse, error) {
+	email := normalizeEmail(req.Email)
+
+	if err := l.throttle(ctx, email, req.IPAddress); err != nil {
+		l.logger.Warn("Verification resend throttled",
+			util.String("email", email),
+			util.String("ip", req.IPAddress),
+		)
+		return nil, err
+	}
+
+	response := &model.ResendVerificationResponse{
+		ResendIn: int64(l.config.ResendIntervalSecond),
+	}
+
+	user, err := l.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		l.logger.Error("Failed to get user by email", util.Error(err))
+		return nil, err
+	}
+	if user == nil || !user.IsActive || user.IsVerified {
+		l.logger.Info("Verification resend requested for unknown, inactive or verified account",
+			util.String("email", email),
+		)
+		return response, nil
+	}
+
+	if err := l.emailService.SendEmailVerification(ctx, model.EmailVerificationRequest{
+		UserID: user.ID,
+		Email:  user.Email,
+	}); err != nil {
+		l.logger.Error("Failed to send email verification", util.Error(err))
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, user.ID, "verification_resent", "user", req.IPAddress, req.UserAgent, nil)
+
+	return response, nil
+}
+
+// throttle enforces hourly send limit of IP, resend interval and hourly send limit of email
+func (l *EmailVerificationLogic) throttle(ctx context.Context, email, ipAddress string) error {
+	if ipAddress != "" && l.config.MaxSendsPerHourIP > 0 {
+		count, err := l.incrHourly(ctx, _const.RedisKeyVerifyIPCount.Key(ipAddress))
+		if err != nil {
+			return err
+		}
+		if count > int64(l.config.MaxSendsPerHourIP) {
+			return util.NewError(_const.CodeVerifyResendLimit.Message())
+		}
+	}
+
+	resendKey := _const.RedisKeyVerifyResend.Key(email)
+	waiting, err := l.redisRepo.Exists(ctx, resendKey)
+	if err != nil {
+		return err
+	}
+	if waiting {
+		return util.NewError(_const.CodeVerifyResendSoon.Message())
+	}
+
+	count, err := l.incrHourly(ctx, _const.RedisKeyVerifySendCount.Key(email))
+	if err != nil {
+		return err
+	}
+	if l.config.MaxSendsPerHour > 0 && count > int64(l.config.MaxSendsPerHour) {
+		return util.NewError(_const.CodeVerifyResendLimit.Message())
+	}
+
+	if l.config.ResendIntervalSecond > 0 {
+		if err := l.redisRepo.Set(ctx, resendKey, "1", time.Duration(l.config.ResendIntervalSecond)*time.Second); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// incrHourly increments counter that expires one hour after first increment
+func (l *EmailVerificationLogic) incrHourly(ctx context.Context, key string) (int64, error) {
+	count, err := l.redisRepo.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := l.redisRepo.Expire(ctx, key, time.Hour); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// tokenScope returns scope of tokens issued to user, unverified users are limited
+// under limited policy
+func tokenScope(config config.EmailVerificationConfig, user *entity.User) string {
+	if !user.IsVerified && config.Policy == _const.EmailPolicyLimited {
+		return _const.TokenScopeUnverified
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// verificationTestUserRepo looks up users by email case-insensitively, as LOWER(email) does
+type verificationTestUserRepo struct {
+	repository.UserRepository
+	users []*entity.User
+}
+
+func (r *verificationTestUserRepo) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, strings.TrimSpace(email)) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// verificationTestEmailService records verification emails instead of sending them
+type verificationTestEmailService struct {
+	EmailServiceInterface
+	sent []model.EmailVerificationRequest
+}
+
+func (s *verificationTestEmailService) SendEmailVerification(ctx context.Context, req model.EmailVerificationRequest) error {
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func TestEmailVerificationLogicResend(t *testing.T) {
+	users := []*entity.User{
+		{ID: 1, Email: "Alice@Example.COM", IsActive: true},
+		{ID: 2, Email: "Bob@Example.com", IsActive: true, IsVerified: true},
+	}
+
+	tests := []struct {
+		name   string
+		email  string
+		wantTo string
+	}{
+		{name: "mixed-case stored email with lowercase request", email: "alice@example.com", wantTo: "Alice@Example.COM"},
+		{name: "mixed-case stored email with differently cased request", email: "  ALICE@example.com ", wantTo: "Alice@Example.COM"},
+		{name: "verified account isn't sent", email: "bob@example.com"},
+		{name: "unknown email isn't sent", email: "carol@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emails := &verificationTestEmailService{}
+			l := NewEmailVerificationLogic(
+				config.EmailVerificationConfig{ResendIntervalSecond: 60, MaxSendsPerHour: 5},
+				&verificationTestUserRepo{users: users},
+				&tenantTestActivityRepo{},
+				newFakeRedisRepo(),
+				emails,
+				util.NewZapLogger(zap.NewNop()),
+			)
+
+			got, err := l.Resend(context.Background(), model.ResendVerificationRequest{Email: tt.email})
+			if err != nil {
+				t.Fatalf("Resend() error = %v", err)
+			}
+			if got.ResendIn != 60 {
+				t.Errorf("Resend() ResendIn = %d, want 60", got.ResendIn)
+			}
+
+			if tt.wantTo == "" {
+				if len(emails.sent) != 0 {
+					t.Fatalf("Resend() sent %+v, want nothing", emails.sent)
+				}
+				return
+			}
+			if len(emails.sent) != 1 || emails.sent[0].Email != tt.wantTo {
+				t.Fatalf("Resend() sent %+v, want one email to %s", emails.sent, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const invitationTokenSize = 64
+
+// InvitationLogic contains tenant invitation logic. Invitations are sent by email with
+// single-use token, pending invitations take seats of tenant plan until closed or expired.
+type InvitationLogic struct {
+	config           config.TenantConfig
+	tenants          *TenantLogic
+	tenantRepo       repository.TenantRepository
+	memberRepo       repository.TenantMemberRepository
+	invitationRepo   repository.TenantInvitationRepository
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	emailService     EmailServiceInterface
+	logger           util.Logger
+}
+
+// NewInvitationLogic creates new InvitationLogic instance
+func NewInvitationLogic(
+	config config.TenantConfig,
+	tenants *TenantLogic,
+	tenantRepo repository.TenantRepository,
+	memberRepo repository.TenantMemberRepository,
+	invitationRepo repository.TenantInvitationRepository,
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	emailService EmailServiceInterface,
+	logger util.Logger,
+) *InvitationLogic {
+	return &InvitationLogic{
+		config:           config,
+		tenants:          tenants,
+		tenantRepo:       tenantRepo,
+		memberRepo:       memberRepo,
+		invitationRepo:   invitationRepo,
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		emailService:     emailService,
+		logger:           logger,
+	}
+}
+
+// Create invites email to tenant with role. Only admins and owners invite, admins
+// can't invite above member. Invitation is revoked when email can't be sent.
+func (l *InvitationLogic) Create(ctx context.Context, tenantID string, req model.CreateInvitationRequest) (*model.TenantInvitation, error) {
+	if _, ok := tenantRoleRank[req.Role]; !ok {
+		return nil, util.NewError(_const.CodeTenantRoleInvalid.Message())
+	}
+	email := normalizeEmail(req.Email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, util.NewError(_const.CodeBadRequest.Message())
+	}
+
+	actor, err := l.tenants.membership(ctx, tenantID, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if !canGrantRole(actor, req.Role) {
+		return nil, util.NewError(_const.CodeTenantForbidden.Message())
+	}
+
+	tenant, err := l.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitee, err := l.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		l.logger.Error("Failed to get user by email", util.Error(err))
+		return nil, err
+	}
+	if invitee != nil {
+		member, err := l.memberRepo.Get(ctx, tenantID, invitee.ID)
+		if err != nil {
+			l.logger.Error("Failed to get tenant membership", util.Error(err))
+			return nil, err
+		}
+		if member != nil {
+			return nil, util.NewError(_const.CodeAlreadyTenantMember.Message())
+		}
+	}
+	pending, err := l.invitationRepo.GetPendingByEmail(ctx, tenantID, email, now)
+	if err != nil {
+		l.logger.Error("Failed to get pending invitation", util.Error(err))
+		return nil, err
+	}
+	if pending != nil {
+		return nil, util.NewError(_const.CodeInvitationPending.Message())
+	}
+
+	inviter, err := l.tenants.getUser(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := util.GenerateRandomString(invitationTokenSize)
+	invitation := &entity.TenantInvitation{
+		TenantID:  tenantID,
+		Email:     email,
+		Role:      req.Role,
+		TokenHash: hashInvitationToken(token),
+		InvitedBy: &req.ActorID,
+		ExpiresAt: now.Add(time.Duration(l.config.InvitationExpireHour) * time.Hour),
+	}
+
+	// Pending invitations hold seats so accepting never exceeds plan
+	if err := l.invitationRepo.CreateWithinSeats(ctx, invitation, l.config.PlanSeats[tenant.Plan], now); err != nil {
+		if errors.Is(err, repository.ErrSeatLimit) {
+			return nil, util.NewError(_const.CodeTenantSeatLimit.Message())
+		}
+		l.logger.Error("Failed to create tenant invitation", util.Error(err))
+		return nil, err
+	}
+
+	if err := l.emailService.SendTenantInvitation(ctx, model.TenantInvitationEmail{
+		Email:       email,
+		TenantName:  tenant.Name,
+		InviterName: displayName(inviter),
+		Role:        req.Role,
+		Token:       token,
+		ExpiresAt:   invitation.ExpiresAt,
+	}); err != nil {
+		l.logger.Error("Failed to send tenant invitation", util.Error(err))
+		// Invitation nobody received shouldn't hold seat
+		if _, err := l.invitationRepo.MarkRevoked(ctx, invitation.ID); err != nil {
+			l.logger.Error("Failed to revoke unsent tenant invitation", util.Error(err))
+		}
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, req.ActorID, "tenant_invite", "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id":     tenantID,
+		"invitation_id": invitation.ID,
+		"email":         email,
+		"role":          req.Role,
+	})
+
+	return invitationView(invitation, now), nil
+}
+
+// List returns invitations of tenant, only admins and owners can list them
+func (l *InvitationLogic) List(ctx context.Context, userID uint, tenantID string) ([]*model.TenantInvitation, error) {
+	if _, err := l.manager(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := l.invitationRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		l.logger.Error("Failed to list tenant invitations", util.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]*model.TenantInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		result = append(result, invitationView(invitation, now))
+	}
+	return result, nil
+}
+
+// Revoke revokes pending invitation so its token can't be used and its seat is freed
+func (l *InvitationLogic) Revoke(ctx context.Context, tenantID string, invitationID uint, req model.TenantActionRequest) error {
+	actor, err := l.manager(ctx, tenantID, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := l.invitationRepo.GetByID(ctx, tenantID, invitationID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant invitation", util.Error(err))
+		return err
+	}
+	if invitation == nil {
+		return util.NewError(_const.CodeInvitationNotFound.Message())
+	}
+	if !canGrantRole(actor, invitation.Role) {
+		return util.NewError(_const.CodeTenantForbidden.Message())
+	}
+
+	revoked, err := l.invitationRepo.MarkRevoked(ctx, invitation.ID)
+	if err != nil {
+		l.logger.Error("Failed to revoke tenant invitation", util.Error(err))
+		return err
+	}
+	if !revoked {
+		return util.NewError(_const.CodeInvitationInvalid.Message())
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, req.ActorID, "tenant_invite_revoke", "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id":     tenantID,
+		"invitation_id": invitation.ID,
+		"email":         invitation.Email,
+	})
+
+	return nil
+}
+
+// Preview returns pending invitation of token, used to show invitation and pre-fill
+// registration of invitees without account
+func (l *InvitationLogic) Preview(ctx context.Context, token string) (*model.InvitationPreview, error) {
+	invitation, err := l.Pending(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := l.userRepo.GetByEmail(ctx, invitation.Email)
+	if err != nil {
+		l.logger.Error("Failed to get user by email", util.Error(err))
+		return nil, err
+	}
+
+	preview := &model.InvitationPreview{
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		ExpiresAt:  invitation.ExpiresAt,
+		Registered: invitee != nil,
+	}
+	if invitation.Tenant != nil {
+		preview.TenantName = invitation.Tenant.Name
+		preview.TenantSlug = invitation.Tenant.Slug
+	}
+	if invitation.Inviter != nil {
+		preview.InviterName = displayName(invitation.Inviter)
+	}
+	return preview, nil
+}
+
+// Pending returns pending invitation of token, CodeInvitationInvalid when token is
+// unknown, used or expired
+func (l *InvitationLogic) Pending(ctx context.Context, token string) (*entity.TenantInvitation, error) {
+	if token == "" {
+		return nil, util.NewError(_const.CodeInvitationInvalid.Message())
+	}
+
+	invitation, err := l.invitationRepo.GetByTokenHash(ctx, hashInvitationToken(token))
+	if err != nil {
+		l.logger.Error("Failed to get tenant invitation", util.Error(err))
+		return nil, err
+	}
+	if invitation == nil || invitationStatus(invitation, time.Now()) != model.InvitationStatusPending {
+		return nil, util.NewError(_const.CodeInvitationInvalid.Message())
+	}
+	return invitation, nil
+}
+
+// Accept adds user to tenant of invitation with invited role. Invitation must be sent
+// to email of user. Tenant becomes active tenant of user when user has none.
+func (l *InvitationLogic) Accept(ctx context.Context, req model.InvitationTokenRequest) (*model.TenantMembership, error) {
+	invitation, err := l.Pending(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := l.tenants.getUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if normalizeEmail(user.Email) != invitation.Email {
+		return nil, util.NewError(_const.CodeInvitationEmail.Message())
+	}
+
+	member, err := l.memberRepo.Get(ctx, invitation.TenantID, user.ID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant membership", util.Error(err))
+		return nil, err
+	}
+	if member != nil {
+		return nil, util.NewError(_const.CodeAlreadyTenantMember.Message())
+	}
+
+	if invitation.Tenant == nil {
+		return nil, util.NewError(_const.CodeTenantNotFound.Message())
+	}
+
+	member = &entity.TenantMember{
+		TenantID: invitation.TenantID,
+		UserID:   user.ID,
+		Role:     invitation.Role,
+	}
+	err = l.invitationRepo.AcceptWithinSeats(ctx, invitation.ID, member, l.config.PlanSeats[invitation.Tenant.Plan])
+	switch {
+	case errors.Is(err, repository.ErrSeatLimit):
+		return nil, util.NewError(_const.CodeTenantSeatLimit.Message())
+	case errors.Is(err, repository.ErrInvitationClosed):
+		return nil, util.NewError(_const.CodeInvitationInvalid.Message())
+	case err != nil:
+		l.logger.Error("Failed to accept tenant invitation", util.Error(err))
+		return nil, err
+	}
+
+	// Token was delivered to email of user, so it's verified too
+	if !user.IsVerified {
+		if err := l.userRepo.VerifyEmail(ctx, user.ID); err != nil {
+			l.logger.Error("Failed to verify email", util.Error(err))
+		}
+	}
+
+	active := user.ActiveTenantID != nil && *user.ActiveTenantID == invitation.TenantID
+	if user.ActiveTenantID == nil {
+		// Included in tokens from next refresh or tenant switch
+		if err := l.userRepo.SetActiveTenant(ctx, user.ID, &invitation.TenantID); err != nil {
+			l.logger.Error("Failed to set active tenant", util.Error(err))
+		} else {
+			active = true
+		}
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, user.ID, "tenant_invite_accept", "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id":     invitation.TenantID,
+		"invitation_id": invitation.ID,
+		"role":          invitation.Role,
+	})
+
+	l.logger.Info("Tenant invitation accepted",
+		util.String("tenant_id", invitation.TenantID),
+		util.Int("user_id", int(user.ID)),
+	)
+
+	return &model.TenantMembership{
+		Tenant: invitation.Tenant,
+		Role:   invitation.Role,
+		Active: active,
+	}, nil
+}
+
+// Decline declines invitation of token, anyone holding the token can decline it
+func (l *InvitationLogic) Decline(ctx context.Context, req model.InvitationTokenRequest) error {
+	invitation, err := l.Pending(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	declined, err := l.invitationRepo.MarkDeclined(ctx, invitation.ID)
+	if err != nil {
+		l.logger.Error("Failed to decline tenant invitation", util.Error(err))
+		return err
+	}
+	if !declined {
+		return util.NewError(_const.CodeInvitationInvalid.Message())
+	}
+
+	l.logger.Info("Tenant invitation declined",
+		util.String("tenant_id", invitation.TenantID),
+		util.Int("invitation_id", int(invitation.ID)),
+		util.String("ip", req.IPAddress),
+	)
+
+	return nil
+}
+
+// manager returns membership of user in tenant, CodeTenantForbidden unless user is admin or owner
+func (l *InvitationLogic) manager(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error) {
+	member, err := l.tenants.membership(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tenantRoleRank[member.Role] < tenantRoleRank[_const.TenantRoleAdmin] {
+		return nil, util.NewError(_const.CodeTenantForbidden.Message())
+	}
+	return member, nil
+}
+
+// getTenant gets tenant by ID, returns CodeTenantNotFound when missing
+func (l *InvitationLogic) getTenant(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	tenant, err := l.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant", util.Error(err))
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, util.NewError(_const.CodeTenantNotFound.Message())
+	}
+	return tenant, nil
+}
+
+// invitationView converts invitation to view listed to tenant admins
+func invitationView(invitation *entity.TenantInvitation, now time.Time) *model.TenantInvitation {
+	return &model.TenantInvitation{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		Status:    invitationStatus(invitation, now),
+		InvitedBy: invitation.InvitedBy,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+// invitationStatus returns status of invitation at time now
+func invitationStatus(invitation *entity.TenantInvitation, now time.Time) string {
+	switch {
+	case invitation.AcceptedAt != nil:
+		return model.InvitationStatusAccepted
+	case invitation.DeclinedAt != nil:
+		return model.InvitationStatusDeclined
+	case invitation.RevokedAt != nil:
+		return model.InvitationStatusRevoked
+	case !now.Before(invitation.ExpiresAt):
+		return model.InvitationStatusExpired
+	}
+	return model.InvitationStatusPending
+}
+
+// displayName returns full name of user, username when user has no name
+func displayName(user *entity.User) string {
+	if name := strings.TrimSpace(user.Firstname + " " + user.Lastname); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// hashInvitationToken returns SHA-256 hex digest of invitation token
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+const invitationTestTenantID = "5f0c8a52-4a4e-4d3c-9a7e-1b2f3c4d5e6f"
+
+type invitationTestTenantRepo struct {
+	repository.TenantRepository
+}
+
+func (r *invitationTestTenantRepo) GetByID(ctx context.Context, id string) (*entity.Tenant, error) {
+	return &entity.Tenant{ID: id, Name: "Acme", Slug: "acme"}, nil
+}
+
+// invitationTestRepo serves invitations by token hash, no invitation is pending by email
+type invitationTestRepo struct {
+	repository.TenantInvitationRepository
+	invitations []*entity.TenantInvitation
+}
+
+func (r *invitationTestRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.TenantInvitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			return invitation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *invitationTestRepo) GetPendingByEmail(ctx context.Context, tenantID, email string, now time.Time) (*entity.TenantInvitation, error) {
+	return nil, nil
+}
+
+func newInvitationTestLogic(t *testing.T, invitations []*entity.TenantInvitation) *InvitationLogic {
+	t.Helper()
+	env := newTenantTestEnv(t, []*entity.TenantMember{
+		{TenantID: invitationTestTenantID, UserID: 1, Role: _const.TenantRoleOwner},
+		{TenantID: invitationTestTenantID, UserID: 2, Role: _const.TenantRoleMember},
+	})
+	users := &verificationTestUserRepo{users: []*entity.User{
+		{ID: 1, Email: "owner@example.com", IsActive: true},
+		{ID: 2, Email: "Bob@Example.COM", IsActive: true},
+	}}
+	return NewInvitationLogic(
+		config.TenantConfig{},
+		env.logic,
+		&invitationTestTenantRepo{},
+		env.members,
+		&invitationTestRepo{invitations: invitations},
+		users,
+		env.activities,
+		&verificationTestEmailService{},
+		util.NewZapLogger(zap.NewNop()),
+	)
+}
+
+func TestInvitationLogicCreateDetectsMixedCaseMember(t *testing.T) {
+	l := newInvitationTestLogic(t, nil)
+
+	for _, email := range []string{"bob@example.com", "BOB@EXAMPLE.COM"} {
+		t.Run(email, func(t *testing.T) {
+			_, err := l.Create(context.Background(), invitationTestTenantID, model.CreateInvitationRequest{
+				Email:   email,
+				Role:    _const.TenantRoleMember,
+				ActorID: 1,
+			})
+			if err == nil || err.Error() != _const.CodeAlreadyTenantMember.Message() {
+				t.Fatalf("Create() error = %v, want %s", err, _const.CodeAlreadyTenantMember.Message())
+			}
+		})
+	}
+}
+
+func TestInvitationLogicPreviewMixedCaseAccount(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	l := newInvitationTestLogic(t, []*entity.TenantInvitation{
+		{ID: 1, TenantID: invitationTestTenantID, Email: "bob@example.com", Role: _const.TenantRoleMember, TokenHash: hashInvitationToken("registered"), ExpiresAt: expiresAt},
+		{ID: 2, TenantID: invitationTestTenantID, Email: "carol@example.com", Role: _const.TenantRoleMember, TokenHash: hashInvitationToken("unregistered"), ExpiresAt: expiresAt},
+	})
+
+	tests := []struct {
+		token          string
+		wantRegistered bool
+	}{
+		{token: "registered", wantRegistered: true},
+		{token: "unregistered", wantRegistered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			got, err := l.Preview(context.Background(), tt.token)
+			if err != nil {
+				t.Fatalf("Preview() error = %v", err)
+			}
+			if got.Registered != tt.wantRegistered {
+				t.Errorf("Preview() Registered = %v, want %v", got.Registered, tt.wantRegistered)
+			}
+		})
+	}
+}
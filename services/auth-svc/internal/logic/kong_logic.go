@@ -0,0 +1,328 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// kongReconcilePageSize is number of users or tenants loaded per page during reconciliation
+const kongReconcilePageSize = 500
+
+// KongLogic provisions Kong consumers and JWT credentials for users and tenants.
+// Every consumer gets one credential per published signing key so Kong
+// keeps accepting tokens signed by the previous key during rotation.
+type KongLogic struct {
+	config      config.KongConfig
+	client      repository.KongAdminClient
+	signingKeys *SigningKeyLogic
+	userRepo    repository.UserRepository
+	tenantRepo  repository.TenantRepository
+	logger      util.Logger
+}
+
+// NewKongLogic creates new KongLogic instance
+func NewKongLogic(
+	config config.KongConfig,
+	client repository.KongAdminClient,
+	signingKeys *SigningKeyLogic,
+	userRepo repository.UserRepository,
+	tenantRepo repository.TenantRepository,
+	logger util.Logger,
+) *KongLogic {
+	return &KongLogic{
+		config:      config,
+		client:      client,
+		signingKeys: signingKeys,
+		userRepo:    userRepo,
+		tenantRepo:  tenantRepo,
+		logger:      logger,
+	}
+}
+
+// Enabled reports whether Kong provisioning is enabled
+func (l *KongLogic) Enabled() bool {
+	return l.config.Enabled
+}
+
+// ConsumerUsername returns Kong consumer username of user
+func (l *KongLogic) ConsumerUsername(userID uint) string {
+	return l.config.ConsumerPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+// TenantConsumerUsername returns Kong consumer username of tenant
+func (l *KongLogic) TenantConsumerUsername(tenantID string) string {
+	return l.config.TenantConsumerPrefix + tenantID
+}
+
+// CredentialKey returns value of kong_key claim matching credential of signing key.
+// Tokens with active tenant match consumer of tenant so Kong can tell tenants apart.
+// Key of static consumer is returned when provisioning is disabled.
+func (l *KongLogic) CredentialKey(userID uint, tenantID, kid string) string {
+	if !l.config.Enabled {
+		return l.config.StaticKey
+	}
+
+	consumer := l.ConsumerUsername(userID)
+	if tenantID != "" {
+		consumer = l.TenantConsumerUsername(tenantID)
+	}
+	return credentialKey(consumer, kid)
+}
+
+// ProvisionUser creates or updates consumer of user and syncs its JWT credentials
+func (l *KongLogic) ProvisionUser(ctx context.Context, user *entity.User) error {
+	if !l.config.Enabled {
+		return nil
+	}
+
+	return l.provisionConsumer(ctx, model.KongConsumer{
+		Username: l.ConsumerUsername(user.ID),
+		CustomID: strconv.FormatUint(uint64(user.ID), 10),
+		Tags:     []string{l.config.Tag},
+	})
+}
+
+// DeprovisionUser deletes consumer of user with its credentials
+func (l *KongLogic) DeprovisionUser(ctx context.Context, userID uint) error {
+	if !l.config.Enabled {
+		return nil
+	}
+	return l.deleteConsumer(ctx, l.ConsumerUsername(userID))
+}
+
+// ProvisionTenant creates or updates consumer of tenant and syncs its JWT credentials
+func (l *KongLogic) ProvisionTenant(ctx context.Context, tenant *entity.Tenant) error {
+	if !l.config.Enabled {
+		return nil
+	}
+
+	return l.provisionConsumer(ctx, model.KongConsumer{
+		Username: l.TenantConsumerUsername(tenant.ID),
+		CustomID: tenant.ID,
+		Tags:     []string{l.config.Tag},
+	})
+}
+
+// DeprovisionTenant deletes consumer of tenant with its credentials
+func (l *KongLogic) DeprovisionTenant(ctx context.Context, tenantID string) error {
+	if !l.config.Enabled {
+		return nil
+	}
+	return l.deleteConsumer(ctx, l.TenantConsumerUsername(tenantID))
+}
+
+// SyncUser provisions consumer of user, failures are logged and repaired by Reconcile
+func (l *KongLogic) SyncUser(ctx context.Context, user *entity.User) {
+	if err := l.ProvisionUser(ctx, user); err != nil {
+		l.logger.Error("Failed to provision Kong consumer", util.Int("user_id", int(user.ID)), util.Error(err))
+	}
+}
+
+// RemoveUser deprovisions consumer of user, failures are logged and repaired by Reconcile
+func (l *KongLogic) RemoveUser(ctx context.Context, userID uint) {
+	if err := l.DeprovisionUser(ctx, userID); err != nil {
+		l.logger.Error("Failed to deprovision Kong consumer", util.Int("user_id", int(userID)), util.Error(err))
+	}
+}
+
+// SyncTenant provisions consumer of tenant, failures are logged and repaired by Reconcile
+func (l *KongLogic) SyncTenant(ctx context.Context, tenant *entity.Tenant) {
+	if err := l.ProvisionTenant(ctx, tenant); err != nil {
+		l.logger.Error("Failed to provision Kong consumer of tenant", util.String("tenant_id", tenant.ID), util.Error(err))
+	}
+}
+
+// RemoveTenant deprovisions consumer of tenant, failures are logged and repaired by Reconcile
+func (l *KongLogic) RemoveTenant(ctx context.Context, tenantID string) {
+	if err := l.DeprovisionTenant(ctx, tenantID); err != nil {
+		l.logger.Error("Failed to deprovision Kong consumer of tenant", util.String("tenant_id", tenantID), util.Error(err))
+	}
+}
+
+// Reconcile provisions consumers of active users and tenants and removes consumers
+// of inactive, deleted or unknown users and tenants tagged by auth-svc
+func (l *KongLogic) Reconcile(ctx context.Context) (*model.KongReconcileResult, error) {
+	result := &model.KongReconcileResult{}
+	if !l.config.Enabled {
+		return result, nil
+	}
+
+	expected := make(map[string]bool)
+	for offset := 0; ; offset += kongReconcilePageSize {
+		users, err := l.userRepo.List(ctx, offset, kongReconcilePageSize)
+		if err != nil {
+			l.logger.Error("Failed to list users", util.Error(err))
+			return nil, err
+		}
+
+		for _, user := range users {
+			if !user.IsActive {
+				continue
+			}
+			expected[l.ConsumerUsername(user.ID)] = true
+
+			if err := l.ProvisionUser(ctx, user); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Provisioned++
+		}
+
+		if len(users) < kongReconcilePageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += kongReconcilePageSize {
+		tenants, err := l.tenantRepo.List(ctx, offset, kongReconcilePageSize)
+		if err != nil {
+			l.logger.Error("Failed to list tenants", util.Error(err))
+			return nil, err
+		}
+
+		for _, tenant := range tenants {
+			expected[l.TenantConsumerUsername(tenant.ID)] = true
+
+			if err := l.ProvisionTenant(ctx, tenant); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Provisioned++
+		}
+
+		if len(tenants) < kongReconcilePageSize {
+			break
+		}
+	}
+
+	consumers, err := l.client.ListConsumers(ctx, l.config.Tag)
+	if err != nil {
+		l.logger.Error("Failed to list Kong consumers", util.Error(err))
+		return nil, err
+	}
+	for _, consumer := range consumers {
+		if expected[consumer.Username] {
+			continue
+		}
+		result.Orphans++
+
+		if err := l.client.DeleteConsumer(ctx, consumer.Username); err != nil {
+			l.logger.Error("Failed to delete orphan Kong consumer", util.String("consumer", consumer.Username), util.Error(err))
+			result.Failed++
+			continue
+		}
+		result.Deprovisioned++
+	}
+
+	l.logger.Info("Kong reconciliation completed",
+		util.Int("provisioned", result.Provisioned),
+		util.Int("deprovisioned", result.Deprovisioned),
+		util.Int("orphans", result.Orphans),
+		util.Int("failed", result.Failed),
+	)
+
+	return result, nil
+}
+
+// deleteConsumer deletes consumer with its credentials
+func (l *KongLogic) deleteConsumer(ctx context.Context, username string) error {
+	if err := l.client.DeleteConsumer(ctx, username); err != nil {
+		l.logger.Error("Failed to delete Kong consumer", util.String("consumer", username), util.Error(err))
+		return err
+	}
+
+	l.logger.Info("Kong consumer deleted", util.String("consumer", username))
+	return nil
+}
+
+// provisionConsumer upserts consumer and replaces its credentials with current signing keys
+func (l *KongLogic) provisionConsumer(ctx context.Context, consumer model.KongConsumer) error {
+	if err := l.client.UpsertConsumer(ctx, consumer); err != nil {
+		l.logger.Error("Failed to upsert Kong consumer", util.String("consumer", consumer.Username), util.Error(err))
+		return err
+	}
+
+	credentials, err := l.credentials(consumer.Username)
+	if err != nil {
+		l.logger.Error("Failed to build Kong credentials", util.Error(err))
+		return err
+	}
+
+	wanted := make(map[string]bool)
+	for _, credential := range credentials {
+		wanted[credential.Key] = true
+		if err := l.client.UpsertJWTCredential(ctx, consumer.Username, credential); err != nil {
+			l.logger.Error("Failed to upsert Kong JWT credential",
+				util.String("consumer", consumer.Username),
+				util.String("key", credential.Key),
+				util.Error(err),
+			)
+			return err
+		}
+	}
+
+	// Drop credentials of retired signing keys
+	existing, err := l.client.ListJWTCredentials(ctx, consumer.Username)
+	if err != nil {
+		l.logger.Error("Failed to list Kong JWT credentials", util.String("consumer", consumer.Username), util.Error(err))
+		return err
+	}
+	for _, credential := range existing {
+		if wanted[credential.Key] {
+			continue
+		}
+		if err := l.client.DeleteJWTCredential(ctx, consumer.Username, credential.Key); err != nil {
+			l.logger.Error("Failed to delete Kong JWT credential",
+				util.String("consumer", consumer.Username),
+				util.String("key", credential.Key),
+				util.Error(err),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// credentials builds JWT credentials of consumer from published signing keys
+func (l *KongLogic) credentials(consumer string) ([]model.KongJWTCredential, error) {
+	if secret, ok := l.signingKeys.SharedSecret(); ok {
+		return []model.KongJWTCredential{{
+			Key:       credentialKey(consumer, ""),
+			Algorithm: "HS256",
+			Secret:    secret,
+			Tags:      []string{l.config.Tag},
+		}}, nil
+	}
+
+	publicKeys, err := l.signingKeys.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]model.KongJWTCredential, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		credentials = append(credentials, model.KongJWTCredential{
+			Key:          credentialKey(consumer, publicKey.KeyID),
+			Algorithm:    publicKey.Algorithm,
+			RSAPublicKey: publicKey.PublicKeyPEM,
+			Tags:         []string{l.config.Tag},
+		})
+	}
+
+	return credentials, nil
+}
+
+// credentialKey returns key of JWT credential of consumer for signing key
+func credentialKey(consumer, kid string) string {
+	if kid == "" {
+		return consumer
+	}
+	return consumer + ":" + kid
+}
@@ -0,0 +1,188 @@
+package logic
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	repository_impl "github.com/taititans/bitzap/auth-svc/internal/domain/repository/repository_impl"
+	"github.com/taititans/bitzap/auth-svc/internal/kongtest"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// kongTestUserRepo serves users to KongLogic, other methods aren't used
+type kongTestUserRepo struct {
+	repository.UserRepository
+	users []*entity.User
+}
+
+func (r *kongTestUserRepo) List(ctx context.Context, offset, limit int) ([]*entity.User, error) {
+	return pageOf(r.users, offset, limit), nil
+}
+
+// kongTestTenantRepo serves tenants to KongLogic, other methods aren't used
+type kongTestTenantRepo struct {
+	repository.TenantRepository
+	tenants []*entity.Tenant
+}
+
+func (r *kongTestTenantRepo) List(ctx context.Context, offset, limit int) ([]*entity.Tenant, error) {
+	return pageOf(r.tenants, offset, limit), nil
+}
+
+func pageOf[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+func newTestKongLogic(t *testing.T, enabled bool, server *kongtest.Server, users []*entity.User, tenants []*entity.Tenant) *KongLogic {
+	t.Helper()
+	logger := util.NewZapLogger(zap.NewNop())
+
+	signingKeys, err := NewSigningKeyLogic(config.AuthConfig{SecretKey: "test-secret"}, logger)
+	if err != nil {
+		t.Fatalf("NewSigningKeyLogic() error = %v", err)
+	}
+
+	kongConfig := config.KongConfig{
+		Enabled:              enabled,
+		ConsumerPrefix:       "user-",
+		TenantConsumerPrefix: "tenant-",
+		StaticKey:            "bitzap-key",
+		Tag:                  "auth-svc",
+		TimeoutSecond:        5,
+	}
+	if server != nil {
+		kongConfig.AdminURL = server.URL
+	}
+
+	return NewKongLogic(
+		kongConfig,
+		repository_impl.NewKongAdminClient(kongConfig, logger),
+		signingKeys,
+		&kongTestUserRepo{users: users},
+		&kongTestTenantRepo{tenants: tenants},
+		logger,
+	)
+}
+
+func TestKongLogicCredentialKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		tenantID string
+		kid      string
+		want     string
+	}{
+		{name: "disabled uses static consumer", enabled: false, want: "bitzap-key"},
+		{name: "disabled ignores tenant and kid", enabled: false, tenantID: "t1", kid: "k1", want: "bitzap-key"},
+		{name: "user with shared secret", enabled: true, want: "user-7"},
+		{name: "user with signing key", enabled: true, kid: "k1", want: "user-7:k1"},
+		{name: "active tenant", enabled: true, tenantID: "t1", want: "tenant-t1"},
+		{name: "active tenant with signing key", enabled: true, tenantID: "t1", kid: "k1", want: "tenant-t1:k1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestKongLogic(t, tt.enabled, nil, nil, nil)
+			if got := l.CredentialKey(7, tt.tenantID, tt.kid); got != tt.want {
+				t.Errorf("CredentialKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKongLogicReconcile(t *testing.T) {
+	server := kongtest.NewServer()
+	defer server.Close()
+
+	// Consumer of inactive user, orphan consumer and stale credential of active user
+	server.AddConsumer(model.KongConsumer{Username: "user-2", Tags: []string{"auth-svc"}})
+	server.AddConsumer(model.KongConsumer{Username: "user-99", Tags: []string{"auth-svc"}})
+	server.AddConsumer(model.KongConsumer{Username: "user-1", Tags: []string{"auth-svc"}})
+	server.AddCredential("user-1", model.KongJWTCredential{Key: "user-1:retired", Algorithm: "RS256"})
+	// Consumers not tagged by auth-svc are left alone
+	server.AddConsumer(model.KongConsumer{Username: "bitzap-app"})
+
+	l := newTestKongLogic(t, true, server,
+		[]*entity.User{
+			{ID: 1, IsActive: true},
+			{ID: 2, IsActive: false},
+			{ID: 3, IsActive: true},
+		},
+		[]*entity.Tenant{{ID: "t1"}},
+	)
+
+	result, err := l.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	want := &model.KongReconcileResult{Provisioned: 3, Deprovisioned: 2, Orphans: 2}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Reconcile() = %+v, want %+v", result, want)
+	}
+	if got, want := server.Consumers(), []string{"bitzap-app", "tenant-t1", "user-1", "user-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("consumers = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		consumer string
+		wantKeys []string
+	}{
+		{consumer: "user-1", wantKeys: []string{"user-1"}},
+		{consumer: "user-3", wantKeys: []string{"user-3"}},
+		{consumer: "tenant-t1", wantKeys: []string{"tenant-t1"}},
+	}
+	for _, tt := range tests {
+		if got := server.CredentialKeys(tt.consumer); !reflect.DeepEqual(got, tt.wantKeys) {
+			t.Errorf("credentials of %s = %v, want %v", tt.consumer, got, tt.wantKeys)
+		}
+		credential, _ := server.Credential(tt.consumer, tt.consumer)
+		if credential.Algorithm != "HS256" || credential.Secret != "test-secret" {
+			t.Errorf("credential of %s = %s/%q, want HS256 with shared secret", tt.consumer, credential.Algorithm, credential.Secret)
+		}
+	}
+	if consumer, _ := server.Consumer("tenant-t1"); consumer.CustomID != "t1" {
+		t.Errorf("custom_id of tenant consumer = %q, want %q", consumer.CustomID, "t1")
+	}
+
+	// Second run finds nothing to repair
+	result, err = l.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("second Reconcile() error = %v", err)
+	}
+	if want := (&model.KongReconcileResult{Provisioned: 3}); !reflect.DeepEqual(result, want) {
+		t.Errorf("second Reconcile() = %+v, want %+v", result, want)
+	}
+}
+
+func TestKongLogicDisabled(t *testing.T) {
+	server := kongtest.NewServer()
+	defer server.Close()
+
+	l := newTestKongLogic(t, false, server, []*entity.User{{ID: 1, IsActive: true}}, nil)
+	ctx := context.Background()
+
+	l.SyncUser(ctx, &entity.User{ID: 1})
+	l.SyncTenant(ctx, &entity.Tenant{ID: "t1"})
+	l.RemoveUser(ctx, 1)
+	if _, err := l.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("Admin API called while disabled: %v", requests)
+	}
+}
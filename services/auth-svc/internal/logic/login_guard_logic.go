@@ -0,0 +1,191 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	loginGuardScopeEmail = "email"
+	loginGuardScopeIP    = "ip"
+)
+
+// LoginGuardLogic contains brute-force protection logic for login
+type LoginGuardLogic struct {
+	config           config.LoginProtectionConfig
+	redisRepo        repository.RedisRepository
+	userActivityRepo repository.UserActivityLogRepository
+	logger           util.Logger
+}
+
+// NewLoginGuardLogic creates new LoginGuardLogic instance
+func NewLoginGuardLogic(
+	config config.LoginProtectionConfig,
+	redisRepo repository.RedisRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	logger util.Logger,
+) *LoginGuardLogic {
+	return &LoginGuardLogic{
+		config:           config,
+		redisRepo:        redisRepo,
+		userActivityRepo: userActivityRepo,
+		logger:           logger,
+	}
+}
+
+// CheckBlocked returns error when email or IP is temporarily blocked
+func (l *LoginGuardLogic) CheckBlocked(ctx context.Context, req model.LoginRequest) error {
+	blocked, err := l.redisRepo.Exists(ctx, _const.RedisKeyBlockLogin.Key(loginGuardScopeEmail, normalizeEmail(req.Email)))
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return util.NewError(_const.CodeBlockingAccount.Message())
+	}
+
+	if req.IPAddress == "" {
+		return nil
+	}
+
+	blocked, err = l.redisRepo.Exists(ctx, _const.RedisKeyBlockLogin.Key(loginGuardScopeIP, req.IPAddress))
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return util.NewError(_const.CodeLoginRate.Message())
+	}
+
+	return nil
+}
+
+// RegisterFailure records failed login attempt and blocks email or IP when limit is reached.
+// User may be nil when email doesn't belong to any account.
+func (l *LoginGuardLogic) RegisterFailure(ctx context.Context, user *entity.User, req model.LoginRequest) error {
+	email := normalizeEmail(req.Email)
+
+	attempts, err := l.countAttempt(ctx, _const.RedisKeyLoginRateLimit.Key(loginGuardScopeEmail, email))
+	if err != nil {
+		return err
+	}
+	if l.config.MaxAttemptsPerEmail > 0 && attempts >= int64(l.config.MaxAttemptsPerEmail) {
+		if err := l.block(ctx, user, req, loginGuardScopeEmail, email, attempts); err != nil {
+			return err
+		}
+		return util.NewError(_const.CodeExceedLoginAttempts.Message())
+	}
+
+	if req.IPAddress == "" {
+		return nil
+	}
+
+	attempts, err = l.countAttempt(ctx, _const.RedisKeyLoginRateLimit.Key(loginGuardScopeIP, req.IPAddress))
+	if err != nil {
+		return err
+	}
+	if l.config.MaxAttemptsPerIP > 0 && attempts >= int64(l.config.MaxAttemptsPerIP) {
+		if err := l.block(ctx, user, req, loginGuardScopeIP, req.IPAddress, attempts); err != nil {
+			return err
+		}
+		return util.NewError(_const.CodeLoginRate.Message())
+	}
+
+	return nil
+}
+
+// RegisterSuccess clears failed attempts of email after successful login
+func (l *LoginGuardLogic) RegisterSuccess(ctx context.Context, req model.LoginRequest) error {
+	return l.redisRepo.Del(ctx, _const.RedisKeyLoginRateLimit.Key(loginGuardScopeEmail, normalizeEmail(req.Email)))
+}
+
+// countAttempt adds attempt to sliding window and returns number of attempts inside window
+func (l *LoginGuardLogic) countAttempt(ctx context.Context, key string) (int64, error) {
+	window := time.Duration(l.config.WindowMinute) * time.Minute
+	now := time.Now()
+
+	windowStart := strconv.FormatInt(now.Add(-window).UnixNano(), 10)
+	if err := l.redisRepo.ZRemRangeByScore(ctx, key, "-inf", windowStart); err != nil {
+		return 0, err
+	}
+
+	member := strconv.FormatInt(now.UnixNano(), 10)
+	if err := l.redisRepo.ZAdd(ctx, key, float64(now.UnixNano()), member); err != nil {
+		return 0, err
+	}
+	if err := l.redisRepo.Expire(ctx, key, window); err != nil {
+		return 0, err
+	}
+
+	return l.redisRepo.ZCard(ctx, key)
+}
+
+// block blocks scope value with duration escalating on each repeated block
+func (l *LoginGuardLogic) block(ctx context.Context, user *entity.User, req model.LoginRequest, scope, value string, attempts int64) error {
+	levelKey := _const.RedisKeyBlockLoginLevel.Key(scope, value)
+	level, err := l.redisRepo.Incr(ctx, levelKey)
+	if err != nil {
+		return err
+	}
+	if err := l.redisRepo.Expire(ctx, levelKey, time.Duration(l.config.BlockLevelResetMinute)*time.Minute); err != nil {
+		return err
+	}
+
+	blockMinute := l.blockMinute(level)
+	if blockMinute <= 0 {
+		return nil
+	}
+
+	blockKey := _const.RedisKeyBlockLogin.Key(scope, value)
+	if err := l.redisRepo.Set(ctx, blockKey, strconv.FormatInt(level, 10), time.Duration(blockMinute)*time.Minute); err != nil {
+		return err
+	}
+
+	// Reset window so attempts are counted again after block expires
+	if err := l.redisRepo.Del(ctx, _const.RedisKeyLoginRateLimit.Key(scope, value)); err != nil {
+		return err
+	}
+
+	l.logger.Warn("Login blocked after too many failed attempts",
+		util.String("scope", scope),
+		util.String("value", value),
+		util.Int64("attempts", attempts),
+		util.Int64("level", level),
+		util.Int("block_minute", blockMinute),
+	)
+
+	if user != nil {
+		// Log activity
+		l.userActivityRepo.LogActivity(ctx, user.ID, "login_blocked", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+			"scope":        scope,
+			"attempts":     attempts,
+			"level":        level,
+			"block_minute": blockMinute,
+		})
+	}
+
+	return nil
+}
+
+// blockMinute returns block duration for escalation level
+func (l *LoginGuardLogic) blockMinute(level int64) int {
+	if len(l.config.BlockMinutes) == 0 {
+		return 0
+	}
+	if int(level) > len(l.config.BlockMinutes) {
+		return l.config.BlockMinutes[len(l.config.BlockMinutes)-1]
+	}
+	return l.config.BlockMinutes[level-1]
+}
+
+// normalizeEmail lowercases and trims email for use in keys
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,173 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+func newTestLoginGuard() (*LoginGuardLogic, *fakeRedisRepo, *tenantTestActivityRepo) {
+	redis := newFakeRedisRepo()
+	activities := &tenantTestActivityRepo{}
+	guard := NewLoginGuardLogic(config.LoginProtectionConfig{
+		WindowMinute:          15,
+		MaxAttemptsPerEmail:   3,
+		MaxAttemptsPerIP:      5,
+		BlockMinutes:          []int{1, 5, 30},
+		BlockLevelResetMinute: 120,
+	}, redis, activities, util.NewZapLogger(zap.NewNop()))
+	return guard, redis, activities
+}
+
+// failLogin registers failed attempts and returns error of last one
+func failLogin(t *testing.T, guard *LoginGuardLogic, user *entity.User, req model.LoginRequest, attempts int) error {
+	t.Helper()
+	var err error
+	for i := 0; i < attempts; i++ {
+		err = guard.RegisterFailure(context.Background(), user, req)
+	}
+	return err
+}
+
+func TestLoginGuardBlockMinute(t *testing.T) {
+	tests := []struct {
+		blockMinutes []int
+		level        int64
+		want         int
+	}{
+		{blockMinutes: []int{1, 5, 30}, level: 1, want: 1},
+		{blockMinutes: []int{1, 5, 30}, level: 2, want: 5},
+		{blockMinutes: []int{1, 5, 30}, level: 3, want: 30},
+		{blockMinutes: []int{1, 5, 30}, level: 10, want: 30},
+		{blockMinutes: nil, level: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v level %d", tt.blockMinutes, tt.level), func(t *testing.T) {
+			l := &LoginGuardLogic{config: config.LoginProtectionConfig{BlockMinutes: tt.blockMinutes}}
+			if got := l.blockMinute(tt.level); got != tt.want {
+				t.Errorf("blockMinute() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginGuardEscalation(t *testing.T) {
+	ctx := context.Background()
+	guard, redis, activities := newTestLoginGuard()
+	user := &entity.User{ID: 1}
+	req := model.LoginRequest{Email: " User@Example.com "}
+	blockKey := _const.RedisKeyBlockLogin.Key(loginGuardScopeEmail, "user@example.com")
+
+	// Each round fails until blocked, then waits block out. Block grows with each round
+	// until last configured duration and starts over once level resets.
+	rounds := []struct {
+		name      string
+		idle      time.Duration
+		wantBlock time.Duration
+	}{
+		{name: "first block", wantBlock: time.Minute},
+		{name: "second block", wantBlock: 5 * time.Minute},
+		{name: "third block", wantBlock: 30 * time.Minute},
+		{name: "capped at last duration", wantBlock: 30 * time.Minute},
+		{name: "level reset", idle: 2 * time.Hour, wantBlock: time.Minute},
+	}
+
+	for _, round := range rounds {
+		redis.advance(round.idle)
+
+		if err := failLogin(t, guard, user, req, 2); err != nil {
+			t.Fatalf("%s: attempts below limit error = %v", round.name, err)
+		}
+		if err := guard.CheckBlocked(ctx, req); err != nil {
+			t.Fatalf("%s: CheckBlocked() before limit error = %v", round.name, err)
+		}
+
+		err := guard.RegisterFailure(ctx, user, req)
+		if err == nil || err.Error() != _const.CodeExceedLoginAttempts.Message() {
+			t.Fatalf("%s: RegisterFailure() at limit error = %v, want %q", round.name, err, _const.CodeExceedLoginAttempts.Message())
+		}
+		if err := guard.CheckBlocked(ctx, req); err == nil || err.Error() != _const.CodeBlockingAccount.Message() {
+			t.Fatalf("%s: CheckBlocked() error = %v, want %q", round.name, err, _const.CodeBlockingAccount.Message())
+		}
+		if ttl, _ := redis.TTL(ctx, blockKey); ttl != round.wantBlock {
+			t.Fatalf("%s: block = %v, want %v", round.name, ttl, round.wantBlock)
+		}
+
+		redis.advance(round.wantBlock)
+		if err := guard.CheckBlocked(ctx, req); err != nil {
+			t.Fatalf("%s: CheckBlocked() after block error = %v", round.name, err)
+		}
+	}
+
+	if got := len(activities.actions); got != len(rounds) {
+		t.Errorf("login_blocked activities = %d, want %d", got, len(rounds))
+	}
+}
+
+func TestLoginGuardIPLimit(t *testing.T) {
+	ctx := context.Background()
+	guard, _, activities := newTestLoginGuard()
+
+	// Spraying different emails from one IP stays below email limit
+	var err error
+	for i := 0; i < 5; i++ {
+		err = guard.RegisterFailure(ctx, nil, model.LoginRequest{Email: fmt.Sprintf("user%d@example.com", i), IPAddress: "10.0.0.1"})
+	}
+	if err == nil || err.Error() != _const.CodeLoginRate.Message() {
+		t.Fatalf("RegisterFailure() at IP limit error = %v, want %q", err, _const.CodeLoginRate.Message())
+	}
+
+	tests := []struct {
+		name    string
+		req     model.LoginRequest
+		wantErr string
+	}{
+		{name: "blocked IP", req: model.LoginRequest{Email: "new@example.com", IPAddress: "10.0.0.1"}, wantErr: _const.CodeLoginRate.Message()},
+		{name: "other IP", req: model.LoginRequest{Email: "new@example.com", IPAddress: "10.0.0.2"}},
+		{name: "unknown IP", req: model.LoginRequest{Email: "new@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckBlocked(ctx, tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckBlocked() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CheckBlocked() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Unknown emails have no user to log activity for
+	if len(activities.actions) != 0 {
+		t.Errorf("activities = %v, want none", activities.actions)
+	}
+}
+
+func TestLoginGuardSuccessClearsAttempts(t *testing.T) {
+	ctx := context.Background()
+	guard, _, _ := newTestLoginGuard()
+	req := model.LoginRequest{Email: "user@example.com"}
+
+	if err := failLogin(t, guard, nil, req, 2); err != nil {
+		t.Fatalf("RegisterFailure() error = %v", err)
+	}
+	if err := guard.RegisterSuccess(ctx, req); err != nil {
+		t.Fatalf("RegisterSuccess() error = %v", err)
+	}
+	if err := failLogin(t, guard, nil, req, 2); err != nil {
+		t.Fatalf("RegisterFailure() after success error = %v, want attempts counted from zero", err)
+	}
+}
@@ -0,0 +1,290 @@
+package logic
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	oauthStateLength        = 32
+	oauthNonceLength        = 32
+	oauthPasswordLength     = 64
+	oauthUsernameSuffixSize = 6
+	oauthUsernameMaxTries   = 5
+)
+
+// OAuthLogic contains OAuth2 / OIDC social login logic
+type OAuthLogic struct {
+	config           config.OAuthConfig
+	providers        map[string]repository.OAuthProvider
+	userRepo         repository.UserRepository
+	identityRepo     repository.UserIdentityRepository
+	userActivityRepo repository.UserActivityLogRepository
+	redisRepo        repository.RedisRepository
+	usernamePolicy   *UsernamePolicy
+	kong             *KongLogic
+	permissions      *PermissionLogic
+	logger           util.Logger
+}
+
+// NewOAuthLogic creates new OAuthLogic instance
+func NewOAuthLogic(
+	config config.OAuthConfig,
+	providers map[string]repository.OAuthProvider,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	redisRepo repository.RedisRepository,
+	usernamePolicy *UsernamePolicy,
+	kong *KongLogic,
+	permissions *PermissionLogic,
+	logger util.Logger,
+) *OAuthLogic {
+	return &OAuthLogic{
+		config:           config,
+		providers:        providers,
+		userRepo:         userRepo,
+		identityRepo:     identityRepo,
+		userActivityRepo: userActivityRepo,
+		redisRepo:        redisRepo,
+		usernamePolicy:   usernamePolicy,
+		kong:             kong,
+		permissions:      permissions,
+		logger:           logger,
+	}
+}
+
+// Authorize stores state with PKCE verifier and nonce and returns provider authorize URL.
+// Returned state must be kept in browser cookie so callback can't be replayed in another browser.
+func (l *OAuthLogic) Authorize(ctx context.Context, providerName string) (*model.OAuthAuthorization, error) {
+	provider, ok := l.providers[providerName]
+	if !ok {
+		return nil, util.NewError(_const.CodeOAuthProvider.Message())
+	}
+
+	state := util.GenerateRandomString(oauthStateLength)
+	codeVerifier := oauth2.GenerateVerifier()
+	nonce := util.GenerateRandomString(oauthNonceLength)
+
+	value, err := json.Marshal(model.OAuthState{
+		Provider:     providerName,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(l.config.StateExpireMinute) * time.Minute
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyOAuthState.Key(state), string(value), ttl); err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, codeVerifier, nonce)
+	if err != nil {
+		l.logger.Error("Failed to build OAuth authorize URL", util.String("provider", providerName), util.Error(err))
+		return nil, util.NewError(_const.CodeOAuthExchange.Message())
+	}
+
+	return &model.OAuthAuthorization{
+		URL:       authURL,
+		State:     state,
+		ExpiresIn: int64(ttl.Seconds()),
+	}, nil
+}
+
+// Callback validates state against cookie, exchanges code and returns linked or newly created user.
+// Existing users are linked by email only when both provider and account emails are verified.
+func (l *OAuthLogic) Callback(ctx context.Context, req model.OAuthCallbackRequest) (*entity.User, error) {
+	provider, ok := l.providers[req.Provider]
+	if !ok {
+		return nil, util.NewError(_const.CodeOAuthProvider.Message())
+	}
+
+	// State must come back to the browser that started login
+	if req.StateCookie == "" || subtle.ConstantTimeCompare([]byte(req.StateCookie), []byte(req.State)) != 1 {
+		l.logger.Warn("OAuth state doesn't match cookie",
+			util.String("provider", req.Provider),
+			util.String("ip", req.IPAddress),
+		)
+		return nil, util.NewError(_const.CodeOAuthState.Message())
+	}
+
+	// State is single use
+	value, err := l.redisRepo.GetDel(ctx, _const.RedisKeyOAuthState.Key(req.State))
+	if err != nil {
+		return nil, err
+	}
+	var state model.OAuthState
+	if value == "" || json.Unmarshal([]byte(value), &state) != nil || state.Provider != req.Provider {
+		return nil, util.NewError(_const.CodeOAuthState.Message())
+	}
+
+	profile, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, util.NewError(_const.CodeOAuthExchange.Message())
+	}
+
+	// Known identity
+	identity, err := l.identityRepo.GetByProviderSubject(ctx, req.Provider, profile.Subject)
+	if err != nil {
+		l.logger.Error("Failed to get user identity", util.Error(err))
+		return nil, err
+	}
+	if identity != nil {
+		user, err := l.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			l.logger.Error("Failed to get user", util.Error(err))
+			return nil, err
+		}
+		if user == nil {
+			return nil, util.NewError(_const.CodeUserNotFound.Message())
+		}
+		return user, nil
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		return nil, util.NewError(_const.CodeOAuthUnverified.Message())
+	}
+
+	user, err := l.userRepo.GetByEmail(ctx, normalizeEmail(profile.Email))
+	if err != nil {
+		l.logger.Error("Failed to get user by email", util.Error(err))
+		return nil, err
+	}
+
+	action := "oauth_linked"
+	if user == nil {
+		if user, err = l.createUser(ctx, profile); err != nil {
+			return nil, err
+		}
+		action = "oauth_register"
+	} else if !user.IsVerified {
+		// Someone else may have registered this email with a password
+		return nil, util.NewError(_const.CodeOAuthLinkBlocked.Message())
+	}
+
+	if err := l.identityRepo.Create(ctx, &entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: req.Provider,
+		Subject:  profile.Subject,
+		Email:    profile.Email,
+	}); err != nil {
+		l.logger.Error("Failed to create user identity", util.Error(err))
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, user.ID, action, "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"provider": req.Provider,
+	})
+
+	l.logger.Info("OAuth identity linked",
+		util.Int("user_id", int(user.ID)),
+		util.String("provider", req.Provider),
+		util.String("action", action),
+	)
+
+	return user, nil
+}
+
+// createUser creates verified user from provider profile with random password
+func (l *OAuthLogic) createUser(ctx context.Context, profile *model.OAuthProfile) (*entity.User, error) {
+	username, err := l.availableUsername(ctx, profile.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(util.GenerateRandomString(oauthPasswordLength)), bcrypt.DefaultCost)
+	if err != nil {
+		l.logger.Error("Failed to hash password", util.Error(err))
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		Email:           normalizeEmail(profile.Email),
+		Username:        username,
+		PasswordHash:    string(hashedPassword),
+		Firstname:       profile.FirstName,
+		Lastname:        profile.LastName,
+		AvatarURL:       profile.AvatarURL,
+		IsActive:        true,
+		IsVerified:      true,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := l.userRepo.Create(ctx, user); err != nil {
+		l.logger.Error("Failed to create user", util.Error(err))
+		return nil, err
+	}
+
+	// Assign default role, can be assigned again by admin when it fails
+	if err := l.permissions.AssignDefaultRole(ctx, user.ID); err != nil {
+		l.logger.Error("Failed to assign default role", util.Int("user_id", int(user.ID)), util.Error(err))
+	}
+
+	l.kong.SyncUser(ctx, user)
+
+	return user, nil
+}
+
+// availableUsername derives valid unused username from email local part
+func (l *OAuthLogic) availableUsername(ctx context.Context, email string) (string, error) {
+	base := email
+	if at := strings.Index(base, "@"); at > 0 {
+		base = base[:at]
+	}
+	base = sanitizeUsername(l.usernamePolicy.Normalize(base))
+	if maxLength := l.usernamePolicy.config.MaxLength - oauthUsernameSuffixSize - 1; maxLength > 0 && len(base) > maxLength {
+		base = base[:maxLength]
+	}
+
+	candidate := base
+	for i := 0; i < oauthUsernameMaxTries; i++ {
+		if l.usernamePolicy.Validate(candidate) == nil {
+			existing, err := l.userRepo.GetByUsername(ctx, candidate)
+			if err != nil {
+				l.logger.Error("Failed to check existing username", util.Error(err))
+				return "", err
+			}
+			if existing == nil {
+				return candidate, nil
+			}
+		}
+		candidate = base + "_" + util.GenerateRandomString(oauthUsernameSuffixSize)
+	}
+
+	return "", util.NewError(_const.CodeUsernameExisted.Message())
+}
+
+// sanitizeUsername replaces chars not allowed in username and ensures it starts with a letter
+func sanitizeUsername(username string) string {
+	var b strings.Builder
+	for _, r := range username {
+		if isUsernameRune(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	sanitized := b.String()
+	if sanitized == "" || !(sanitized[0] >= 'a' && sanitized[0] <= 'z') {
+		sanitized = "user_" + sanitized
+	}
+	return sanitized
+}
@@ -0,0 +1,460 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// phoneNumberRegexp matches E.164 numbers: "+", country code and subscriber number, 15 digits at most
+var phoneNumberRegexp = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// OTPLogic contains one-time passcode logic for passwordless login and phone verification.
+// Phones are stored in E.164 format and only verified phones can be used to log in.
+type OTPLogic struct {
+	config       config.OTPConfig
+	userRepo     repository.UserRepository
+	redisRepo    repository.RedisRepository
+	emailService EmailServiceInterface
+	smsProvider  repository.SMSProvider
+	logger       util.Logger
+}
+
+// NewOTPLogic creates new OTPLogic instance
+func NewOTPLogic(
+	config config.OTPConfig,
+	userRepo repository.UserRepository,
+	redisRepo repository.RedisRepository,
+	emailService EmailServiceInterface,
+	smsProvider repository.SMSProvider,
+	logger util.Logger,
+) *OTPLogic {
+	return &OTPLogic{
+		config:       config,
+		userRepo:     userRepo,
+		redisRepo:    redisRepo,
+		emailService: emailService,
+		smsProvider:  smsProvider,
+		logger:       logger,
+	}
+}
+
+// RequestOTP generates and sends login code to email or phone.
+// Unknown destinations get the same response without sending so accounts can't be enumerated.
+func (l *OTPLogic) RequestOTP(ctx context.Context, req model.OTPRequest) (*model.OTPResponse, error) {
+	destination, err := l.NormalizeDestination(req.Type, req.Destination)
+	if err != nil {
+		return nil, err
+	}
+	keyPart := otpKeyPart(req.Type, destination)
+
+	if err := l.throttle(ctx, keyPart); err != nil {
+		l.logger.Warn("OTP request throttled",
+			util.Int("type", req.Type),
+			util.String("destination", destination),
+			util.String("ip", req.IPAddress),
+		)
+		return nil, err
+	}
+
+	response := &model.OTPResponse{
+		ExpiresIn: int64(l.config.ExpireMinute) * 60,
+		ResendIn:  int64(l.config.ResendIntervalSecond),
+	}
+
+	user, err := l.findUser(ctx, req.Type, destination)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		l.logger.Info("OTP requested for unknown or inactive account",
+			util.Int("type", req.Type),
+			util.String("destination", destination),
+		)
+		return response, nil
+	}
+
+	if err := l.issue(ctx, keyPart, req.Type, destination, user); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// VerifyOTP checks login code and returns user on success.
+// Code is dropped after too many wrong attempts so a new one must be requested.
+func (l *OTPLogic) VerifyOTP(ctx context.Context, req model.OTPLoginRequest) (*entity.User, error) {
+	destination, err := l.NormalizeDestination(req.Type, req.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.checkCode(ctx, otpKeyPart(req.Type, destination), req.Code); err != nil {
+		if err.Error() == _const.CodeOTPInvalid.Message() {
+			l.logger.Warn("Invalid OTP code",
+				util.Int("type", req.Type),
+				util.String("destination", destination),
+			)
+		}
+		return nil, err
+	}
+
+	user, err := l.findUser(ctx, req.Type, destination)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeOTPInvalid.Message())
+	}
+
+	// Receiving the code proves ownership of email, phones are verified before they can log in
+	if req.Type == _const.EMAIL && !user.IsVerified {
+		if err := l.userRepo.VerifyEmail(ctx, user.ID); err != nil {
+			l.logger.Error("Failed to verify email", util.Error(err))
+		}
+	}
+
+	return user, nil
+}
+
+// RequestPhoneVerification sends verification code to phone of user.
+// Sends share throttling of phone with login codes.
+func (l *OTPLogic) RequestPhoneVerification(ctx context.Context, userID uint) (*model.OTPResponse, error) {
+	user, phone, err := l.unverifiedPhone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.throttle(ctx, otpKeyPart(_const.PHONE, phone)); err != nil {
+		l.logger.Warn("Phone verification request throttled",
+			util.Int("user_id", int(userID)),
+			util.String("phone", phone),
+		)
+		return nil, err
+	}
+
+	if err := l.issue(ctx, phoneVerificationKeyPart(userID, phone), _const.PHONE, phone, user); err != nil {
+		return nil, err
+	}
+
+	return &model.OTPResponse{
+		ExpiresIn: int64(l.config.ExpireMinute) * 60,
+		ResendIn:  int64(l.config.ResendIntervalSecond),
+	}, nil
+}
+
+// ConfirmPhoneVerification checks code sent to phone of user and marks phone verified.
+// Phone verified by another account can't be verified again.
+func (l *OTPLogic) ConfirmPhoneVerification(ctx context.Context, userID uint, code string) error {
+	_, phone, err := l.unverifiedPhone(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := l.checkCode(ctx, phoneVerificationKeyPart(userID, phone), code); err != nil {
+		return err
+	}
+
+	owner, err := l.userRepo.GetByPhone(ctx, phone)
+	if err != nil {
+		l.logger.Error("Failed to get user by phone", util.Error(err))
+		return err
+	}
+	if owner != nil && owner.ID != userID {
+		return util.NewError(_const.CodePhoneExists.Message())
+	}
+
+	if err := l.userRepo.VerifyPhone(ctx, userID); err != nil {
+		l.logger.Error("Failed to verify phone", util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RequestReauthCode sends re-authentication code to email of user.
+// Sends share throttling of email with login codes.
+func (l *OTPLogic) RequestReauthCode(ctx context.Context, user *entity.User) (*model.OTPResponse, error) {
+	if err := l.throttle(ctx, otpKeyPart(_const.EMAIL, user.Email)); err != nil {
+		l.logger.Warn("Re-authentication code request throttled",
+			util.Int("user_id", int(user.ID)),
+		)
+		return nil, err
+	}
+
+	if err := l.issue(ctx, reauthKeyPart(user.ID), _const.EMAIL, user.Email, user); err != nil {
+		return nil, err
+	}
+
+	return &model.OTPResponse{
+		ExpiresIn: int64(l.config.ExpireMinute) * 60,
+		ResendIn:  int64(l.config.ResendIntervalSecond),
+	}, nil
+}
+
+// ConfirmReauthCode checks re-authentication code of user, code can be used once
+func (l *OTPLogic) ConfirmReauthCode(ctx context.Context, userID uint, code string) error {
+	return l.checkCode(ctx, reauthKeyPart(userID), code)
+}
+
+// NormalizeDestination validates email or phone number and returns it in stored format
+func (l *OTPLogic) NormalizeDestination(otpType int, destination string) (string, error) {
+	switch otpType {
+	case _const.EMAIL:
+		email := normalizeEmail(destination)
+		if !strings.Contains(email, "@") {
+			return "", util.NewError(_const.CodeBadRequest.Message())
+		}
+		return email, nil
+	case _const.PHONE:
+		return l.NormalizePhone(destination)
+	default:
+		return "", util.NewError(_const.CodeBadRequest.Message())
+	}
+}
+
+// NormalizePhone validates phone number and returns it in E.164 format
+func (l *OTPLogic) NormalizePhone(phone string) (string, error) {
+	return normalizePhone(phone, l.config.DefaultCountryCode)
+}
+
+// unverifiedPhone gets user with phone which isn't verified yet
+func (l *OTPLogic) unverifiedPhone(ctx context.Context, userID uint) (*entity.User, string, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, "", err
+	}
+	if user == nil {
+		return nil, "", util.NewError(_const.CodeUserNotFound.Message())
+	}
+	if user.PhoneVerifiedAt != nil {
+		return nil, "", util.NewError(_const.CodePhoneVerified.Message())
+	}
+
+	// Phones stored before E.164 normalization are normalized on verification
+	phone, err := l.NormalizePhone(user.Phone)
+	if err != nil {
+		return nil, "", err
+	}
+	if phone != user.Phone {
+		user.Phone = phone
+		if err := l.userRepo.Update(ctx, user); err != nil {
+			l.logger.Error("Failed to update phone", util.Error(err))
+			return nil, "", err
+		}
+	}
+
+	return user, phone, nil
+}
+
+// issue generates code, stores its hash under key part and sends it to destination
+func (l *OTPLogic) issue(ctx context.Context, keyPart string, otpType int, destination string, user *entity.User) error {
+	code, err := generateOTPCode(l.config.Length)
+	if err != nil {
+		l.logger.Error("Failed to generate OTP code", util.Error(err))
+		return err
+	}
+
+	ttl := time.Duration(l.config.ExpireMinute) * time.Minute
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyOTPCode.Key(keyPart), hashOTPCode(code), ttl); err != nil {
+		return err
+	}
+	// New code gets fresh attempts
+	if err := l.redisRepo.Del(ctx, _const.RedisKeyOTPAttempt.Key(keyPart)); err != nil {
+		return err
+	}
+
+	if err := l.send(ctx, otpType, destination, user, code); err != nil {
+		l.logger.Error("Failed to send OTP", util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// checkCode compares code with one stored under key part and drops it when used.
+// Code is dropped after too many wrong attempts so a new one must be requested.
+func (l *OTPLogic) checkCode(ctx context.Context, keyPart, code string) error {
+	storedHash, err := l.redisRepo.Get(ctx, _const.RedisKeyOTPCode.Key(keyPart))
+	if err != nil {
+		return err
+	}
+	if storedHash == "" {
+		return util.NewError(_const.CodeOTPInvalid.Message())
+	}
+
+	attemptKey := _const.RedisKeyOTPAttempt.Key(keyPart)
+	attempts, err := l.redisRepo.Incr(ctx, attemptKey)
+	if err != nil {
+		return err
+	}
+	if err := l.redisRepo.Expire(ctx, attemptKey, time.Duration(l.config.ExpireMinute)*time.Minute); err != nil {
+		return err
+	}
+	if l.config.MaxAttempts > 0 && attempts > int64(l.config.MaxAttempts) {
+		l.clearCode(ctx, keyPart)
+		return util.NewError(_const.CodeExceedLoginAttempts.Message())
+	}
+
+	if subtle.ConstantTimeCompare([]byte(storedHash), []byte(hashOTPCode(strings.TrimSpace(code)))) != 1 {
+		return util.NewError(_const.CodeOTPInvalid.Message())
+	}
+
+	l.clearCode(ctx, keyPart)
+	return nil
+}
+
+// throttle enforces resend interval and hourly send limit of destination
+func (l *OTPLogic) throttle(ctx context.Context, keyPart string) error {
+	resendKey := _const.RedisKeyOTPResend.Key(keyPart)
+	waiting, err := l.redisRepo.Exists(ctx, resendKey)
+	if err != nil {
+		return err
+	}
+	if waiting {
+		return util.NewError(_const.CodeOTPResendTooSoon.Message())
+	}
+
+	countKey := _const.RedisKeyOTPSendCount.Key(keyPart)
+	count, err := l.redisRepo.Incr(ctx, countKey)
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		if err := l.redisRepo.Expire(ctx, countKey, time.Hour); err != nil {
+			return err
+		}
+	}
+	if l.config.MaxSendsPerHour > 0 && count > int64(l.config.MaxSendsPerHour) {
+		return util.NewError(_const.CodeOTPSendLimit.Message())
+	}
+
+	if l.config.ResendIntervalSecond > 0 {
+		if err := l.redisRepo.Set(ctx, resendKey, "1", time.Duration(l.config.ResendIntervalSecond)*time.Second); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send delivers code via email service or SMS provider
+func (l *OTPLogic) send(ctx context.Context, otpType int, destination string, user *entity.User, code string) error {
+	switch otpType {
+	case _const.EMAIL:
+		name := strings.TrimSpace(user.Firstname + " " + user.Lastname)
+		if name == "" {
+			name = user.Username
+		}
+		return l.emailService.SendLoginOTP(ctx, destination, name, code, l.config.ExpireMinute)
+	case _const.PHONE:
+		message := fmt.Sprintf("Your Bitzap login code is %s. It expires in %d minutes. Never share this code.", code, l.config.ExpireMinute)
+		return l.smsProvider.SendSMS(ctx, destination, message)
+	default:
+		return util.NewError(_const.CodeBadRequest.Message())
+	}
+}
+
+// findUser gets user by email or phone
+func (l *OTPLogic) findUser(ctx context.Context, otpType int, destination string) (*entity.User, error) {
+	var (
+		user *entity.User
+		err  error
+	)
+	if otpType == _const.PHONE {
+		user, err = l.userRepo.GetByPhone(ctx, destination)
+	} else {
+		user, err = l.userRepo.GetByEmail(ctx, destination)
+	}
+	if err != nil {
+		l.logger.Error("Failed to get user for OTP", util.Error(err))
+		return nil, err
+	}
+	return user, nil
+}
+
+// clearCode removes code and its attempt counter
+func (l *OTPLogic) clearCode(ctx context.Context, keyPart string) {
+	for _, key := range []string{
+		_const.RedisKeyOTPCode.Key(keyPart),
+		_const.RedisKeyOTPAttempt.Key(keyPart),
+	} {
+		if err := l.redisRepo.Del(ctx, key); err != nil {
+			l.logger.Error("Failed to delete OTP code", util.Error(err))
+		}
+	}
+}
+
+// normalizePhone strips formatting of phone number and returns it in E.164 format.
+// "00" international prefix becomes "+", numbers without it get default country code
+// in place of their leading trunk "0", or are rejected when no default is set.
+func normalizePhone(phone, defaultCountryCode string) (string, error) {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(phone))
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(phone, "00"):
+		phone = "+" + strings.TrimPrefix(phone, "00")
+	case defaultCountryCode != "":
+		phone = "+" + strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimPrefix(phone, "0")
+	default:
+		return "", util.NewError(_const.CodeInvalidPhoneNumber.Message())
+	}
+
+	if !phoneNumberRegexp.MatchString(phone) {
+		return "", util.NewError(_const.CodeInvalidPhoneNumber.Message())
+	}
+	return phone, nil
+}
+
+// otpKeyPart builds Redis key part for destination
+func otpKeyPart(otpType int, destination string) string {
+	return strconv.Itoa(otpType) + "-" + destination
+}
+
+// phoneVerificationKeyPart builds Redis key part for verification of phone of user,
+// changing phone abandons its pending code
+func phoneVerificationKeyPart(userID uint, phone string) string {
+	return "verify-" + strconv.FormatUint(uint64(userID), 10) + "-" + phone
+}
+
+// reauthKeyPart builds Redis key part for re-authentication code of user
+func reauthKeyPart(userID uint) string {
+	return "reauth-" + strconv.FormatUint(uint64(userID), 10)
+}
+
+// generateOTPCode generates random numeric code of length
+func generateOTPCode(length int) (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < length; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// hashOTPCode returns SHA-256 hex digest of code
+func hashOTPCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
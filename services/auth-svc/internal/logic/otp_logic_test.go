@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"testing"
+
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name               string
+		phone              string
+		defaultCountryCode string
+		want               string
+		wantErr            bool
+	}{
+		{name: "E.164", phone: "+84901234567", want: "+84901234567"},
+		{name: "formatting stripped", phone: " +1 (415) 555-0100 ", want: "+14155550100"},
+		{name: "international prefix", phone: "0084 901 234 567", want: "+84901234567"},
+		{name: "trunk prefix with default country", phone: "0901234567", defaultCountryCode: "84", want: "+84901234567"},
+		{name: "default country with plus", phone: "901.234.567", defaultCountryCode: "+84", want: "+84901234567"},
+		{name: "national number without default country", phone: "0901234567", wantErr: true},
+		{name: "country code can't start with 0", phone: "+0901234567", wantErr: true},
+		{name: "too short", phone: "+8490123", wantErr: true},
+		{name: "too long", phone: "+8490123456789012", wantErr: true},
+		{name: "letters", phone: "+84 90 CALL ME", wantErr: true},
+		{name: "empty", phone: "", defaultCountryCode: "84", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizePhone(tt.phone, tt.defaultCountryCode)
+			if tt.wantErr {
+				if err == nil || err.Error() != _const.CodeInvalidPhoneNumber.Message() {
+					t.Fatalf("normalizePhone(%q) = %q, %v, want CodeInvalidPhoneNumber", tt.phone, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizePhone(%q) error = %v", tt.phone, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// minAccountInfoLength is the shortest email local part or username checked against password
+const minAccountInfoLength = 3
+
+// PasswordPolicy validates password strength against configured rules
+type PasswordPolicy struct {
+	config config.PasswordPolicyConfig
+}
+
+// NewPasswordPolicy creates new PasswordPolicy instance
+func NewPasswordPolicy(config config.PasswordPolicyConfig) *PasswordPolicy {
+	return &PasswordPolicy{config: config}
+}
+
+// Validate checks password and returns error with message of the violated rule code.
+// Email and username of the account are rejected as part of the password.
+func (p *PasswordPolicy) Validate(password, email, username string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.config.MinLength {
+		return util.NewError(_const.CodePassTooShort.Message())
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		return util.NewError(_const.CodePassTooLong.Message())
+	}
+
+	var hasUpper, hasLower, hasNumber, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasNumber = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.config.RequireUpper && !hasUpper {
+		return util.NewError(_const.CodePassMissUpperCase.Message())
+	}
+	if p.config.RequireLower && !hasLower {
+		return util.NewError(_const.CodePassMissLowerCase.Message())
+	}
+	if p.config.RequireNumber && !hasNumber {
+		return util.NewError(_const.CodePassMissNumber.Message())
+	}
+	if p.config.RequireSpecial && !hasSpecial {
+		return util.NewError(_const.CodePassMissSpecial.Message())
+	}
+
+	if p.config.RejectAccountInfo && containsAccountInfo(password, email, username) {
+		return util.NewError(_const.CodePassContainAccount.Message())
+	}
+
+	return nil
+}
+
+// containsAccountInfo checks if password contains email, its local part or username
+func containsAccountInfo(password, email, username string) bool {
+	lowered := strings.ToLower(password)
+
+	candidates := []string{strings.ToLower(email), strings.ToLower(username)}
+	if at := strings.Index(email, "@"); at > 0 {
+		candidates = append(candidates, strings.ToLower(email[:at]))
+	}
+
+	for _, candidate := range candidates {
+		if utf8.RuneCountInString(candidate) < minAccountInfoLength {
+			continue
+		}
+		if strings.Contains(lowered, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	strict := config.PasswordPolicyConfig{
+		MinLength:         8,
+		MaxLength:         20,
+		RequireUpper:      true,
+		RequireLower:      true,
+		RequireNumber:     true,
+		RequireSpecial:    true,
+		RejectAccountInfo: true,
+	}
+
+	tests := []struct {
+		name     string
+		config   config.PasswordPolicyConfig
+		password string
+		email    string
+		username string
+		wantErr  string
+	}{
+		{name: "valid", config: strict, password: "Str0ng!Pass"},
+		{name: "too short", config: strict, password: "S0!a", wantErr: _const.CodePassTooShort.Message()},
+		{name: "length counts runes not bytes", config: strict, password: "Pä1!öüé", wantErr: _const.CodePassTooShort.Message()},
+		{name: "too long", config: strict, password: "Str0ng!Pass-Str0ng!Pass", wantErr: _const.CodePassTooLong.Message()},
+		{name: "missing upper", config: strict, password: "str0ng!pass", wantErr: _const.CodePassMissUpperCase.Message()},
+		{name: "missing lower", config: strict, password: "STR0NG!PASS", wantErr: _const.CodePassMissLowerCase.Message()},
+		{name: "missing number", config: strict, password: "Strong!Pass", wantErr: _const.CodePassMissNumber.Message()},
+		{name: "missing special", config: strict, password: "Str0ngPass1", wantErr: _const.CodePassMissSpecial.Message()},
+		{name: "space is special", config: strict, password: "Str0ng Pass"},
+		{name: "contains username", config: strict, password: "Alice!2024x", username: "alice", wantErr: _const.CodePassContainAccount.Message()},
+		{name: "contains email local part", config: strict, password: "xBob.Smith1!", email: "bob.smith@example.com", wantErr: _const.CodePassContainAccount.Message()},
+		{name: "contains whole email", config: strict, password: "A1!jo@ex.io", email: "jo@ex.io", wantErr: _const.CodePassContainAccount.Message()},
+		{name: "short account info is ignored", config: strict, password: "Str0ng!Pass", email: "st@example.com", username: "ng"},
+		{name: "account info allowed when not rejected", config: config.PasswordPolicyConfig{MinLength: 8}, password: "alice123", username: "alice"},
+		{name: "no max length", config: config.PasswordPolicyConfig{MinLength: 8}, password: "a-very-long-passphrase-without-limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewPasswordPolicy(tt.config).Validate(tt.password, tt.email, tt.username)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v, want nil", tt.password, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, want %q", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
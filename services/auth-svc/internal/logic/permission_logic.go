@@ -0,0 +1,307 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// PermissionLogic evaluates role based access control.
+// Effective permissions of user are union of permissions of its roles and
+// permissions granted directly, cached in Redis until roles or permissions change.
+type PermissionLogic struct {
+	config             config.PermissionConfig
+	roleRepo           repository.RoleRepository
+	userRoleRepo       repository.UserRoleRepository
+	userPermissionRepo repository.UserPermissionRepository
+	redisRepo          repository.RedisRepository
+	logger             util.Logger
+}
+
+// NewPermissionLogic creates new PermissionLogic instance
+func NewPermissionLogic(
+	config config.PermissionConfig,
+	roleRepo repository.RoleRepository,
+	userRoleRepo repository.UserRoleRepository,
+	userPermissionRepo repository.UserPermissionRepository,
+	redisRepo repository.RedisRepository,
+	logger util.Logger,
+) *PermissionLogic {
+	return &PermissionLogic{
+		config:             config,
+		roleRepo:           roleRepo,
+		userRoleRepo:       userRoleRepo,
+		userPermissionRepo: userPermissionRepo,
+		redisRepo:          redisRepo,
+		logger:             logger,
+	}
+}
+
+// GetEffective returns roles and effective permissions of user
+func (l *PermissionLogic) GetEffective(ctx context.Context, userID uint) (*model.EffectivePermissions, error) {
+	key := _const.RedisKeyRolePermission.Key(strconv.FormatUint(uint64(userID), 10))
+
+	if l.config.CacheExpireSecond > 0 {
+		value, err := l.redisRepo.Get(ctx, key)
+		if err != nil {
+			l.logger.Error("Failed to get cached permissions", util.Error(err))
+		}
+		var effective model.EffectivePermissions
+		if value != "" && json.Unmarshal([]byte(value), &effective) == nil {
+			return &effective, nil
+		}
+	}
+
+	effective, err := l.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.config.CacheExpireSecond > 0 {
+		value, err := json.Marshal(effective)
+		if err == nil {
+			err = l.redisRepo.Set(ctx, key, string(value), time.Duration(l.config.CacheExpireSecond)*time.Second)
+		}
+		if err != nil {
+			l.logger.Error("Failed to cache permissions", util.Error(err))
+		}
+	}
+
+	return effective, nil
+}
+
+// HasPermission reports whether user is granted resource/action by its roles or directly
+func (l *PermissionLogic) HasPermission(ctx context.Context, userID uint, resource, action string) (bool, error) {
+	effective, err := l.GetEffective(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return effective.Has(normalizePermission(resource), normalizePermission(action)), nil
+}
+
+// HasRole reports whether user has role
+func (l *PermissionLogic) HasRole(ctx context.Context, userID uint, role string) (bool, error) {
+	effective, err := l.GetEffective(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return effective.HasRole(role), nil
+}
+
+// AssignDefaultRole assigns configured default role to new user
+func (l *PermissionLogic) AssignDefaultRole(ctx context.Context, userID uint) error {
+	if l.config.DefaultRole == "" {
+		return nil
+	}
+	return l.AssignRole(ctx, userID, l.config.DefaultRole)
+}
+
+// AssignRole assigns role to user
+func (l *PermissionLogic) AssignRole(ctx context.Context, userID uint, roleName string) error {
+	if _, err := l.getRole(ctx, roleName); err != nil {
+		return err
+	}
+
+	if err := l.userRoleRepo.AddRoleToUser(ctx, userID, roleName); err != nil {
+		l.logger.Error("Failed to add role to user", util.String("role", roleName), util.Error(err))
+		return err
+	}
+
+	return l.Invalidate(ctx, userID)
+}
+
+// RemoveRole removes role from user
+func (l *PermissionLogic) RemoveRole(ctx context.Context, userID uint, roleName string) error {
+	if _, err := l.getRole(ctx, roleName); err != nil {
+		return err
+	}
+
+	if err := l.userRoleRepo.RemoveRoleFromUser(ctx, userID, roleName); err != nil {
+		l.logger.Error("Failed to remove role from user", util.String("role", roleName), util.Error(err))
+		return err
+	}
+
+	return l.Invalidate(ctx, userID)
+}
+
+// GrantPermission grants resource/action to user directly
+func (l *PermissionLogic) GrantPermission(ctx context.Context, userID uint, resource, action string) error {
+	resource, action = normalizePermission(resource), normalizePermission(action)
+	if resource == "" || action == "" {
+		return util.NewError(_const.CodeBadRequest.Message())
+	}
+
+	exists, err := l.userPermissionRepo.HasPermission(ctx, userID, resource, action)
+	if err != nil {
+		l.logger.Error("Failed to check user permission", util.Error(err))
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if err := l.userPermissionRepo.AddPermissionToUser(ctx, userID, resource, action); err != nil {
+		l.logger.Error("Failed to add permission to user", util.Error(err))
+		return err
+	}
+
+	return l.Invalidate(ctx, userID)
+}
+
+// RevokePermission revokes resource/action granted to user directly
+func (l *PermissionLogic) RevokePermission(ctx context.Context, userID uint, resource, action string) error {
+	resource, action = normalizePermission(resource), normalizePermission(action)
+
+	if err := l.userPermissionRepo.RemovePermissionFromUser(ctx, userID, resource, action); err != nil {
+		l.logger.Error("Failed to remove permission from user", util.Error(err))
+		return err
+	}
+
+	return l.Invalidate(ctx, userID)
+}
+
+// ListRoles returns all roles with their permissions
+func (l *PermissionLogic) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	roles, err := l.roleRepo.List(ctx)
+	if err != nil {
+		l.logger.Error("Failed to list roles", util.Error(err))
+		return nil, err
+	}
+	return roles, nil
+}
+
+// AddRolePermission grants resource/action to role and drops cache of its users
+func (l *PermissionLogic) AddRolePermission(ctx context.Context, roleName, resource, action string) error {
+	resource, action = normalizePermission(resource), normalizePermission(action)
+	if resource == "" || action == "" {
+		return util.NewError(_const.CodeBadRequest.Message())
+	}
+
+	role, err := l.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	if err := l.roleRepo.AddPermission(ctx, role.ID, resource, action); err != nil {
+		l.logger.Error("Failed to add permission to role", util.String("role", roleName), util.Error(err))
+		return err
+	}
+
+	return l.invalidateRole(ctx, role.ID)
+}
+
+// RemoveRolePermission revokes resource/action from role and drops cache of its users
+func (l *PermissionLogic) RemoveRolePermission(ctx context.Context, roleName, resource, action string) error {
+	role, err := l.getRole(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	if err := l.roleRepo.RemovePermission(ctx, role.ID, normalizePermission(resource), normalizePermission(action)); err != nil {
+		l.logger.Error("Failed to remove permission from role", util.String("role", roleName), util.Error(err))
+		return err
+	}
+
+	return l.invalidateRole(ctx, role.ID)
+}
+
+// Invalidate drops cached effective permissions of user
+func (l *PermissionLogic) Invalidate(ctx context.Context, userID uint) error {
+	key := _const.RedisKeyRolePermission.Key(strconv.FormatUint(uint64(userID), 10))
+	if err := l.redisRepo.Del(ctx, key); err != nil {
+		l.logger.Error("Failed to drop cached permissions", util.Int("user_id", int(userID)), util.Error(err))
+		return err
+	}
+	return nil
+}
+
+// load reads roles and permissions of user from database
+func (l *PermissionLogic) load(ctx context.Context, userID uint) (*model.EffectivePermissions, error) {
+	roles, err := l.userRoleRepo.GetRoleNamesByUserID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user roles", util.Error(err))
+		return nil, err
+	}
+
+	rolePermissions, err := l.roleRepo.GetPermissionsByUserID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get role permissions", util.Error(err))
+		return nil, err
+	}
+
+	userPermissions, err := l.userPermissionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user permissions", util.Error(err))
+		return nil, err
+	}
+
+	effective := &model.EffectivePermissions{
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: []model.Permission{},
+	}
+	if effective.Roles == nil {
+		effective.Roles = []string{}
+	}
+
+	seen := make(map[model.Permission]bool)
+	add := func(resource, action string) {
+		permission := model.Permission{Resource: normalizePermission(resource), Action: normalizePermission(action)}
+		if !seen[permission] {
+			seen[permission] = true
+			effective.Permissions = append(effective.Permissions, permission)
+		}
+	}
+	for _, permission := range rolePermissions {
+		add(permission.Resource, permission.Action)
+	}
+	for _, permission := range userPermissions {
+		add(permission.Resource, permission.Action)
+	}
+
+	return effective, nil
+}
+
+// getRole gets role by name, returns CodeRoleNotFound when missing
+func (l *PermissionLogic) getRole(ctx context.Context, roleName string) (*entity.Role, error) {
+	role, err := l.roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		l.logger.Error("Failed to get role", util.String("role", roleName), util.Error(err))
+		return nil, err
+	}
+	if role == nil {
+		return nil, util.NewError(_const.CodeRoleNotFound.Message())
+	}
+	return role, nil
+}
+
+// invalidateRole drops cached effective permissions of every user having role
+func (l *PermissionLogic) invalidateRole(ctx context.Context, roleID uint) error {
+	userIDs, err := l.userRoleRepo.GetUserIDsByRoleID(ctx, roleID)
+	if err != nil {
+		l.logger.Error("Failed to get users of role", util.Error(err))
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := l.Invalidate(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizePermission lowercases and trims resource or action
+func normalizePermission(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,318 @@
+package logic
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// permissionTestStore keeps roles, role assignments and direct grants in memory
+// and counts loads of effective permissions
+type permissionTestStore struct {
+	roles     map[string]*entity.Role
+	userRoles map[uint][]string
+	userPerms map[uint][]model.Permission
+	loads     int
+}
+
+func newPermissionTestStore() *permissionTestStore {
+	return &permissionTestStore{
+		roles: map[string]*entity.Role{
+			"user":    {ID: 1, Name: "user", Permissions: []entity.RolePermission{{Resource: "profile", Action: "read"}, {Resource: "profile", Action: "update"}}},
+			"support": {ID: 2, Name: "support", Permissions: []entity.RolePermission{{Resource: "users", Action: "read"}, {Resource: "profile", Action: "read"}}},
+			"admin":   {ID: 3, Name: "admin", Permissions: []entity.RolePermission{{Resource: "*", Action: "*"}}},
+		},
+		userRoles: map[uint][]string{},
+		userPerms: map[uint][]model.Permission{},
+	}
+}
+
+type permissionTestRoleRepo struct {
+	repository.RoleRepository
+	store *permissionTestStore
+}
+
+func (r *permissionTestRoleRepo) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	return r.store.roles[name], nil
+}
+
+func (r *permissionTestRoleRepo) GetPermissionsByUserID(ctx context.Context, userID uint) ([]*entity.RolePermission, error) {
+	var permissions []*entity.RolePermission
+	for _, name := range r.store.userRoles[userID] {
+		for i := range r.store.roles[name].Permissions {
+			permissions = append(permissions, &r.store.roles[name].Permissions[i])
+		}
+	}
+	return permissions, nil
+}
+
+func (r *permissionTestRoleRepo) AddPermission(ctx context.Context, roleID uint, resource, action string) error {
+	for _, role := range r.store.roles {
+		if role.ID == roleID {
+			role.Permissions = append(role.Permissions, entity.RolePermission{RoleID: roleID, Resource: resource, Action: action})
+		}
+	}
+	return nil
+}
+
+type permissionTestUserRoleRepo struct {
+	repository.UserRoleRepository
+	store *permissionTestStore
+}
+
+func (r *permissionTestUserRoleRepo) GetRoleNamesByUserID(ctx context.Context, userID uint) ([]string, error) {
+	r.store.loads++
+	return r.store.userRoles[userID], nil
+}
+
+func (r *permissionTestUserRoleRepo) AddRoleToUser(ctx context.Context, userID uint, role string) error {
+	r.store.userRoles[userID] = append(r.store.userRoles[userID], role)
+	return nil
+}
+
+func (r *permissionTestUserRoleRepo) GetUserIDsByRoleID(ctx context.Context, roleID uint) ([]uint, error) {
+	var userIDs []uint
+	for userID, names := range r.store.userRoles {
+		for _, name := range names {
+			if r.store.roles[name].ID == roleID {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	return userIDs, nil
+}
+
+type permissionTestUserPermissionRepo struct {
+	repository.UserPermissionRepository
+	store *permissionTestStore
+}
+
+func (r *permissionTestUserPermissionRepo) GetByUserID(ctx context.Context, userID uint) ([]*entity.UserPermission, error) {
+	var permissions []*entity.UserPermission
+	for _, permission := range r.store.userPerms[userID] {
+		permissions = append(permissions, &entity.UserPermission{UserID: userID, Resource: permission.Resource, Action: permission.Action})
+	}
+	return permissions, nil
+}
+
+func (r *permissionTestUserPermissionRepo) HasPermission(ctx context.Context, userID uint, resource, action string) (bool, error) {
+	for _, permission := range r.store.userPerms[userID] {
+		if permission.Resource == resource && permission.Action == action {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *permissionTestUserPermissionRepo) AddPermissionToUser(ctx context.Context, userID uint, resource, action string) error {
+	r.store.userPerms[userID] = append(r.store.userPerms[userID], model.Permission{Resource: resource, Action: action})
+	return nil
+}
+
+func newTestPermissionLogic(store *permissionTestStore, cacheExpireSecond int) (*PermissionLogic, *fakeRedisRepo) {
+	redis := newFakeRedisRepo()
+	return NewPermissionLogic(
+		config.PermissionConfig{DefaultRole: "user", CacheExpireSecond: cacheExpireSecond},
+		&permissionTestRoleRepo{store: store},
+		&permissionTestUserRoleRepo{store: store},
+		&permissionTestUserPermissionRepo{store: store},
+		redis,
+		util.NewZapLogger(zap.NewNop()),
+	), redis
+}
+
+func TestPermissionLogicEffective(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string
+		direct    []model.Permission
+		wantPerms []model.Permission
+		allowed   []model.Permission
+		denied    []model.Permission
+	}{
+		{
+			name: "no roles",
+			denied: []model.Permission{
+				{Resource: "profile", Action: "read"},
+			},
+		},
+		{
+			name:      "union of roles without duplicates",
+			roles:     []string{"user", "support"},
+			wantPerms: []model.Permission{{Resource: "profile", Action: "read"}, {Resource: "profile", Action: "update"}, {Resource: "users", Action: "read"}},
+			allowed:   []model.Permission{{Resource: "users", Action: "read"}, {Resource: "profile", Action: "update"}},
+			denied:    []model.Permission{{Resource: "users", Action: "update"}},
+		},
+		{
+			name:      "direct grants are normalized and added to roles",
+			roles:     []string{"user"},
+			direct:    []model.Permission{{Resource: " Reports ", Action: "EXPORT"}, {Resource: "profile", Action: "read"}},
+			wantPerms: []model.Permission{{Resource: "profile", Action: "read"}, {Resource: "profile", Action: "update"}, {Resource: "reports", Action: "export"}},
+			allowed:   []model.Permission{{Resource: "Reports", Action: "Export"}},
+			denied:    []model.Permission{{Resource: "reports", Action: "delete"}},
+		},
+		{
+			name:      "wildcard grants everything",
+			roles:     []string{"admin"},
+			wantPerms: []model.Permission{{Resource: "*", Action: "*"}},
+			allowed:   []model.Permission{{Resource: "users", Action: "delete"}, {Resource: "anything", Action: "at-all"}},
+		},
+		{
+			name:      "wildcard action of resource",
+			direct:    []model.Permission{{Resource: "tenants", Action: "*"}},
+			wantPerms: []model.Permission{{Resource: "tenants", Action: "*"}},
+			allowed:   []model.Permission{{Resource: "tenants", Action: "delete"}},
+			denied:    []model.Permission{{Resource: "users", Action: "delete"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newPermissionTestStore()
+			store.userRoles[1] = tt.roles
+			store.userPerms[1] = tt.direct
+			l, _ := newTestPermissionLogic(store, 60)
+
+			effective, err := l.GetEffective(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetEffective() error = %v", err)
+			}
+			got := append([]model.Permission{}, effective.Permissions...)
+			sort.Slice(got, func(i, j int) bool {
+				return got[i].Resource+":"+got[i].Action < got[j].Resource+":"+got[j].Action
+			})
+			want := tt.wantPerms
+			if want == nil {
+				want = []model.Permission{}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("permissions = %v, want %v", got, want)
+			}
+
+			for _, permission := range tt.allowed {
+				if ok, _ := l.HasPermission(ctx, 1, permission.Resource, permission.Action); !ok {
+					t.Errorf("HasPermission(%s, %s) = false, want true", permission.Resource, permission.Action)
+				}
+			}
+			for _, permission := range tt.denied {
+				if ok, _ := l.HasPermission(ctx, 1, permission.Resource, permission.Action); ok {
+					t.Errorf("HasPermission(%s, %s) = true, want false", permission.Resource, permission.Action)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionLogicCache(t *testing.T) {
+	tests := []struct {
+		name string
+		// change is applied between two reads of user 1
+		change    func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error
+		cache     int
+		wantLoads int
+		wantAllow bool
+	}{
+		{
+			name:      "second read is cached",
+			change:    func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error { return nil },
+			cache:     60,
+			wantLoads: 1,
+		},
+		{
+			name:      "cache disabled",
+			change:    func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error { return nil },
+			wantLoads: 2,
+		},
+		{
+			name: "cache expires",
+			change: func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error {
+				redis.advance(time.Minute)
+				return nil
+			},
+			cache:     60,
+			wantLoads: 2,
+		},
+		{
+			name: "assigning role drops cache",
+			change: func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error {
+				return l.AssignRole(ctx, 1, "support")
+			},
+			cache:     60,
+			wantLoads: 2,
+			wantAllow: true,
+		},
+		{
+			name: "granting permission drops cache",
+			change: func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error {
+				return l.GrantPermission(ctx, 1, "users", "read")
+			},
+			cache:     60,
+			wantLoads: 2,
+			wantAllow: true,
+		},
+		{
+			name: "changing role drops cache of its users",
+			change: func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error {
+				return l.AddRolePermission(ctx, "user", "users", "read")
+			},
+			cache:     60,
+			wantLoads: 2,
+			wantAllow: true,
+		},
+		{
+			name: "changing other role keeps cache",
+			change: func(ctx context.Context, l *PermissionLogic, redis *fakeRedisRepo) error {
+				return l.AddRolePermission(ctx, "support", "users", "update")
+			},
+			cache:     60,
+			wantLoads: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newPermissionTestStore()
+			store.userRoles[1] = []string{"user"}
+			l, redis := newTestPermissionLogic(store, tt.cache)
+
+			if ok, err := l.HasPermission(ctx, 1, "users", "read"); err != nil || ok {
+				t.Fatalf("HasPermission() before change = %v, %v", ok, err)
+			}
+			if err := tt.change(ctx, l, redis); err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+
+			ok, err := l.HasPermission(ctx, 1, "users", "read")
+			if err != nil {
+				t.Fatalf("HasPermission() error = %v", err)
+			}
+			if ok != tt.wantAllow {
+				t.Errorf("HasPermission() after change = %v, want %v", ok, tt.wantAllow)
+			}
+			if store.loads != tt.wantLoads {
+				t.Errorf("loads = %d, want %d", store.loads, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestPermissionLogicAssignUnknownRole(t *testing.T) {
+	l, _ := newTestPermissionLogic(newPermissionTestStore(), 60)
+
+	err := l.AssignRole(context.Background(), 1, "superuser")
+	if err == nil || err.Error() != _const.CodeRoleNotFound.Message() {
+		t.Errorf("AssignRole() error = %v, want %q", err, _const.CodeRoleNotFound.Message())
+	}
+}
@@ -0,0 +1,350 @@
+package logic
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	dataExportTokenLength     = 48
+	dataExportActivityPerPage = 500
+	defaultPurgeBatchSize     = 100
+)
+
+// PrivacyLogic handles data subject requests: personal data export and
+// account deletion. Deletion is scheduled after grace period and carried out
+// by PurgeDue, which deletes user and keeps its activity logs only under pseudonym.
+type PrivacyLogic struct {
+	config           config.PrivacyConfig
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	apiKeyRepo       repository.APIKeyRepository
+	redisRepo        repository.RedisRepository
+	emailService     EmailServiceInterface
+	tokenLogic       *TokenLogic
+	permissions      *PermissionLogic
+	tenants          *TenantLogic
+	reauth           *ReauthLogic
+	kong             *KongLogic
+	logger           util.Logger
+}
+
+// NewPrivacyLogic creates new PrivacyLogic instance
+func NewPrivacyLogic(
+	config config.PrivacyConfig,
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	redisRepo repository.RedisRepository,
+	emailService EmailServiceInterface,
+	tokenLogic *TokenLogic,
+	permissions *PermissionLogic,
+	tenants *TenantLogic,
+	reauth *ReauthLogic,
+	kong *KongLogic,
+	logger util.Logger,
+) *PrivacyLogic {
+	return &PrivacyLogic{
+		config:           config,
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		apiKeyRepo:       apiKeyRepo,
+		redisRepo:        redisRepo,
+		emailService:     emailService,
+		tokenLogic:       tokenLogic,
+		permissions:      permissions,
+		tenants:          tenants,
+		reauth:           reauth,
+		kong:             kong,
+		logger:           logger,
+	}
+}
+
+// RequestExport builds zip archive of personal data of user and emails link to download it
+func (l *PrivacyLogic) RequestExport(ctx context.Context, userID uint, req model.DataExportRequest) error {
+	lockKey := _const.RedisKeyDataExportLock.Key(strconv.FormatUint(uint64(userID), 10))
+	locked, err := l.redisRepo.Exists(ctx, lockKey)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return util.NewError(_const.CodeExportTooSoon.Message())
+	}
+
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Log activity first so export contains the request itself
+	l.userActivityRepo.LogActivity(ctx, userID, "data_export", "user", req.IPAddress, req.UserAgent, nil)
+
+	archive, err := l.buildExport(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// Cooldown also applies to oversized exports so they aren't rebuilt on every retry
+	cooldown := time.Duration(l.config.ExportCooldownMinute) * time.Minute
+	if err := l.redisRepo.Set(ctx, lockKey, "1", cooldown); err != nil {
+		l.logger.Error("Failed to set data export cooldown", util.Error(err))
+	}
+
+	if l.config.ExportMaxSizeKB > 0 && len(archive) > l.config.ExportMaxSizeKB*1024 {
+		l.logger.Warn("Data export exceeds size limit",
+			util.Int("user_id", int(userID)),
+			util.Int("size", len(archive)),
+		)
+		return util.NewError(_const.CodeExportTooLarge.Message())
+	}
+
+	token := util.GenerateRandomString(dataExportTokenLength)
+	ttl := time.Duration(l.config.ExportLinkExpireHour) * time.Hour
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyDataExport.Key(strconv.FormatUint(uint64(userID), 10), token), string(archive), ttl); err != nil {
+		l.logger.Error("Failed to store data export", util.Error(err))
+		return err
+	}
+
+	if err := l.emailService.SendDataExportReady(ctx, user.Email, user.Firstname, token, l.config.ExportLinkExpireHour); err != nil {
+		l.logger.Error("Failed to send data export email", util.Int("user_id", int(userID)), util.Error(err))
+		return err
+	}
+
+	l.logger.Info("Data export created",
+		util.Int("user_id", int(userID)),
+		util.Int("size", len(archive)),
+	)
+
+	return nil
+}
+
+// DownloadExport returns data export archive of download token, only to user who requested it
+func (l *PrivacyLogic) DownloadExport(ctx context.Context, userID uint, token string) ([]byte, error) {
+	archive, err := l.redisRepo.Get(ctx, _const.RedisKeyDataExport.Key(strconv.FormatUint(uint64(userID), 10), token))
+	if err != nil {
+		return nil, err
+	}
+	if archive == "" {
+		return nil, util.NewError(_const.CodeExportLinkInvalid.Message())
+	}
+	return []byte(archive), nil
+}
+
+// RequestDeletion schedules deletion of account after grace period, confirmed with ReauthProof.
+// Sessions and API keys are revoked right away, user can still log in to cancel.
+func (l *PrivacyLogic) RequestDeletion(ctx context.Context, userID uint, req model.AccountDeletionRequest) (*model.AccountDeletionResponse, error) {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionDueAt != nil {
+		return nil, util.NewError(_const.CodeDeletionScheduled.Message())
+	}
+
+	if err := l.reauth.Check(ctx, user, req.ReauthProof); err != nil {
+		return nil, err
+	}
+
+	// Sessions are revoked before scheduling so failed request can be retried
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke tokens", util.Int("user_id", int(userID)), util.Error(err))
+		return nil, err
+	}
+
+	dueAt := time.Now().AddDate(0, 0, l.config.DeletionGraceDay)
+	if err := l.userRepo.SetDeletionDueAt(ctx, userID, &dueAt); err != nil {
+		l.logger.Error("Failed to schedule account deletion", util.Error(err))
+		return nil, err
+	}
+
+	if err := l.apiKeyRepo.RevokeByUserID(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke API keys", util.Int("user_id", int(userID)), util.Error(err))
+	}
+
+	if err := l.emailService.SendAccountDeletionScheduled(ctx, user.Email, user.Firstname, dueAt); err != nil {
+		l.logger.Error("Failed to send account deletion email", util.Int("user_id", int(userID)), util.Error(err))
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "account_deletion_requested", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"deletion_due_at": dueAt,
+	})
+
+	l.logger.Info("Account deletion scheduled",
+		util.Int("user_id", int(userID)),
+		util.String("due_at", dueAt.Format(time.RFC3339)),
+	)
+
+	return &model.AccountDeletionResponse{DeletionDueAt: dueAt}, nil
+}
+
+// CancelDeletion cancels scheduled deletion of account
+func (l *PrivacyLogic) CancelDeletion(ctx context.Context, userID uint, req model.CancelDeletionRequest) error {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionDueAt == nil {
+		return util.NewError(_const.CodeDeletionNotPending.Message())
+	}
+
+	if err := l.userRepo.SetDeletionDueAt(ctx, userID, nil); err != nil {
+		l.logger.Error("Failed to cancel account deletion", util.Error(err))
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "account_deletion_canceled", "user", req.IPAddress, req.UserAgent, nil)
+
+	return nil
+}
+
+// PurgeDue deletes accounts whose grace period is over. Activity logs are kept
+// without user ID, IP address, user agent and metadata under random pseudonym.
+func (l *PrivacyLogic) PurgeDue(ctx context.Context) (*model.PurgeResult, error) {
+	result := &model.PurgeResult{}
+	now := time.Now()
+
+	batchSize := l.config.PurgeBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+
+	var afterID uint
+	for {
+		users, err := l.userRepo.ListDeletionDue(ctx, now, afterID, batchSize)
+		if err != nil {
+			l.logger.Error("Failed to list accounts due for deletion", util.Error(err))
+			return result, err
+		}
+
+		for _, user := range users {
+			afterID = user.ID
+			if err := l.purgeUser(ctx, user); err != nil {
+				l.logger.Error("Failed to purge account", util.Int("user_id", int(user.ID)), util.Error(err))
+				result.Failed++
+				continue
+			}
+			result.Purged++
+		}
+
+		if len(users) < batchSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// purgeUser hands over tenants of user, revokes its access, pseudonymizes its
+// activity logs and deletes it
+func (l *PrivacyLogic) purgeUser(ctx context.Context, user *entity.User) error {
+	// Tenants must not be left without owner once memberships are deleted by cascade
+	if err := l.tenants.ReleaseOwnedTenants(ctx, user.ID); err != nil {
+		return err
+	}
+
+	// Logged before pseudonymization, so it's kept under pseudonym too
+	l.userActivityRepo.LogActivity(ctx, user.ID, "account_deleted", "user", "", "", nil)
+
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	l.kong.RemoveUser(ctx, user.ID)
+
+	if err := l.userActivityRepo.Pseudonymize(ctx, user.ID, uuid.New().String()); err != nil {
+		return err
+	}
+
+	// Roles, permissions, identities, API keys and devices are deleted by cascade
+	if err := l.userRepo.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := l.permissions.Invalidate(ctx, user.ID); err != nil {
+		l.logger.Error("Failed to drop cached permissions", util.Int("user_id", int(user.ID)), util.Error(err))
+	}
+
+	l.logger.Info("Account purged",
+		util.Int("user_id", int(user.ID)),
+	)
+
+	return nil
+}
+
+// buildExport collects personal data of user and zips it as JSON
+func (l *PrivacyLogic) buildExport(ctx context.Context, user *entity.User) ([]byte, error) {
+	effective, err := l.permissions.GetEffective(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := model.DataExport{
+		ExportedAt:   time.Now(),
+		User:         user,
+		Roles:        effective.Roles,
+		Permissions:  effective.Permissions,
+		ActivityLogs: []*entity.UserActivityLog{},
+	}
+
+	filter := model.ActivityFilter{UserID: user.ID, Limit: dataExportActivityPerPage}
+	for {
+		logs, err := l.userActivityRepo.ListByFilter(ctx, filter, false)
+		if err != nil {
+			l.logger.Error("Failed to list activity logs for export", util.Error(err))
+			return nil, err
+		}
+		export.ActivityLogs = append(export.ActivityLogs, logs...)
+		if len(logs) < dataExportActivityPerPage {
+			break
+		}
+		filter.Cursor = logs[len(logs)-1].ID
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(model.DataExportFileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(data); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// getUser gets user by ID, returns CodeUserNotFound when missing
+func (l *PrivacyLogic) getUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+	return user, nil
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ReauthLogic confirms identity of signed in user before sensitive actions like
+// account deletion and email change. Password, code sent to account email or
+// session signed in recently are accepted, so accounts without password aren't locked out.
+type ReauthLogic struct {
+	config     config.ReauthConfig
+	tokenLogic *TokenLogic
+	otp        *OTPLogic
+	logger     util.Logger
+}
+
+// NewReauthLogic creates new ReauthLogic instance
+func NewReauthLogic(config config.ReauthConfig, tokenLogic *TokenLogic, otp *OTPLogic, logger util.Logger) *ReauthLogic {
+	return &ReauthLogic{
+		config:     config,
+		tokenLogic: tokenLogic,
+		otp:        otp,
+		logger:     logger,
+	}
+}
+
+// RequestCode sends re-authentication code to email of user
+func (l *ReauthLogic) RequestCode(ctx context.Context, user *entity.User) (*model.OTPResponse, error) {
+	return l.otp.RequestReauthCode(ctx, user)
+}
+
+// Check returns nil when proof confirms identity of user. Password is checked first,
+// then emailed code, then whether session of proof was signed in within FreshLoginMinute.
+func (l *ReauthLogic) Check(ctx context.Context, user *entity.User, proof model.ReauthProof) error {
+	switch {
+	case proof.Password != "":
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(proof.Password)); err != nil {
+			l.logger.Warn("Invalid password for re-authentication",
+				util.Int("user_id", int(user.ID)),
+			)
+			return util.NewError(_const.CodeWrongPassword.Message())
+		}
+		return nil
+	case proof.Code != "":
+		return l.otp.ConfirmReauthCode(ctx, user.ID, proof.Code)
+	}
+
+	fresh, err := l.freshSession(ctx, user.ID, proof.FamilyID)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return util.NewError(_const.CodeReauthRequired.Message())
+	}
+	return nil
+}
+
+// freshSession reports whether token family of user was started by login within FreshLoginMinute.
+// Refreshing tokens keeps family, so it doesn't make session fresh again.
+func (l *ReauthLogic) freshSession(ctx context.Context, userID uint, familyID string) (bool, error) {
+	if familyID == "" || l.config.FreshLoginMinute <= 0 {
+		return false, nil
+	}
+
+	session, err := l.tokenLogic.GetSession(ctx, familyID)
+	if err != nil {
+		return false, err
+	}
+	if session == nil || session.UserID != userID {
+		return false, nil
+	}
+	return time.Since(session.CreatedAt) <= time.Duration(l.config.FreshLoginMinute)*time.Minute, nil
+}
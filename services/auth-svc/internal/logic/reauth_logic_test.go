@@ -0,0 +1,126 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+func TestReauthLogicCheck(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	user := &entity.User{ID: 1, Email: "user@example.com", PasswordHash: string(hash)}
+	// OAuth accounts have no password
+	oauthUser := &entity.User{ID: 2, Email: "oauth@example.com"}
+
+	tests := []struct {
+		name string
+		user *entity.User
+		// session is age of session signing in user, no session when zero
+		session time.Duration
+		// otherSession signs in another user and uses that family
+		otherSession bool
+		proof        model.ReauthProof
+		wantErr      string
+	}{
+		{name: "password", user: user, proof: model.ReauthProof{Password: "secret-password"}},
+		{name: "wrong password", user: user, proof: model.ReauthProof{Password: "wrong"}, wantErr: _const.CodeWrongPassword.Message()},
+		{name: "wrong password isn't saved by fresh session", user: user, session: time.Minute, proof: model.ReauthProof{Password: "wrong"}, wantErr: _const.CodeWrongPassword.Message()},
+		{name: "emailed code", user: oauthUser, proof: model.ReauthProof{Code: "123456"}},
+		{name: "wrong code", user: oauthUser, proof: model.ReauthProof{Code: "654321"}, wantErr: _const.CodeOTPInvalid.Message()},
+		{name: "fresh session", user: oauthUser, session: time.Minute},
+		{name: "stale session", user: oauthUser, session: time.Hour, wantErr: _const.CodeReauthRequired.Message()},
+		{name: "session of another user", user: oauthUser, session: time.Minute, otherSession: true, wantErr: _const.CodeReauthRequired.Message()},
+		{name: "no proof", user: oauthUser, wantErr: _const.CodeReauthRequired.Message()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTenantTestEnv(t, nil)
+			otp := NewOTPLogic(config.OTPConfig{ExpireMinute: 5, MaxAttempts: 3}, nil, env.redis, nil, nil, util.NewZapLogger(zap.NewNop()))
+			l := NewReauthLogic(config.ReauthConfig{FreshLoginMinute: 10}, env.tokens, otp, util.NewZapLogger(zap.NewNop()))
+
+			if err := env.redis.Set(ctx, _const.RedisKeyOTPCode.Key(reauthKeyPart(tt.user.ID)), hashOTPCode("123456"), time.Minute); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			proof := tt.proof
+			if tt.session > 0 {
+				sessionUser := tt.user
+				if tt.otherSession {
+					sessionUser = &entity.User{ID: 99}
+				}
+				proof.FamilyID = startSession(t, env, sessionUser, tt.session)
+			}
+
+			err := l.Check(ctx, tt.user, proof)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReauthLogicCodeUsedOnce(t *testing.T) {
+	ctx := context.Background()
+	env := newTenantTestEnv(t, nil)
+	otp := NewOTPLogic(config.OTPConfig{ExpireMinute: 5, MaxAttempts: 3}, nil, env.redis, nil, nil, util.NewZapLogger(zap.NewNop()))
+	l := NewReauthLogic(config.ReauthConfig{}, env.tokens, otp, util.NewZapLogger(zap.NewNop()))
+	user := &entity.User{ID: 1}
+
+	if err := env.redis.Set(ctx, _const.RedisKeyOTPCode.Key(reauthKeyPart(user.ID)), hashOTPCode("123456"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if err := l.Check(ctx, user, model.ReauthProof{Code: "123456"}); err != nil {
+		t.Fatalf("first Check() error = %v", err)
+	}
+	if err := l.Check(ctx, user, model.ReauthProof{Code: "123456"}); err == nil {
+		t.Fatal("second Check() with same code succeeded")
+	}
+}
+
+// startSession signs user in and backdates session by age, returns token family of session
+func startSession(t *testing.T, env *tenantTestEnv, user *entity.User, age time.Duration) string {
+	t.Helper()
+	ctx := context.Background()
+
+	pair, err := env.tokens.GenerateTokenPair(ctx, user, model.SessionClient{})
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	claims, err := env.tokens.ValidateAccessToken(ctx, pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	session, err := env.tokens.GetSession(ctx, claims.FamilyID)
+	if err != nil || session == nil {
+		t.Fatalf("GetSession() = %v, %v", session, err)
+	}
+	session.CreatedAt = time.Now().Add(-age)
+	value, _ := json.Marshal(session)
+	if err := env.redis.Set(ctx, _const.RedisKeySession.Key(claims.FamilyID), string(value), time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	return claims.FamilyID
+}
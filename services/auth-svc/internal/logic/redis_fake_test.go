@@ -0,0 +1,220 @@
+package logic
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// fakeRedisRepo keeps keys in memory and honors expiration against now,
+// which tests can move forward
+type fakeRedisRepo struct {
+	mu      sync.Mutex
+	now     time.Time
+	values  map[string]string
+	sets    map[string]map[string]struct{}
+	zsets   map[string]map[string]float64
+	expires map[string]time.Time
+}
+
+func newFakeRedisRepo() *fakeRedisRepo {
+	return &fakeRedisRepo{
+		now:     time.Now(),
+		values:  make(map[string]string),
+		sets:    make(map[string]map[string]struct{}),
+		zsets:   make(map[string]map[string]float64),
+		expires: make(map[string]time.Time),
+	}
+}
+
+// advance moves clock of fake forward, keys expire accordingly
+func (r *fakeRedisRepo) advance(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = r.now.Add(d)
+}
+
+// expire drops key once its expiration passed, caller holds lock
+func (r *fakeRedisRepo) expire(key string) {
+	if at, ok := r.expires[key]; ok && !r.now.Before(at) {
+		r.delete(key)
+	}
+}
+
+func (r *fakeRedisRepo) delete(key string) {
+	delete(r.values, key)
+	delete(r.sets, key)
+	delete(r.zsets, key)
+	delete(r.expires, key)
+}
+
+func (r *fakeRedisRepo) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delete(key)
+	r.values[key] = value
+	if expiration > 0 {
+		r.expires[key] = r.now.Add(expiration)
+	}
+	return nil
+}
+
+func (r *fakeRedisRepo) Get(ctx context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	return r.values[key], nil
+}
+
+func (r *fakeRedisRepo) GetDel(ctx context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	value := r.values[key]
+	r.delete(key)
+	return value, nil
+}
+
+func (r *fakeRedisRepo) SetIfGreater(ctx context.Context, key string, value int64, expiration time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	if current, err := strconv.ParseInt(r.values[key], 10, 64); err == nil && current >= value {
+		return false, nil
+	}
+	r.delete(key)
+	r.values[key] = strconv.FormatInt(value, 10)
+	r.expires[key] = r.now.Add(expiration)
+	return true, nil
+}
+
+func (r *fakeRedisRepo) Del(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delete(key)
+	return nil
+}
+
+func (r *fakeRedisRepo) Exists(ctx context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	_, isValue := r.values[key]
+	_, isSet := r.sets[key]
+	_, isZSet := r.zsets[key]
+	return isValue || isSet || isZSet, nil
+}
+
+func (r *fakeRedisRepo) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	r.expires[key] = r.now.Add(expiration)
+	return nil
+}
+
+func (r *fakeRedisRepo) Incr(ctx context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	count, _ := strconv.ParseInt(r.values[key], 10, 64)
+	count++
+	r.values[key] = strconv.FormatInt(count, 10)
+	return count, nil
+}
+
+func (r *fakeRedisRepo) TTL(ctx context.Context, key string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	if at, ok := r.expires[key]; ok {
+		return at.Sub(r.now), nil
+	}
+	if _, ok := r.values[key]; ok {
+		return -1, nil
+	}
+	return -2, nil
+}
+
+func (r *fakeRedisRepo) SAdd(ctx context.Context, key string, members ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	if r.sets[key] == nil {
+		r.sets[key] = make(map[string]struct{})
+	}
+	for _, member := range members {
+		r.sets[key][member] = struct{}{}
+	}
+	return nil
+}
+
+func (r *fakeRedisRepo) SMembers(ctx context.Context, key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	members := make([]string, 0, len(r.sets[key]))
+	for member := range r.sets[key] {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (r *fakeRedisRepo) SRem(ctx context.Context, key string, members ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, member := range members {
+		delete(r.sets[key], member)
+	}
+	return nil
+}
+
+func (r *fakeRedisRepo) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	if r.zsets[key] == nil {
+		r.zsets[key] = make(map[string]float64)
+	}
+	r.zsets[key][member] = score
+	return nil
+}
+
+func (r *fakeRedisRepo) ZRemRangeByScore(ctx context.Context, key, min, max string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	from, err := parseScore(min)
+	if err != nil {
+		return err
+	}
+	to, err := parseScore(max)
+	if err != nil {
+		return err
+	}
+	for member, score := range r.zsets[key] {
+		if score >= from && score <= to {
+			delete(r.zsets[key], member)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRedisRepo) ZCard(ctx context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(key)
+	return int64(len(r.zsets[key])), nil
+}
+
+func parseScore(value string) (float64, error) {
+	switch value {
+	case "-inf":
+		return -1 << 62, nil
+	case "+inf":
+		return 1 << 62, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
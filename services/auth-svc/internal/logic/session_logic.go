@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"context"
+
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// SessionLogic lets users see and revoke their login sessions.
+// Session is refresh token family stored by TokenLogic.
+type SessionLogic struct {
+	tokenLogic       *TokenLogic
+	userActivityRepo repository.UserActivityLogRepository
+	logger           util.Logger
+}
+
+// NewSessionLogic creates new SessionLogic instance
+func NewSessionLogic(tokenLogic *TokenLogic, userActivityRepo repository.UserActivityLogRepository, logger util.Logger) *SessionLogic {
+	return &SessionLogic{
+		tokenLogic:       tokenLogic,
+		userActivityRepo: userActivityRepo,
+		logger:           logger,
+	}
+}
+
+// List returns active sessions of user, marking the one of current token
+func (l *SessionLogic) List(ctx context.Context, claims *model.TokenClaims) ([]*model.Session, error) {
+	sessions, err := l.tokenLogic.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == claims.FamilyID
+	}
+
+	return sessions, nil
+}
+
+// Revoke revokes session of user, access tokens of session are rejected right away
+func (l *SessionLogic) Revoke(ctx context.Context, userID uint, sessionID string, req model.SessionActionRequest) error {
+	session, err := l.tokenLogic.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return util.NewError(_const.CodeSessionNotFound.Message())
+	}
+
+	if err := l.tokenLogic.RevokeTokenFamily(ctx, sessionID); err != nil {
+		return err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "session_revoke", "token", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"family_id":   sessionID,
+		"device_name": session.DeviceName,
+	})
+
+	l.logger.Info("Session revoked",
+		util.Int("user_id", int(userID)),
+		util.String("family_id", sessionID),
+	)
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package logic
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// ecP256CoordinateSize is byte length of P-256 curve coordinates in JWK
+const ecP256CoordinateSize = 32
+
+// signingKey is loaded asymmetric signing key with its validity window
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	activateAt time.Time
+	retireAt   time.Time
+}
+
+// retired reports whether key is no longer published nor accepted
+func (k *signingKey) retired(now time.Time) bool {
+	return !k.retireAt.IsZero() && !now.Before(k.retireAt)
+}
+
+// SigningKeyLogic signs and verifies tokens with rotating RS256 / ES256 keys
+// and publishes their public part as JWKS
+type SigningKeyLogic struct {
+	config    config.SigningConfig
+	secretKey []byte
+	keys      []*signingKey
+	logger    util.Logger
+}
+
+// NewSigningKeyLogic loads signing keys, generating missing key files when enabled
+func NewSigningKeyLogic(authConfig config.AuthConfig, logger util.Logger) (*SigningKeyLogic, error) {
+	l := &SigningKeyLogic{
+		config:    authConfig.Signing,
+		secretKey: []byte(authConfig.SecretKey),
+		logger:    logger,
+	}
+
+	seen := make(map[string]bool)
+	for _, keyConfig := range authConfig.Signing.Keys {
+		if keyConfig.KeyID == "" {
+			return nil, fmt.Errorf("signing key without kid")
+		}
+		if seen[keyConfig.KeyID] {
+			return nil, fmt.Errorf("duplicate signing key kid: %s", keyConfig.KeyID)
+		}
+		seen[keyConfig.KeyID] = true
+
+		key, err := l.loadKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key %s: %w", keyConfig.KeyID, err)
+		}
+		l.keys = append(l.keys, key)
+	}
+
+	if len(l.keys) == 0 {
+		logger.Warn("No signing keys configured, tokens are signed with HS256 shared secret")
+	} else if l.activeKey(time.Now()) == nil {
+		logger.Warn("No signing key is active yet")
+	}
+	if len(l.keys) > 0 && l.config.AcceptHS256 {
+		logger.Warn("HS256 tokens signed with shared secret are still accepted, disable acceptHS256 once they expire")
+	}
+
+	return l, nil
+}
+
+// ActiveKeyID returns kid of key currently signing tokens, empty for HS256 shared secret
+func (l *SigningKeyLogic) ActiveKeyID() string {
+	if key := l.activeKey(time.Now()); key != nil {
+		return key.id
+	}
+	return ""
+}
+
+// Sign signs claims with key returned by ActiveKeyID and sets its kid header
+func (l *SigningKeyLogic) Sign(kid string, claims jwt.Claims) (string, error) {
+	if len(l.keys) == 0 {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(l.secretKey)
+	}
+
+	var key *signingKey
+	now := time.Now()
+	for _, k := range l.keys {
+		if k.id == kid && !k.retired(now) {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return "", errors.New("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.privateKey)
+}
+
+// Keyfunc returns verification key for token by its kid and algorithm.
+// HS256 tokens are accepted only without keys or while acceptHS256 is enabled.
+func (l *SigningKeyLogic) Keyfunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(l.keys) == 0 || l.config.AcceptHS256 {
+			return l.secretKey, nil
+		}
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := token.Header["kid"].(string)
+		now := time.Now()
+		for _, key := range l.keys {
+			if key.id == kid && key.method.Alg() == token.Method.Alg() && !key.retired(now) {
+				return key.privateKey.Public(), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+// ValidMethods returns algorithms accepted by Keyfunc
+func (l *SigningKeyLogic) ValidMethods() []string {
+	methods := l.algorithms()
+	if len(l.keys) == 0 || l.config.AcceptHS256 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	return methods
+}
+
+// JWKS returns public keys that are not retired, including keys not activated yet
+func (l *SigningKeyLogic) JWKS() (*model.JWKS, error) {
+	jwks := &model.JWKS{Keys: []model.JWK{}}
+
+	now := time.Now()
+	for _, key := range l.keys {
+		if key.retired(now) {
+			continue
+		}
+
+		jwk := model.JWK{
+			KeyID:     key.id,
+			Use:       "sig",
+			Algorithm: key.method.Alg(),
+		}
+		switch publicKey := key.privateKey.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, ecP256CoordinateSize)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, ecP256CoordinateSize)))
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
+// PublicKeys returns PEM encoded public keys that are not retired
+func (l *SigningKeyLogic) PublicKeys() ([]model.PublicSigningKey, error) {
+	var publicKeys []model.PublicSigningKey
+
+	now := time.Now()
+	for _, key := range l.keys {
+		if key.retired(now) {
+			continue
+		}
+
+		publicKeyPEM, err := util.EncodePublicKeyPEM(key.privateKey.Public())
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, model.PublicSigningKey{
+			KeyID:        key.id,
+			Algorithm:    key.method.Alg(),
+			PublicKeyPEM: string(publicKeyPEM),
+		})
+	}
+
+	return publicKeys, nil
+}
+
+// SharedSecret returns HS256 secret when tokens are signed without asymmetric keys
+func (l *SigningKeyLogic) SharedSecret() (string, bool) {
+	if len(l.keys) > 0 {
+		return "", false
+	}
+	return string(l.secretKey), true
+}
+
+// OpenIDConfiguration returns discovery metadata pointing verifiers to JWKS
+func (l *SigningKeyLogic) OpenIDConfiguration() *model.OpenIDConfiguration {
+	issuer := strings.TrimRight(l.config.IssuerURL, "/")
+
+	return &model.OpenIDConfiguration{
+		Issuer:                           issuer,
+		JWKSURI:                          issuer + "/.well-known/jwks.json",
+		UserInfoEndpoint:                 issuer + "/auth/me",
+		ResponseTypesSupported:           []string{"token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: l.algorithms(),
+		ClaimsSupported: []string{
+			"iss", "sub", "iat", "nbf", "exp", "jti",
+			"user_id", "username", "email", "token_type", "family_id", "kong_key",
+		},
+	}
+}
+
+// JWKSCacheSecond returns how long verifiers may cache JWKS
+func (l *SigningKeyLogic) JWKSCacheSecond() int {
+	return l.config.JWKSCacheSecond
+}
+
+// activeKey returns the most recently activated key that isn't retired
+func (l *SigningKeyLogic) activeKey(now time.Time) *signingKey {
+	var active *signingKey
+	for _, key := range l.keys {
+		if key.activateAt.After(now) || key.retired(now) {
+			continue
+		}
+		if active == nil || key.activateAt.After(active.activateAt) {
+			active = key
+		}
+	}
+	return active
+}
+
+// algorithms returns distinct algorithms of configured keys
+func (l *SigningKeyLogic) algorithms() []string {
+	var algorithms []string
+	seen := make(map[string]bool)
+	for _, key := range l.keys {
+		if alg := key.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			algorithms = append(algorithms, alg)
+		}
+	}
+	return algorithms
+}
+
+// loadKey reads private key from config, file or generates it when allowed
+func (l *SigningKeyLogic) loadKey(keyConfig config.SigningKeyConfig) (*signingKey, error) {
+	var (
+		privateKey crypto.Signer
+		err        error
+	)
+
+	switch {
+	case keyConfig.PrivateKey != "":
+		privateKey, err = util.ParseSigningKeyPEM([]byte(keyConfig.PrivateKey))
+	case keyConfig.PrivateKeyFile != "":
+		privateKey, err = l.readKeyFile(keyConfig)
+	default:
+		err = errors.New("neither privateKey nor privateKeyFile is set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, err := util.SigningKeyAlgorithm(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyConfig.Algorithm != "" && keyConfig.Algorithm != algorithm {
+		return nil, fmt.Errorf("key type is %s but algorithm %s is configured", algorithm, keyConfig.Algorithm)
+	}
+
+	return &signingKey{
+		id:         keyConfig.KeyID,
+		method:     jwt.GetSigningMethod(algorithm),
+		privateKey: privateKey,
+		activateAt: keyConfig.ActivateAt,
+		retireAt:   keyConfig.RetireAt,
+	}, nil
+}
+
+// readKeyFile reads PEM key file and creates it when missing and generateIfMissing is enabled
+func (l *SigningKeyLogic) readKeyFile(keyConfig config.SigningKeyConfig) (crypto.Signer, error) {
+	data, err := os.ReadFile(keyConfig.PrivateKeyFile)
+	if err == nil {
+		return util.ParseSigningKeyPEM(data)
+	}
+	if !errors.Is(err, os.ErrNotExist) || !l.config.GenerateIfMissing {
+		return nil, err
+	}
+
+	privateKey, err := util.GenerateSigningKey(keyConfig.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	data, err = util.EncodeSigningKeyPEM(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(keyConfig.PrivateKeyFile), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyConfig.PrivateKeyFile, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	l.logger.Warn("Generated signing key, don't use generated keys in production",
+		util.String("kid", keyConfig.KeyID),
+		util.String("file", keyConfig.PrivateKeyFile),
+	)
+
+	return privateKey, nil
+}
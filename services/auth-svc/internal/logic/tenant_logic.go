@@ -0,0 +1,603 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	tenantSlugMaxLength  = 63
+	tenantSlugSuffixSize = 6
+	tenantSlugMaxTries   = 5
+)
+
+var tenantSlugRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{1,61}[a-z0-9])$`)
+
+// tenantRoleRank orders tenant roles, higher rank is more privileged
+var tenantRoleRank = map[string]int{
+	_const.TenantRoleOwner:  4,
+	_const.TenantRoleAdmin:  3,
+	_const.TenantRoleMember: 2,
+	_const.TenantRoleViewer: 1,
+}
+
+// TenantLogic contains tenant and tenant membership logic.
+// Admins manage members and viewers, owners manage everyone.
+type TenantLogic struct {
+	config           config.TenantConfig
+	tenantRepo       repository.TenantRepository
+	memberRepo       repository.TenantMemberRepository
+	userRepo         repository.UserRepository
+	userActivityRepo repository.UserActivityLogRepository
+	tokenLogic       *TokenLogic
+	kong             *KongLogic
+	logger           util.Logger
+}
+
+// NewTenantLogic creates new TenantLogic instance
+func NewTenantLogic(
+	config config.TenantConfig,
+	tenantRepo repository.TenantRepository,
+	memberRepo repository.TenantMemberRepository,
+	userRepo repository.UserRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	tokenLogic *TokenLogic,
+	kong *KongLogic,
+	logger util.Logger,
+) *TenantLogic {
+	return &TenantLogic{
+		config:           config,
+		tenantRepo:       tenantRepo,
+		memberRepo:       memberRepo,
+		userRepo:         userRepo,
+		userActivityRepo: userActivityRepo,
+		tokenLogic:       tokenLogic,
+		kong:             kong,
+		logger:           logger,
+	}
+}
+
+// Create creates tenant owned by user. It becomes active tenant of user when user has none.
+func (l *TenantLogic) Create(ctx context.Context, req model.CreateTenantRequest) (*entity.Tenant, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, util.NewError(_const.CodeBadRequest.Message())
+	}
+
+	if l.config.MaxOwnedPerUser > 0 {
+		owned, err := l.memberRepo.CountByUserRole(ctx, req.UserID, _const.TenantRoleOwner)
+		if err != nil {
+			l.logger.Error("Failed to count owned tenants", util.Error(err))
+			return nil, err
+		}
+		if owned >= int64(l.config.MaxOwnedPerUser) {
+			return nil, util.NewError(_const.CodeTenantLimit.Message())
+		}
+	}
+
+	slug, err := l.availableSlug(ctx, name, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := &entity.Tenant{
+		ID:   uuid.New().String(),
+		Name: name,
+		Slug: slug,
+		Plan: "free",
+	}
+	if err := l.tenantRepo.CreateWithMember(ctx, tenant, &entity.TenantMember{
+		UserID: req.UserID,
+		Role:   _const.TenantRoleOwner,
+	}); err != nil {
+		l.logger.Error("Failed to create tenant", util.Error(err))
+		return nil, err
+	}
+
+	user, err := l.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+	} else if user != nil && user.ActiveTenantID == nil {
+		// Included in tokens from next refresh or tenant switch
+		if err := l.userRepo.SetActiveTenant(ctx, req.UserID, &tenant.ID); err != nil {
+			l.logger.Error("Failed to set active tenant", util.Error(err))
+		}
+	}
+
+	l.kong.SyncTenant(ctx, tenant)
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, req.UserID, "tenant_create", "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id": tenant.ID,
+		"slug":      tenant.Slug,
+	})
+
+	l.logger.Info("Tenant created",
+		util.String("tenant_id", tenant.ID),
+		util.Int("user_id", int(req.UserID)),
+	)
+
+	return tenant, nil
+}
+
+// ListMine returns tenants of user with its roles
+func (l *TenantLogic) ListMine(ctx context.Context, userID uint) ([]*model.TenantMembership, error) {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := l.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to list tenant memberships", util.Error(err))
+		return nil, err
+	}
+
+	memberships := make([]*model.TenantMembership, 0, len(members))
+	for _, member := range members {
+		memberships = append(memberships, &model.TenantMembership{
+			Tenant: member.Tenant,
+			Role:   member.Role,
+			Active: user.ActiveTenantID != nil && *user.ActiveTenantID == member.TenantID,
+		})
+	}
+	return memberships, nil
+}
+
+// Get returns tenant with role of user, only members can see tenant
+func (l *TenantLogic) Get(ctx context.Context, userID uint, tenantID string) (*model.TenantMembership, error) {
+	member, err := l.membership(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := l.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant", util.Error(err))
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, util.NewError(_const.CodeTenantNotFound.Message())
+	}
+
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TenantMembership{
+		Tenant: tenant,
+		Role:   member.Role,
+		Active: user.ActiveTenantID != nil && *user.ActiveTenantID == tenantID,
+	}, nil
+}
+
+// ListMembers returns members of tenant, only members can list them
+func (l *TenantLogic) ListMembers(ctx context.Context, userID uint, tenantID string) ([]*model.TenantMember, error) {
+	if _, err := l.membership(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := l.memberRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		l.logger.Error("Failed to list tenant members", util.Error(err))
+		return nil, err
+	}
+
+	result := make([]*model.TenantMember, 0, len(members))
+	for _, member := range members {
+		if member.User == nil {
+			continue
+		}
+		result = append(result, &model.TenantMember{
+			UserID:    member.UserID,
+			Username:  member.User.Username,
+			Email:     member.User.Email,
+			Firstname: member.User.Firstname,
+			Lastname:  member.User.Lastname,
+			Role:      member.Role,
+			JoinedAt:  member.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// UpdateMemberRole changes role of tenant member. Admins can't change admins or owners
+// and can't grant roles above member, tenant always keeps an owner. Sessions of member
+// are revoked so tokens with previous role stop working.
+func (l *TenantLogic) UpdateMemberRole(ctx context.Context, tenantID string, userID uint, req model.TenantMemberRoleRequest) error {
+	if _, ok := tenantRoleRank[req.Role]; !ok {
+		return util.NewError(_const.CodeTenantRoleInvalid.Message())
+	}
+
+	actor, err := l.membership(ctx, tenantID, req.ActorID)
+	if err != nil {
+		return err
+	}
+	target, err := l.targetMember(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !canManageMember(actor, target) || !canGrantRole(actor, req.Role) {
+		return util.NewError(_const.CodeTenantForbidden.Message())
+	}
+	if target.Role == req.Role {
+		return nil
+	}
+
+	if err := l.memberRepo.UpdateRoleKeepingOwner(ctx, tenantID, userID, req.Role); err != nil {
+		if errors.Is(err, repository.ErrLastOwner) {
+			return util.NewError(_const.CodeTenantLastOwner.Message())
+		}
+		l.logger.Error("Failed to update tenant member role", util.Error(err))
+		return err
+	}
+
+	l.revokeMemberTokens(ctx, tenantID, userID)
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, req.ActorID, "tenant_member_role", "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id":     tenantID,
+		"user_id":       userID,
+		"role":          req.Role,
+		"previous_role": target.Role,
+	})
+
+	return nil
+}
+
+// RemoveMember removes user from tenant and revokes its sessions. Members can leave
+// by removing themselves, tenant always keeps an owner.
+func (l *TenantLogic) RemoveMember(ctx context.Context, tenantID string, userID uint, req model.TenantActionRequest) error {
+	actor, err := l.membership(ctx, tenantID, req.ActorID)
+	if err != nil {
+		return err
+	}
+
+	target := actor
+	action := "tenant_leave"
+	if userID != req.ActorID {
+		if target, err = l.targetMember(ctx, tenantID, userID); err != nil {
+			return err
+		}
+		if !canManageMember(actor, target) {
+			return util.NewError(_const.CodeTenantForbidden.Message())
+		}
+		action = "tenant_member_remove"
+	}
+
+	if err := l.memberRepo.DeleteKeepingOwner(ctx, tenantID, userID); err != nil {
+		if errors.Is(err, repository.ErrLastOwner) {
+			return util.NewError(_const.CodeTenantLastOwner.Message())
+		}
+		l.logger.Error("Failed to remove tenant member", util.Error(err))
+		return err
+	}
+
+	// Tokens issued after next login don't carry tenant anymore
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+	} else if user != nil && user.ActiveTenantID != nil && *user.ActiveTenantID == tenantID {
+		if err := l.userRepo.SetActiveTenant(ctx, userID, nil); err != nil {
+			l.logger.Error("Failed to clear active tenant", util.Error(err))
+		}
+	}
+
+	l.revokeMemberTokens(ctx, tenantID, userID)
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, req.ActorID, action, "tenant", req.IPAddress, req.UserAgent, entity.JSONMap{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+		"role":      target.Role,
+	})
+
+	return nil
+}
+
+// Switch makes tenant active tenant of user and reissues tokens of current session
+// with tenant ID and role
+func (l *TenantLogic) Switch(ctx context.Context, claims *model.TokenClaims, tenantID string, client model.SessionClient) (*model.TokenPair, error) {
+	member, err := l.membership(ctx, tenantID, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := l.getUser(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.userRepo.SetActiveTenant(ctx, user.ID, &tenantID); err != nil {
+		l.logger.Error("Failed to set active tenant", util.Error(err))
+		return nil, err
+	}
+	user.ActiveTenantID = &tenantID
+
+	tokens, err := l.tokenLogic.ReissueTokenPair(ctx, user, claims, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, user.ID, "tenant_switch", "tenant", client.IPAddress, client.UserAgent, entity.JSONMap{
+		"tenant_id": tenantID,
+		"role":      member.Role,
+	})
+
+	return tokens, nil
+}
+
+// ReleaseOwnedTenants hands over tenants owned by user before its account is deleted.
+// Tenant with other owners is left as is, otherwise the most privileged, longest
+// standing member becomes owner. Tenants without other members are deleted.
+func (l *TenantLogic) ReleaseOwnedTenants(ctx context.Context, userID uint) error {
+	memberships, err := l.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to list tenant memberships", util.Error(err))
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.Role != _const.TenantRoleOwner {
+			continue
+		}
+		if err := l.releaseTenant(ctx, membership.TenantID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseTenant makes another member owner of tenant or deletes tenant when user is its only member
+func (l *TenantLogic) releaseTenant(ctx context.Context, tenantID string, userID uint) error {
+	members, err := l.memberRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		l.logger.Error("Failed to list tenant members", util.Error(err))
+		return err
+	}
+
+	// Members are ordered by join date, so earliest member wins among equal roles
+	var successor *entity.TenantMember
+	for _, member := range members {
+		if member.UserID == userID {
+			continue
+		}
+		if member.Role == _const.TenantRoleOwner {
+			return nil
+		}
+		if successor == nil || tenantRoleRank[member.Role] > tenantRoleRank[successor.Role] {
+			successor = member
+		}
+	}
+
+	if successor == nil {
+		if err := l.tenantRepo.Delete(ctx, tenantID); err != nil {
+			l.logger.Error("Failed to delete tenant", util.String("tenant_id", tenantID), util.Error(err))
+			return err
+		}
+		l.kong.RemoveTenant(ctx, tenantID)
+
+		l.logger.Info("Tenant of deleted account removed",
+			util.String("tenant_id", tenantID),
+			util.Int("user_id", int(userID)),
+		)
+		return nil
+	}
+
+	if err := l.memberRepo.UpdateRole(ctx, tenantID, successor.UserID, _const.TenantRoleOwner); err != nil {
+		l.logger.Error("Failed to transfer tenant ownership", util.String("tenant_id", tenantID), util.Error(err))
+		return err
+	}
+	l.revokeMemberTokens(ctx, tenantID, successor.UserID)
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, successor.UserID, "tenant_ownership_transfer", "tenant", "", "", entity.JSONMap{
+		"tenant_id":     tenantID,
+		"from_user_id":  userID,
+		"previous_role": successor.Role,
+	})
+
+	return nil
+}
+
+// TenantIDByHost returns ID of tenant requested host belongs to, either <slug>.BaseDomain
+// or custom domain of tenant. Returns empty ID when host isn't tenant's.
+func (l *TenantLogic) TenantIDByHost(ctx context.Context, host string) (string, error) {
+	baseDomain := strings.ToLower(l.config.BaseDomain)
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if baseDomain == "" || host == "" || host == baseDomain {
+		return "", nil
+	}
+
+	var (
+		tenant *entity.Tenant
+		err    error
+	)
+	if slug, ok := strings.CutSuffix(host, "."+baseDomain); ok {
+		if strings.Contains(slug, ".") || !validTenantSlug(slug) {
+			return "", nil
+		}
+		tenant, err = l.tenantRepo.GetBySlug(ctx, slug)
+	} else {
+		tenant, err = l.tenantRepo.GetByCustomDomain(ctx, host)
+	}
+	if err != nil {
+		l.logger.Error("Failed to get tenant by host", util.String("host", host), util.Error(err))
+		return "", err
+	}
+	if tenant == nil {
+		return "", nil
+	}
+	return tenant.ID, nil
+}
+
+// MemberRole returns role of user in tenant, empty role when user isn't a member
+func (l *TenantLogic) MemberRole(ctx context.Context, tenantID string, userID uint) (string, error) {
+	if _, err := uuid.Parse(tenantID); err != nil {
+		return "", nil
+	}
+
+	member, err := l.memberRepo.Get(ctx, tenantID, userID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant membership", util.Error(err))
+		return "", err
+	}
+	if member == nil {
+		return "", nil
+	}
+	return member.Role, nil
+}
+
+// membership returns membership of user in tenant, CodeTenantNotFound when user isn't
+// a member so tenants of others can't be discovered
+func (l *TenantLogic) membership(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error) {
+	if _, err := uuid.Parse(tenantID); err != nil {
+		return nil, util.NewError(_const.CodeTenantNotFound.Message())
+	}
+
+	member, err := l.memberRepo.Get(ctx, tenantID, userID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant membership", util.Error(err))
+		return nil, err
+	}
+	if member == nil {
+		return nil, util.NewError(_const.CodeTenantNotFound.Message())
+	}
+	return member, nil
+}
+
+// targetMember returns membership of user acted on, CodeUserNotInTenant when missing
+func (l *TenantLogic) targetMember(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error) {
+	member, err := l.memberRepo.Get(ctx, tenantID, userID)
+	if err != nil {
+		l.logger.Error("Failed to get tenant membership", util.Error(err))
+		return nil, err
+	}
+	if member == nil {
+		return nil, util.NewError(_const.CodeUserNotInTenant.Message())
+	}
+	return member, nil
+}
+
+// revokeMemberTokens revokes token families of user after its membership changed, so
+// tenant role in issued tokens can't outlive it. Change itself is already stored,
+// failures are only logged.
+func (l *TenantLogic) revokeMemberTokens(ctx context.Context, tenantID string, userID uint) {
+	if err := l.tokenLogic.RevokeAllUserTokens(ctx, userID); err != nil {
+		l.logger.Error("Failed to revoke tokens of tenant member",
+			util.String("tenant_id", tenantID),
+			util.Int("user_id", int(userID)),
+			util.Error(err),
+		)
+	}
+}
+
+// getUser gets user by ID, returns CodeUserNotFound when missing
+func (l *TenantLogic) getUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+	return user, nil
+}
+
+// availableSlug validates requested slug or derives unused slug from tenant name
+func (l *TenantLogic) availableSlug(ctx context.Context, name, requested string) (string, error) {
+	if requested != "" {
+		slug := strings.ToLower(strings.TrimSpace(requested))
+		if !validTenantSlug(slug) {
+			return "", util.NewError(_const.CodeTenantSlugInvalid.Message())
+		}
+		existing, err := l.tenantRepo.GetBySlug(ctx, slug)
+		if err != nil {
+			l.logger.Error("Failed to check existing tenant slug", util.Error(err))
+			return "", err
+		}
+		if existing != nil {
+			return "", util.NewError(_const.CodeTenantExisted.Message())
+		}
+		return slug, nil
+	}
+
+	base := slugify(name)
+	if maxLength := tenantSlugMaxLength - tenantSlugSuffixSize - 1; len(base) > maxLength {
+		base = strings.TrimRight(base[:maxLength], "-")
+	}
+
+	candidate := base
+	for i := 0; i < tenantSlugMaxTries; i++ {
+		if validTenantSlug(candidate) {
+			existing, err := l.tenantRepo.GetBySlug(ctx, candidate)
+			if err != nil {
+				l.logger.Error("Failed to check existing tenant slug", util.Error(err))
+				return "", err
+			}
+			if existing == nil {
+				return candidate, nil
+			}
+		}
+		candidate = strings.TrimLeft(base+"-"+util.GenerateRandomString(tenantSlugSuffixSize), "-")
+	}
+
+	return "", util.NewError(_const.CodeTenantExisted.Message())
+}
+
+// validTenantSlug reports whether slug can be used, reserved names collide with service subdomains
+func validTenantSlug(slug string) bool {
+	return tenantSlugRegexp.MatchString(slug) && !slices.Contains(_const.ReservedUsernames, slug)
+}
+
+// slugify lowercases name and replaces runs of other chars with single hyphen
+func slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteRune('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// canManageMember reports whether actor can change or remove target member.
+// Owners manage everyone, admins manage only lower roles.
+func canManageMember(actor, target *entity.TenantMember) bool {
+	if actor.Role == _const.TenantRoleOwner {
+		return true
+	}
+	return tenantRoleRank[actor.Role] >= tenantRoleRank[_const.TenantRoleAdmin] &&
+		tenantRoleRank[target.Role] < tenantRoleRank[actor.Role]
+}
+
+// canGrantRole reports whether actor can grant role. Owners grant any role, admins lower roles.
+func canGrantRole(actor *entity.TenantMember, role string) bool {
+	if actor.Role == _const.TenantRoleOwner {
+		return true
+	}
+	return tenantRoleRank[actor.Role] >= tenantRoleRank[_const.TenantRoleAdmin] &&
+		tenantRoleRank[role] < tenantRoleRank[actor.Role]
+}
@@ -0,0 +1,452 @@
+package logic
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// tenantTestMemberRepo keeps memberships in join order
+type tenantTestMemberRepo struct {
+	repository.TenantMemberRepository
+	members []*entity.TenantMember
+}
+
+func (r *tenantTestMemberRepo) Get(ctx context.Context, tenantID string, userID uint) (*entity.TenantMember, error) {
+	for _, member := range r.members {
+		if member.TenantID == tenantID && member.UserID == userID {
+			copied := *member
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *tenantTestMemberRepo) ListByUser(ctx context.Context, userID uint) ([]*entity.TenantMember, error) {
+	var members []*entity.TenantMember
+	for _, member := range r.members {
+		if member.UserID == userID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (r *tenantTestMemberRepo) ListByTenant(ctx context.Context, tenantID string) ([]*entity.TenantMember, error) {
+	var members []*entity.TenantMember
+	for _, member := range r.members {
+		if member.TenantID == tenantID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (r *tenantTestMemberRepo) CountByRole(ctx context.Context, tenantID, role string) (int64, error) {
+	var count int64
+	for _, member := range r.members {
+		if member.TenantID == tenantID && member.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *tenantTestMemberRepo) UpdateRole(ctx context.Context, tenantID string, userID uint, role string) error {
+	for _, member := range r.members {
+		if member.TenantID == tenantID && member.UserID == userID {
+			member.Role = role
+		}
+	}
+	return nil
+}
+
+func (r *tenantTestMemberRepo) Delete(ctx context.Context, tenantID string, userID uint) error {
+	members := r.members[:0]
+	for _, member := range r.members {
+		if member.TenantID != tenantID || member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+	r.members = members
+	return nil
+}
+
+func (r *tenantTestMemberRepo) UpdateRoleKeepingOwner(ctx context.Context, tenantID string, userID uint, role string) error {
+	if role != _const.TenantRoleOwner && r.lastOwner(tenantID, userID) {
+		return repository.ErrLastOwner
+	}
+	return r.UpdateRole(ctx, tenantID, userID, role)
+}
+
+func (r *tenantTestMemberRepo) DeleteKeepingOwner(ctx context.Context, tenantID string, userID uint) error {
+	if r.lastOwner(tenantID, userID) {
+		return repository.ErrLastOwner
+	}
+	return r.Delete(ctx, tenantID, userID)
+}
+
+// lastOwner reports whether user is the only owner of tenant
+func (r *tenantTestMemberRepo) lastOwner(tenantID string, userID uint) bool {
+	member, _ := r.Get(context.Background(), tenantID, userID)
+	if member == nil || member.Role != _const.TenantRoleOwner {
+		return false
+	}
+	owners, _ := r.CountByRole(context.Background(), tenantID, _const.TenantRoleOwner)
+	return owners <= 1
+}
+
+// roles returns "tenant/user=role" of memberships
+func (r *tenantTestMemberRepo) roles() []string {
+	var roles []string
+	for _, member := range r.members {
+		roles = append(roles, member.TenantID+"/"+strconv.Itoa(int(member.UserID))+"="+member.Role)
+	}
+	return roles
+}
+
+// tenantTestTenantRepo records deleted tenants and drops their memberships like cascade does
+type tenantTestTenantRepo struct {
+	repository.TenantRepository
+	members *tenantTestMemberRepo
+	deleted []string
+}
+
+func (r *tenantTestTenantRepo) Delete(ctx context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	members := r.members.members[:0]
+	for _, member := range r.members.members {
+		if member.TenantID != id {
+			members = append(members, member)
+		}
+	}
+	r.members.members = members
+	return nil
+}
+
+type tenantTestUserRepo struct {
+	repository.UserRepository
+}
+
+func (r *tenantTestUserRepo) GetByID(ctx context.Context, id uint) (*entity.User, error) {
+	return &entity.User{ID: id, IsActive: true}, nil
+}
+
+type tenantTestActivityRepo struct {
+	repository.UserActivityLogRepository
+	actions []string
+}
+
+func (r *tenantTestActivityRepo) LogActivity(ctx context.Context, userID uint, action, resource, ipAddress, userAgent string, metadata entity.JSONMap) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+type tenantTestEnv struct {
+	logic      *TenantLogic
+	tokens     *TokenLogic
+	redis      *fakeRedisRepo
+	members    *tenantTestMemberRepo
+	tenants    *tenantTestTenantRepo
+	activities *tenantTestActivityRepo
+}
+
+func newTenantTestEnv(t *testing.T, members []*entity.TenantMember) *tenantTestEnv {
+	t.Helper()
+	logger := util.NewZapLogger(zap.NewNop())
+	authConfig := config.AuthConfig{
+		SecretKey:                "test-secret",
+		AccessTokenExpireMinute:  15,
+		RefreshTokenExpireMinute: 60,
+	}
+
+	signingKeys, err := NewSigningKeyLogic(authConfig, logger)
+	if err != nil {
+		t.Fatalf("NewSigningKeyLogic() error = %v", err)
+	}
+
+	env := &tenantTestEnv{
+		redis:      newFakeRedisRepo(),
+		members:    &tenantTestMemberRepo{members: members},
+		activities: &tenantTestActivityRepo{},
+	}
+	env.tenants = &tenantTestTenantRepo{members: env.members}
+	kong := newTestKongLogic(t, false, nil, nil, nil)
+	env.tokens = NewTokenLogic(authConfig, signingKeys, kong, env.members, env.redis, logger)
+	env.logic = NewTenantLogic(config.TenantConfig{}, env.tenants, env.members, &tenantTestUserRepo{}, env.activities, env.tokens, kong, logger)
+	return env
+}
+
+// login starts new session of user
+func (e *tenantTestEnv) login(t *testing.T, userID uint) {
+	t.Helper()
+	if _, err := e.tokens.GenerateTokenPair(context.Background(), &entity.User{ID: userID}, model.SessionClient{}); err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+}
+
+func (e *tenantTestEnv) sessions(t *testing.T, userID uint) int {
+	t.Helper()
+	sessions, err := e.tokens.ListSessions(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	return len(sessions)
+}
+
+func TestCanManageMember(t *testing.T) {
+	roles := []string{_const.TenantRoleOwner, _const.TenantRoleAdmin, _const.TenantRoleMember, _const.TenantRoleViewer}
+
+	// want[actor][target]
+	want := map[string][]bool{
+		_const.TenantRoleOwner:  {true, true, true, true},
+		_const.TenantRoleAdmin:  {false, false, true, true},
+		_const.TenantRoleMember: {false, false, false, false},
+		_const.TenantRoleViewer: {false, false, false, false},
+	}
+
+	for _, actorRole := range roles {
+		for i, targetRole := range roles {
+			t.Run(actorRole+" manages "+targetRole, func(t *testing.T) {
+				actor := &entity.TenantMember{Role: actorRole}
+				target := &entity.TenantMember{Role: targetRole}
+				if got := canManageMember(actor, target); got != want[actorRole][i] {
+					t.Errorf("canManageMember() = %v, want %v", got, want[actorRole][i])
+				}
+			})
+		}
+	}
+}
+
+func TestCanGrantRole(t *testing.T) {
+	tests := []struct {
+		actor string
+		role  string
+		want  bool
+	}{
+		{actor: _const.TenantRoleOwner, role: _const.TenantRoleOwner, want: true},
+		{actor: _const.TenantRoleOwner, role: _const.TenantRoleViewer, want: true},
+		{actor: _const.TenantRoleAdmin, role: _const.TenantRoleOwner, want: false},
+		{actor: _const.TenantRoleAdmin, role: _const.TenantRoleAdmin, want: false},
+		{actor: _const.TenantRoleAdmin, role: _const.TenantRoleMember, want: true},
+		{actor: _const.TenantRoleAdmin, role: _const.TenantRoleViewer, want: true},
+		{actor: _const.TenantRoleMember, role: _const.TenantRoleViewer, want: false},
+		{actor: _const.TenantRoleViewer, role: _const.TenantRoleViewer, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actor+" grants "+tt.role, func(t *testing.T) {
+			if got := canGrantRole(&entity.TenantMember{Role: tt.actor}, tt.role); got != tt.want {
+				t.Errorf("canGrantRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantLogicMembershipChangeRevokesTokens(t *testing.T) {
+	const tenantID = "6f1c2b8e-4d3a-4f5e-9a7b-1c2d3e4f5a6b"
+
+	tests := []struct {
+		name   string
+		change func(l *TenantLogic) error
+	}{
+		{
+			name: "role change",
+			change: func(l *TenantLogic) error {
+				return l.UpdateMemberRole(context.Background(), tenantID, 2, model.TenantMemberRoleRequest{ActorID: 1, Role: _const.TenantRoleViewer})
+			},
+		},
+		{
+			name: "removal",
+			change: func(l *TenantLogic) error {
+				return l.RemoveMember(context.Background(), tenantID, 2, model.TenantActionRequest{ActorID: 1})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTenantTestEnv(t, []*entity.TenantMember{
+				{TenantID: tenantID, UserID: 1, Role: _const.TenantRoleOwner},
+				{TenantID: tenantID, UserID: 2, Role: _const.TenantRoleAdmin},
+			})
+			env.login(t, 1)
+			env.login(t, 2)
+			env.login(t, 2)
+
+			if err := tt.change(env.logic); err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+			if got := env.sessions(t, 2); got != 0 {
+				t.Errorf("sessions of target = %d, want 0", got)
+			}
+			if got := env.sessions(t, 1); got != 1 {
+				t.Errorf("sessions of actor = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestTenantLogicKeepsLastOwner(t *testing.T) {
+	const tenantID = "6f1c2b8e-4d3a-4f5e-9a7b-1c2d3e4f5a6b"
+
+	tests := []struct {
+		name   string
+		owners []uint
+		change func(l *TenantLogic) error
+		want   []string
+	}{
+		{
+			name:   "last owner can't step down",
+			owners: []uint{1},
+			change: func(l *TenantLogic) error {
+				return l.UpdateMemberRole(context.Background(), tenantID, 1, model.TenantMemberRoleRequest{ActorID: 1, Role: _const.TenantRoleAdmin})
+			},
+			want: []string{tenantID + "/1=owner"},
+		},
+		{
+			name:   "last owner can't leave",
+			owners: []uint{1},
+			change: func(l *TenantLogic) error {
+				return l.RemoveMember(context.Background(), tenantID, 1, model.TenantActionRequest{ActorID: 1})
+			},
+			want: []string{tenantID + "/1=owner"},
+		},
+		{
+			name:   "owner steps down while other owner remains",
+			owners: []uint{1, 2},
+			change: func(l *TenantLogic) error {
+				return l.UpdateMemberRole(context.Background(), tenantID, 2, model.TenantMemberRoleRequest{ActorID: 1, Role: _const.TenantRoleAdmin})
+			},
+			want: []string{tenantID + "/1=owner", tenantID + "/2=admin"},
+		},
+		{
+			name:   "owner removed while other owner remains",
+			owners: []uint{1, 2},
+			change: func(l *TenantLogic) error {
+				return l.RemoveMember(context.Background(), tenantID, 2, model.TenantActionRequest{ActorID: 1})
+			},
+			want: []string{tenantID + "/1=owner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var members []*entity.TenantMember
+			for _, owner := range tt.owners {
+				members = append(members, &entity.TenantMember{TenantID: tenantID, UserID: owner, Role: _const.TenantRoleOwner})
+			}
+			env := newTenantTestEnv(t, members)
+
+			err := tt.change(env.logic)
+			if len(tt.owners) == 1 {
+				if err == nil || err.Error() != _const.CodeTenantLastOwner.Message() {
+					t.Fatalf("change error = %v, want %s", err, _const.CodeTenantLastOwner.Message())
+				}
+			} else if err != nil {
+				t.Fatalf("change error = %v", err)
+			}
+			if got := env.members.roles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("roles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantLogicReleaseOwnedTenants(t *testing.T) {
+	tests := []struct {
+		name        string
+		members     []*entity.TenantMember
+		wantRoles   []string
+		wantDeleted []string
+		wantRevoked []uint
+	}{
+		{
+			name: "other owner keeps tenant",
+			members: []*entity.TenantMember{
+				{TenantID: "t1", UserID: 1, Role: _const.TenantRoleOwner},
+				{TenantID: "t1", UserID: 2, Role: _const.TenantRoleAdmin},
+				{TenantID: "t1", UserID: 3, Role: _const.TenantRoleOwner},
+			},
+			wantRoles: []string{"t1/1=owner", "t1/2=admin", "t1/3=owner"},
+		},
+		{
+			name: "most privileged member becomes owner",
+			members: []*entity.TenantMember{
+				{TenantID: "t1", UserID: 1, Role: _const.TenantRoleOwner},
+				{TenantID: "t1", UserID: 2, Role: _const.TenantRoleMember},
+				{TenantID: "t1", UserID: 3, Role: _const.TenantRoleAdmin},
+				{TenantID: "t1", UserID: 4, Role: _const.TenantRoleAdmin},
+			},
+			wantRoles:   []string{"t1/1=owner", "t1/2=member", "t1/3=owner", "t1/4=admin"},
+			wantRevoked: []uint{3},
+		},
+		{
+			name: "viewer inherits when alone",
+			members: []*entity.TenantMember{
+				{TenantID: "t1", UserID: 1, Role: _const.TenantRoleOwner},
+				{TenantID: "t1", UserID: 2, Role: _const.TenantRoleViewer},
+			},
+			wantRoles:   []string{"t1/1=owner", "t1/2=owner"},
+			wantRevoked: []uint{2},
+		},
+		{
+			name: "tenant without other members is deleted",
+			members: []*entity.TenantMember{
+				{TenantID: "t1", UserID: 1, Role: _const.TenantRoleOwner},
+				{TenantID: "t2", UserID: 1, Role: _const.TenantRoleMember},
+				{TenantID: "t2", UserID: 2, Role: _const.TenantRoleOwner},
+			},
+			wantRoles:   []string{"t2/1=member", "t2/2=owner"},
+			wantDeleted: []string{"t1"},
+		},
+		{
+			name: "tenants where user isn't owner are left alone",
+			members: []*entity.TenantMember{
+				{TenantID: "t1", UserID: 1, Role: _const.TenantRoleAdmin},
+				{TenantID: "t1", UserID: 2, Role: _const.TenantRoleOwner},
+			},
+			wantRoles: []string{"t1/1=admin", "t1/2=owner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTenantTestEnv(t, tt.members)
+			for userID := uint(2); userID <= 4; userID++ {
+				env.login(t, userID)
+			}
+
+			if err := env.logic.ReleaseOwnedTenants(context.Background(), 1); err != nil {
+				t.Fatalf("ReleaseOwnedTenants() error = %v", err)
+			}
+
+			if got := env.members.roles(); !reflect.DeepEqual(got, tt.wantRoles) {
+				t.Errorf("memberships = %v, want %v", got, tt.wantRoles)
+			}
+			if !reflect.DeepEqual(env.tenants.deleted, tt.wantDeleted) {
+				t.Errorf("deleted tenants = %v, want %v", env.tenants.deleted, tt.wantDeleted)
+			}
+			for userID := uint(2); userID <= 4; userID++ {
+				want := 1
+				for _, revoked := range tt.wantRevoked {
+					if revoked == userID {
+						want = 0
+					}
+				}
+				if got := env.sessions(t, userID); got != want {
+					t.Errorf("sessions of user %d = %d, want %d", userID, got, want)
+				}
+			}
+		})
+	}
+}
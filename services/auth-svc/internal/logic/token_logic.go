@@ -0,0 +1,501 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// TokenLogic contains business logic for issuing and verifying JWT tokens
+type TokenLogic struct {
+	config      config.AuthConfig
+	signingKeys *SigningKeyLogic
+	kong        *KongLogic
+	memberRepo  repository.TenantMemberRepository
+	redisRepo   repository.RedisRepository
+	logger      util.Logger
+}
+
+// NewTokenLogic creates new TokenLogic instance
+func NewTokenLogic(config config.AuthConfig, signingKeys *SigningKeyLogic, kong *KongLogic, memberRepo repository.TenantMemberRepository, redisRepo repository.RedisRepository, logger util.Logger) *TokenLogic {
+	return &TokenLogic{
+		config:      config,
+		signingKeys: signingKeys,
+		kong:        kong,
+		memberRepo:  memberRepo,
+		redisRepo:   redisRepo,
+		logger:      logger,
+	}
+}
+
+// GenerateTokenPair issues access and refresh tokens for user in a new token family,
+// which starts a new session of client
+func (l *TokenLogic) GenerateTokenPair(ctx context.Context, user *entity.User, client model.SessionClient) (*model.TokenPair, error) {
+	familyID := uuid.New().String()
+
+	tokens, err := l.issueTokenPair(ctx, user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.saveSession(ctx, user.ID, familyID, client)
+
+	return tokens, nil
+}
+
+// RotateTokenPair issues new access and refresh tokens within an existing token family
+// and updates last seen time of its session
+func (l *TokenLogic) RotateTokenPair(ctx context.Context, user *entity.User, familyID string, client model.SessionClient) (*model.TokenPair, error) {
+	tokens, err := l.issueTokenPair(ctx, user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.saveSession(ctx, user.ID, familyID, client)
+
+	return tokens, nil
+}
+
+// ReissueTokenPair replaces tokens of current session after claims of user changed.
+// Presented access token and active refresh token of its family stop working.
+func (l *TokenLogic) ReissueTokenPair(ctx context.Context, user *entity.User, claims *model.TokenClaims, client model.SessionClient) (*model.TokenPair, error) {
+	activeID, err := l.redisRepo.GetDel(ctx, _const.RedisKeyRefreshFamily.Key(claims.FamilyID))
+	if err != nil {
+		l.logger.Error("Failed to get refresh token family", util.Error(err))
+		return nil, err
+	}
+	if activeID == "" {
+		return nil, util.NewError(_const.CodeInvalidToken.Message())
+	}
+
+	if err := l.redisRepo.Del(ctx, _const.RedisKeyRefreshToken.Key(activeID)); err != nil {
+		l.logger.Error("Failed to revoke refresh token", util.Error(err))
+		return nil, err
+	}
+	if err := l.RevokeAccessToken(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return l.RotateTokenPair(ctx, user, claims.FamilyID, client)
+}
+
+// ListSessions returns active sessions of user, most recently seen first.
+// Families whose refresh token expired or was revoked are dropped from user index.
+func (l *TokenLogic) ListSessions(ctx context.Context, userID uint) ([]*model.Session, error) {
+	userKey := _const.RedisKeyUserFamilies.Key(strconv.FormatUint(uint64(userID), 10))
+
+	familyIDs, err := l.redisRepo.SMembers(ctx, userKey)
+	if err != nil {
+		l.logger.Error("Failed to get user token families", util.Error(err))
+		return nil, err
+	}
+
+	sessions := make([]*model.Session, 0, len(familyIDs))
+	var stale []string
+	for _, familyID := range familyIDs {
+		session, err := l.GetSession(ctx, familyID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.UserID != userID {
+			stale = append(stale, familyID)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(stale) > 0 {
+		if err := l.redisRepo.SRem(ctx, userKey, stale...); err != nil {
+			l.logger.Error("Failed to drop stale user token families", util.Error(err))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// GetSession returns session of token family, nil when it expired or was revoked
+func (l *TokenLogic) GetSession(ctx context.Context, familyID string) (*model.Session, error) {
+	// Family without active refresh token can't be used anymore
+	activeID, err := l.redisRepo.Get(ctx, _const.RedisKeyRefreshFamily.Key(familyID))
+	if err != nil {
+		l.logger.Error("Failed to get refresh token family", util.Error(err))
+		return nil, err
+	}
+	if activeID == "" {
+		return nil, nil
+	}
+
+	value, err := l.redisRepo.Get(ctx, _const.RedisKeySession.Key(familyID))
+	if err != nil {
+		l.logger.Error("Failed to get session", util.Error(err))
+		return nil, err
+	}
+
+	var session model.Session
+	if value == "" || json.Unmarshal([]byte(value), &session) != nil {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// ConsumeRefreshToken marks refresh token as used so it can't be presented again.
+// Presenting an already rotated token revokes the whole family and returns ErrorRefreshTokenReuse.
+func (l *TokenLogic) ConsumeRefreshToken(ctx context.Context, claims *model.TokenClaims) error {
+	userID, err := l.redisRepo.GetDel(ctx, _const.RedisKeyRefreshToken.Key(claims.ID))
+	if err != nil {
+		l.logger.Error("Failed to consume refresh token", util.Error(err))
+		return err
+	}
+
+	if userID == "" {
+		familyID, err := l.redisRepo.Get(ctx, _const.RedisKeyRefreshUsed.Key(claims.ID))
+		if err != nil {
+			l.logger.Error("Failed to check used refresh token", util.Error(err))
+			return err
+		}
+		if familyID == "" {
+			return util.NewError(_const.CodeInvalidToken.Message())
+		}
+
+		l.logger.Warn("Refresh token reuse detected, revoking token family",
+			util.Int("user_id", int(claims.UserID)),
+			util.String("family_id", familyID),
+		)
+		if err := l.RevokeTokenFamily(ctx, familyID); err != nil {
+			return err
+		}
+		return _const.ErrorRefreshTokenReuse
+	}
+
+	// Remember used token until its original expiry to detect replay
+	usedTTL := time.Until(claims.ExpiresAt.Time)
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyRefreshUsed.Key(claims.ID), claims.FamilyID, usedTTL); err != nil {
+		l.logger.Error("Failed to mark refresh token as used", util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RevokeTokenFamily revokes the active refresh token of a token family
+// and every access token issued within it
+func (l *TokenLogic) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	// Access tokens of revoked family are rejected until they expire
+	accessTTL := time.Duration(l.config.AccessTokenExpireMinute) * time.Minute
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyRevokedFamily.Key(familyID), "1", accessTTL); err != nil {
+		l.logger.Error("Failed to mark token family as revoked", util.Error(err))
+		return err
+	}
+
+	if err := l.redisRepo.Del(ctx, _const.RedisKeySession.Key(familyID)); err != nil {
+		l.logger.Error("Failed to delete session", util.Error(err))
+		return err
+	}
+
+	familyKey := _const.RedisKeyRefreshFamily.Key(familyID)
+
+	activeID, err := l.redisRepo.GetDel(ctx, familyKey)
+	if err != nil {
+		l.logger.Error("Failed to get refresh token family", util.Error(err))
+		return err
+	}
+	if activeID == "" {
+		return nil
+	}
+
+	if err := l.redisRepo.Del(ctx, _const.RedisKeyRefreshToken.Key(activeID)); err != nil {
+		l.logger.Error("Failed to revoke refresh token", util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllUserTokens revokes every token family issued to user
+func (l *TokenLogic) RevokeAllUserTokens(ctx context.Context, userID uint) error {
+	userKey := _const.RedisKeyUserFamilies.Key(strconv.FormatUint(uint64(userID), 10))
+
+	familyIDs, err := l.redisRepo.SMembers(ctx, userKey)
+	if err != nil {
+		l.logger.Error("Failed to get user token families", util.Error(err))
+		return err
+	}
+
+	for _, familyID := range familyIDs {
+		if err := l.RevokeTokenFamily(ctx, familyID); err != nil {
+			return err
+		}
+	}
+
+	if err := l.redisRepo.Del(ctx, userKey); err != nil {
+		l.logger.Error("Failed to delete user token families", util.Error(err))
+		return err
+	}
+
+	l.logger.Info("Revoked all user tokens",
+		util.Int("user_id", int(userID)),
+		util.Int("families", len(familyIDs)),
+	)
+
+	return nil
+}
+
+// RevokeOtherUserTokens revokes every token family of user except keepFamilyID,
+// so session that made a change stays signed in
+func (l *TokenLogic) RevokeOtherUserTokens(ctx context.Context, userID uint, keepFamilyID string) error {
+	userKey := _const.RedisKeyUserFamilies.Key(strconv.FormatUint(uint64(userID), 10))
+
+	familyIDs, err := l.redisRepo.SMembers(ctx, userKey)
+	if err != nil {
+		l.logger.Error("Failed to get user token families", util.Error(err))
+		return err
+	}
+
+	var revoked []string
+	for _, familyID := range familyIDs {
+		if familyID == keepFamilyID {
+			continue
+		}
+		if err := l.RevokeTokenFamily(ctx, familyID); err != nil {
+			return err
+		}
+		revoked = append(revoked, familyID)
+	}
+
+	if len(revoked) > 0 {
+		if err := l.redisRepo.SRem(ctx, userKey, revoked...); err != nil {
+			l.logger.Error("Failed to drop revoked user token families", util.Error(err))
+			return err
+		}
+	}
+
+	l.logger.Info("Revoked other user tokens",
+		util.Int("user_id", int(userID)),
+		util.Int("families", len(revoked)),
+	)
+
+	return nil
+}
+
+// RevokeAccessToken adds access token to denylist until it expires
+func (l *TokenLogic) RevokeAccessToken(ctx context.Context, claims *model.TokenClaims) error {
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyTokenDenylist.Key(claims.ID), "1", ttl); err != nil {
+		l.logger.Error("Failed to add access token to denylist", util.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ValidateAccessToken verifies access token and checks it hasn't been revoked
+func (l *TokenLogic) ValidateAccessToken(ctx context.Context, tokenString string) (*model.TokenClaims, error) {
+	claims, err := l.ParseToken(tokenString, _const.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := l.redisRepo.Exists(ctx, _const.RedisKeyTokenDenylist.Key(claims.ID))
+	if err != nil {
+		l.logger.Error("Failed to check token denylist", util.Error(err))
+		return nil, err
+	}
+	if denied {
+		return nil, util.NewError(_const.CodeInvalidToken.Message())
+	}
+
+	revoked, err := l.redisRepo.Exists(ctx, _const.RedisKeyRevokedFamily.Key(claims.FamilyID))
+	if err != nil {
+		l.logger.Error("Failed to check revoked token family", util.Error(err))
+		return nil, err
+	}
+	if revoked {
+		return nil, util.NewError(_const.CodeInvalidToken.Message())
+	}
+
+	return claims, nil
+}
+
+// issueTokenPair signs access and refresh tokens and stores refresh token state in Redis
+func (l *TokenLogic) issueTokenPair(ctx context.Context, user *entity.User, familyID string) (*model.TokenPair, error) {
+	accessTTL := time.Duration(l.config.AccessTokenExpireMinute) * time.Minute
+	refreshTTL := time.Duration(l.config.RefreshTokenExpireMinute) * time.Minute
+
+	tenant, err := l.activeTenant(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, _, err := l.signToken(user, tenant, _const.TokenTypeAccess, familyID, accessTTL)
+	if err != nil {
+		l.logger.Error("Failed to sign access token", util.Error(err))
+		return nil, err
+	}
+
+	refreshToken, refreshID, err := l.signToken(user, tenant, _const.TokenTypeRefresh, familyID, refreshTTL)
+	if err != nil {
+		l.logger.Error("Failed to sign refresh token", util.Error(err))
+		return nil, err
+	}
+
+	// Store refresh token in Redis so it can be revoked later
+	key := _const.RedisKeyRefreshToken.Key(refreshID)
+	if err := l.redisRepo.Set(ctx, key, strconv.FormatUint(uint64(user.ID), 10), refreshTTL); err != nil {
+		l.logger.Error("Failed to store refresh token in Redis", util.Error(err))
+		return nil, err
+	}
+
+	// Point token family to its current refresh token
+	familyKey := _const.RedisKeyRefreshFamily.Key(familyID)
+	if err := l.redisRepo.Set(ctx, familyKey, refreshID, refreshTTL); err != nil {
+		l.logger.Error("Failed to store refresh token family in Redis", util.Error(err))
+		return nil, err
+	}
+
+	// Track token families per user so all of them can be revoked at once
+	userKey := _const.RedisKeyUserFamilies.Key(strconv.FormatUint(uint64(user.ID), 10))
+	if err := l.redisRepo.SAdd(ctx, userKey, familyID); err != nil {
+		l.logger.Error("Failed to track user token family in Redis", util.Error(err))
+		return nil, err
+	}
+	if err := l.redisRepo.Expire(ctx, userKey, refreshTTL); err != nil {
+		l.logger.Error("Failed to set expiration for user token families", util.Error(err))
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    _const.TokenSchemeBearer,
+		ExpiresIn:    int64(accessTTL.Seconds()),
+	}, nil
+}
+
+// activeTenant returns membership of user in its active tenant, nil when there is none
+// or user was removed from it
+func (l *TokenLogic) activeTenant(ctx context.Context, user *entity.User) (*entity.TenantMember, error) {
+	if user.ActiveTenantID == nil {
+		return nil, nil
+	}
+
+	member, err := l.memberRepo.Get(ctx, *user.ActiveTenantID, user.ID)
+	if err != nil {
+		l.logger.Error("Failed to get active tenant membership", util.Error(err))
+		return nil, err
+	}
+	return member, nil
+}
+
+// saveSession stores session of token family with client it was last used from.
+// Session only describes token family, so failures are logged without failing login.
+func (l *TokenLogic) saveSession(ctx context.Context, userID uint, familyID string, client model.SessionClient) {
+	key := _const.RedisKeySession.Key(familyID)
+	now := time.Now()
+
+	session := &model.Session{
+		ID:        familyID,
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	if value, err := l.redisRepo.Get(ctx, key); err != nil {
+		l.logger.Error("Failed to get session", util.Error(err))
+	} else if value != "" {
+		_ = json.Unmarshal([]byte(value), session)
+	}
+
+	session.DeviceName = util.DeviceName(client.UserAgent)
+	session.UserAgent = client.UserAgent
+	session.IPAddress = client.IPAddress
+	session.LastSeenAt = now
+
+	value, err := json.Marshal(session)
+	if err == nil {
+		// Session lives as long as refresh token of its family
+		err = l.redisRepo.Set(ctx, key, string(value), time.Duration(l.config.RefreshTokenExpireMinute)*time.Minute)
+	}
+	if err != nil {
+		l.logger.Error("Failed to store session", util.String("family_id", familyID), util.Error(err))
+	}
+}
+
+// ParseToken verifies token signature, expiry and type
+func (l *TokenLogic) ParseToken(tokenString, tokenType string) (*model.TokenClaims, error) {
+	options := []jwt.ParserOption{jwt.WithValidMethods(l.signingKeys.ValidMethods())}
+	if l.config.Issuer != "" {
+		// Tokens are only accepted from issuer this service signs as
+		options = append(options, jwt.WithIssuer(l.config.Issuer))
+	}
+
+	claims := &model.TokenClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, l.signingKeys.Keyfunc, options...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, util.NewError(_const.CodeTokenExpired.Message())
+		}
+		return nil, util.NewError(_const.CodeInvalidToken.Message())
+	}
+
+	if !token.Valid || claims.TokenType != tokenType {
+		return nil, util.NewError(_const.CodeInvalidToken.Message())
+	}
+
+	return claims, nil
+}
+
+// signToken signs a token of given type with active tenant of user and returns it with its ID
+func (l *TokenLogic) signToken(user *entity.User, tenant *entity.TenantMember, tokenType, familyID string, ttl time.Duration) (string, string, error) {
+	now := time.Now()
+	tokenID := uuid.New().String()
+	kid := l.signingKeys.ActiveKeyID()
+
+	claims := model.TokenClaims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenType: tokenType,
+		FamilyID:  familyID,
+		Scope:     tokenScope(l.config.EmailVerification, user),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Issuer:    l.config.Issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	if tenant != nil {
+		claims.TenantID = tenant.TenantID
+		claims.TenantRole = tenant.Role
+	}
+	claims.KongKey = l.kong.CredentialKey(user.ID, claims.TenantID, kid)
+
+	tokenString, err := l.signingKeys.Sign(kid, claims)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return tokenString, tokenID, nil
+}
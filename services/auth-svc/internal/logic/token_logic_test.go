@@ -0,0 +1,245 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+// refresh presents refresh token the way AuthLogic.RefreshToken does
+func refresh(ctx context.Context, tokens *TokenLogic, user *entity.User, refreshToken string) (*model.TokenPair, error) {
+	claims, err := tokens.ParseToken(refreshToken, _const.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if err := tokens.ConsumeRefreshToken(ctx, claims); err != nil {
+		return nil, err
+	}
+	return tokens.RotateTokenPair(ctx, user, claims.FamilyID, model.SessionClient{})
+}
+
+func TestTokenLogicRefreshRotation(t *testing.T) {
+	user := &entity.User{ID: 1}
+
+	tests := []struct {
+		name string
+		// rotations is how many times family is refreshed before presenting token
+		rotations int
+		// present picks presented refresh token out of issued ones, first is from login
+		present func(issued []*model.TokenPair) string
+		// wantErr is matched with errors.Is, wantErrMatch with error message
+		wantErr      error
+		wantErrMatch string
+		// wantRevoked expects whole family to be revoked
+		wantRevoked bool
+	}{
+		{
+			name:    "login token",
+			present: func(issued []*model.TokenPair) string { return issued[0].RefreshToken },
+		},
+		{
+			name:      "latest token after rotations",
+			rotations: 3,
+			present:   func(issued []*model.TokenPair) string { return issued[3].RefreshToken },
+		},
+		{
+			name:        "rotated token is reuse",
+			rotations:   1,
+			present:     func(issued []*model.TokenPair) string { return issued[0].RefreshToken },
+			wantErr:     _const.ErrorRefreshTokenReuse,
+			wantRevoked: true,
+		},
+		{
+			name:        "older rotated token is reuse",
+			rotations:   3,
+			present:     func(issued []*model.TokenPair) string { return issued[1].RefreshToken },
+			wantErr:     _const.ErrorRefreshTokenReuse,
+			wantRevoked: true,
+		},
+		{
+			name:         "access token isn't refresh token",
+			present:      func(issued []*model.TokenPair) string { return issued[0].AccessToken },
+			wantErrMatch: _const.CodeInvalidToken.Message(),
+		},
+		{
+			name:         "malformed token",
+			present:      func(issued []*model.TokenPair) string { return "not-a-token" },
+			wantErrMatch: _const.CodeInvalidToken.Message(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTenantTestEnv(t, nil)
+
+			pair, err := env.tokens.GenerateTokenPair(ctx, user, model.SessionClient{})
+			if err != nil {
+				t.Fatalf("GenerateTokenPair() error = %v", err)
+			}
+			issued := []*model.TokenPair{pair}
+			for i := 0; i < tt.rotations; i++ {
+				pair, err = refresh(ctx, env.tokens, user, pair.RefreshToken)
+				if err != nil {
+					t.Fatalf("rotation %d error = %v", i+1, err)
+				}
+				issued = append(issued, pair)
+			}
+			latest := issued[len(issued)-1]
+
+			_, err = refresh(ctx, env.tokens, user, tt.present(issued))
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("refresh() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantErrMatch != "":
+				if err == nil || err.Error() != tt.wantErrMatch {
+					t.Fatalf("refresh() error = %v, want %q", err, tt.wantErrMatch)
+				}
+			case err != nil:
+				t.Fatalf("refresh() error = %v", err)
+			}
+
+			if !tt.wantRevoked {
+				return
+			}
+			// Reuse revokes whole family, including tokens issued after the reused one
+			if _, err := refresh(ctx, env.tokens, user, latest.RefreshToken); err == nil {
+				t.Error("latest refresh token still works after reuse")
+			}
+			if _, err := env.tokens.ValidateAccessToken(ctx, latest.AccessToken); err == nil {
+				t.Error("latest access token still works after reuse")
+			}
+			sessions, err := env.tokens.ListSessions(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("ListSessions() error = %v", err)
+			}
+			if len(sessions) != 0 {
+				t.Errorf("sessions after reuse = %d, want 0", len(sessions))
+			}
+		})
+	}
+}
+
+func TestTokenLogicReuseKeepsOtherFamilies(t *testing.T) {
+	ctx := context.Background()
+	env := newTenantTestEnv(t, nil)
+	user := &entity.User{ID: 1}
+
+	stolen, err := env.tokens.GenerateTokenPair(ctx, user, model.SessionClient{})
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	other, err := env.tokens.GenerateTokenPair(ctx, user, model.SessionClient{})
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	if _, err := refresh(ctx, env.tokens, user, stolen.RefreshToken); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if _, err := refresh(ctx, env.tokens, user, stolen.RefreshToken); !errors.Is(err, _const.ErrorRefreshTokenReuse) {
+		t.Fatalf("refresh() of reused token error = %v, want %v", err, _const.ErrorRefreshTokenReuse)
+	}
+
+	if _, err := env.tokens.ValidateAccessToken(ctx, other.AccessToken); err != nil {
+		t.Errorf("access token of other session error = %v", err)
+	}
+	if _, err := refresh(ctx, env.tokens, user, other.RefreshToken); err != nil {
+		t.Errorf("refresh() of other session error = %v", err)
+	}
+}
+
+func TestTokenLogicRevokeOtherUserTokens(t *testing.T) {
+	ctx := context.Background()
+	env := newTenantTestEnv(t, nil)
+	user := &entity.User{ID: 1}
+
+	var pairs []*model.TokenPair
+	for i := 0; i < 3; i++ {
+		pair, err := env.tokens.GenerateTokenPair(ctx, user, model.SessionClient{})
+		if err != nil {
+			t.Fatalf("GenerateTokenPair() error = %v", err)
+		}
+		pairs = append(pairs, pair)
+	}
+	kept, err := env.tokens.ValidateAccessToken(ctx, pairs[0].AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+
+	if err := env.tokens.RevokeOtherUserTokens(ctx, user.ID, kept.FamilyID); err != nil {
+		t.Fatalf("RevokeOtherUserTokens() error = %v", err)
+	}
+
+	for i, pair := range pairs {
+		_, err := env.tokens.ValidateAccessToken(ctx, pair.AccessToken)
+		if wantValid := i == 0; (err == nil) != wantValid {
+			t.Errorf("session %d access token error = %v, want valid %v", i, err, wantValid)
+		}
+	}
+	sessions, err := env.tokens.ListSessions(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != kept.FamilyID {
+		t.Errorf("sessions = %+v, want only %s", sessions, kept.FamilyID)
+	}
+}
+
+func TestTokenLogicParseTokenIssuer(t *testing.T) {
+	logger := util.NewZapLogger(zap.NewNop())
+	newTokens := func(issuer string) *TokenLogic {
+		authConfig := config.AuthConfig{
+			SecretKey:                "test-secret",
+			Issuer:                   issuer,
+			AccessTokenExpireMinute:  15,
+			RefreshTokenExpireMinute: 60,
+		}
+		signingKeys, err := NewSigningKeyLogic(authConfig, logger)
+		if err != nil {
+			t.Fatalf("NewSigningKeyLogic() error = %v", err)
+		}
+		kong := newTestKongLogic(t, false, nil, nil, nil)
+		return NewTokenLogic(authConfig, signingKeys, kong, &tenantTestMemberRepo{}, newFakeRedisRepo(), logger)
+	}
+	parser := newTokens("bitzap-key")
+
+	tests := []struct {
+		name    string
+		issuer  string
+		wantErr bool
+	}{
+		{name: "same issuer", issuer: "bitzap-key"},
+		{name: "other issuer with same key", issuer: "other-service", wantErr: true},
+		{name: "no issuer", issuer: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := newTokens(tt.issuer).GenerateTokenPair(context.Background(), &entity.User{ID: 1}, model.SessionClient{})
+			if err != nil {
+				t.Fatalf("GenerateTokenPair() error = %v", err)
+			}
+
+			_, err = parser.ParseToken(pair.AccessToken, _const.TokenTypeAccess)
+			if tt.wantErr {
+				if err == nil || err.Error() != _const.CodeInvalidToken.Message() {
+					t.Fatalf("ParseToken() error = %v, want %s", err, _const.CodeInvalidToken.Message())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseToken() error = %v", err)
+			}
+		})
+	}
+}
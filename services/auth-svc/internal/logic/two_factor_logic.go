@@ -0,0 +1,371 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/domain/repository"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// recoveryCodeLength is number of hex chars in a recovery code
+const recoveryCodeLength = 10
+
+// TwoFactorLogic contains TOTP two-factor authentication logic
+type TwoFactorLogic struct {
+	config           config.TwoFactorConfig
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.UserRecoveryCodeRepository
+	userActivityRepo repository.UserActivityLogRepository
+	redisRepo        repository.RedisRepository
+	loginGuard       *LoginGuardLogic
+	logger           util.Logger
+}
+
+// NewTwoFactorLogic creates new TwoFactorLogic instance
+func NewTwoFactorLogic(
+	config config.TwoFactorConfig,
+	userRepo repository.UserRepository,
+	recoveryCodeRepo repository.UserRecoveryCodeRepository,
+	userActivityRepo repository.UserActivityLogRepository,
+	redisRepo repository.RedisRepository,
+	loginGuard *LoginGuardLogic,
+	logger util.Logger,
+) *TwoFactorLogic {
+	return &TwoFactorLogic{
+		config:           config,
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		userActivityRepo: userActivityRepo,
+		redisRepo:        redisRepo,
+		loginGuard:       loginGuard,
+		logger:           logger,
+	}
+}
+
+// Enroll generates pending TOTP secret which becomes active after Confirm
+func (l *TwoFactorLogic) Enroll(ctx context.Context, userID uint) (*model.TwoFactorEnrollResponse, error) {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, util.NewError(_const.CodeTwoFactorEnabled.Message())
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		l.logger.Error("Failed to generate TOTP secret", util.Error(err))
+		return nil, err
+	}
+
+	ttl := time.Duration(l.config.EnrollExpireMinute) * time.Minute
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyTwoFactorEnroll.Key(strconv.FormatUint(uint64(userID), 10)), secret, ttl); err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: util.TOTPURI(l.config.Issuer, user.Email, secret, l.config.PeriodSecond, l.config.Digits),
+		ExpiresIn:  int64(ttl.Seconds()),
+	}, nil
+}
+
+// Confirm verifies code of pending secret, enables two-factor and returns recovery codes
+func (l *TwoFactorLogic) Confirm(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, util.NewError(_const.CodeTwoFactorEnabled.Message())
+	}
+
+	enrollKey := _const.RedisKeyTwoFactorEnroll.Key(strconv.FormatUint(uint64(userID), 10))
+	secret, err := l.redisRepo.Get(ctx, enrollKey)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, util.NewError(_const.CodeTwoFactorNoEnroll.Message())
+	}
+
+	ok, err := l.verifyTOTP(ctx, userID, secret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, util.NewError(_const.CodeTwoFactorInvalid.Message())
+	}
+
+	if err := l.userRepo.EnableTwoFactor(ctx, userID, secret); err != nil {
+		l.logger.Error("Failed to enable two-factor", util.Error(err))
+		return nil, err
+	}
+	if err := l.redisRepo.Del(ctx, enrollKey); err != nil {
+		l.logger.Error("Failed to delete two-factor enrollment", util.Error(err))
+	}
+
+	codes, err := l.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "2fa_enabled", "user", req.IPAddress, req.UserAgent, nil)
+
+	l.logger.Info("Two-factor enabled", util.Int("user_id", int(userID)))
+
+	return &model.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor after checking password and TOTP or recovery code
+func (l *TwoFactorLogic) Disable(ctx context.Context, userID uint, req model.TwoFactorDisableRequest) error {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return util.NewError(_const.CodeTwoFactorDisabled.Message())
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return util.NewError(_const.CodeWrongPassword.Message())
+	}
+
+	ok, _, err := l.verifyCode(ctx, user, req.Code, req.RecoveryCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return util.NewError(_const.CodeTwoFactorInvalid.Message())
+	}
+
+	if err := l.userRepo.DisableTwoFactor(ctx, userID); err != nil {
+		l.logger.Error("Failed to disable two-factor", util.Error(err))
+		return err
+	}
+	if err := l.recoveryCodeRepo.DeleteByUserID(ctx, userID); err != nil {
+		l.logger.Error("Failed to delete recovery codes", util.Error(err))
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "2fa_disabled", "user", req.IPAddress, req.UserAgent, nil)
+
+	l.logger.Info("Two-factor disabled", util.Int("user_id", int(userID)))
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces recovery codes after checking TOTP code
+func (l *TwoFactorLogic) RegenerateRecoveryCodes(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	user, err := l.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, util.NewError(_const.CodeTwoFactorDisabled.Message())
+	}
+
+	ok, err := l.verifyTOTP(ctx, userID, user.TwoFactorSecret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, util.NewError(_const.CodeTwoFactorInvalid.Message())
+	}
+
+	codes, err := l.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log activity
+	l.userActivityRepo.LogActivity(ctx, userID, "2fa_recovery_codes_regenerated", "user", req.IPAddress, req.UserAgent, nil)
+
+	return &model.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// CreateChallenge stores pending login of user waiting for second factor
+func (l *TwoFactorLogic) CreateChallenge(ctx context.Context, user *entity.User) (*model.TwoFactorChallenge, error) {
+	challengeToken := uuid.New().String()
+	ttl := time.Duration(l.config.ChallengeExpireMinute) * time.Minute
+
+	if err := l.redisRepo.Set(ctx, _const.RedisKeyTwoFactorChallenge.Key(challengeToken), strconv.FormatUint(uint64(user.ID), 10), ttl); err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorChallenge{
+		ChallengeToken: challengeToken,
+		ExpiresIn:      int64(ttl.Seconds()),
+	}, nil
+}
+
+// VerifyChallenge checks second factor of pending login and returns user on success.
+// Challenge is dropped after too many wrong codes so password step must be repeated.
+// Wrong codes also count as failed logins of user, so new challenges can't reset the limit.
+func (l *TwoFactorLogic) VerifyChallenge(ctx context.Context, req model.TwoFactorVerifyRequest) (*entity.User, error) {
+	challengeKey := _const.RedisKeyTwoFactorChallenge.Key(req.ChallengeToken)
+	value, err := l.redisRepo.Get(ctx, challengeKey)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := strconv.ParseUint(value, 10, 64)
+	if value == "" || err != nil {
+		return nil, util.NewError(_const.CodeTwoFactorChallenge.Message())
+	}
+
+	attemptKey := _const.RedisKeyTwoFactorAttempt.Key(req.ChallengeToken)
+	attempts, err := l.redisRepo.Incr(ctx, attemptKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.redisRepo.Expire(ctx, attemptKey, time.Duration(l.config.ChallengeExpireMinute)*time.Minute); err != nil {
+		return nil, err
+	}
+	if l.config.MaxAttempts > 0 && attempts > int64(l.config.MaxAttempts) {
+		l.clearChallenge(ctx, req.ChallengeToken)
+		return nil, util.NewError(_const.CodeExceedLoginAttempts.Message())
+	}
+
+	user, err := l.getUser(ctx, uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, util.NewError(_const.CodeTwoFactorChallenge.Message())
+	}
+
+	loginReq := model.LoginRequest{Email: user.Email, IPAddress: req.IPAddress, UserAgent: req.UserAgent}
+	if err := l.loginGuard.CheckBlocked(ctx, loginReq); err != nil {
+		l.clearChallenge(ctx, req.ChallengeToken)
+		return nil, err
+	}
+
+	ok, usedRecovery, err := l.verifyCode(ctx, user, req.Code, req.RecoveryCode)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		l.logger.Warn("Invalid two-factor code",
+			util.Int("user_id", int(user.ID)),
+			util.Int64("attempts", attempts),
+		)
+		if err := l.loginGuard.RegisterFailure(ctx, user, loginReq); err != nil {
+			l.clearChallenge(ctx, req.ChallengeToken)
+			return nil, err
+		}
+		return nil, util.NewError(_const.CodeTwoFactorInvalid.Message())
+	}
+
+	l.clearChallenge(ctx, req.ChallengeToken)
+
+	if usedRecovery {
+		remaining, err := l.recoveryCodeRepo.CountUnused(ctx, user.ID)
+		if err != nil {
+			l.logger.Error("Failed to count recovery codes", util.Error(err))
+		}
+
+		// Log activity
+		l.userActivityRepo.LogActivity(ctx, user.ID, "2fa_recovery_code_used", "user", req.IPAddress, req.UserAgent, entity.JSONMap{
+			"remaining": remaining,
+		})
+	}
+
+	return user, nil
+}
+
+// clearChallenge removes pending login challenge and its attempt counter
+func (l *TwoFactorLogic) clearChallenge(ctx context.Context, challengeToken string) {
+	for _, key := range []string{
+		_const.RedisKeyTwoFactorChallenge.Key(challengeToken),
+		_const.RedisKeyTwoFactorAttempt.Key(challengeToken),
+	} {
+		if err := l.redisRepo.Del(ctx, key); err != nil {
+			l.logger.Error("Failed to delete two-factor challenge", util.Error(err))
+		}
+	}
+}
+
+// verifyCode checks TOTP code or, when given, recovery code of user
+func (l *TwoFactorLogic) verifyCode(ctx context.Context, user *entity.User, code, recoveryCode string) (ok bool, usedRecovery bool, err error) {
+	if recoveryCode != "" {
+		ok, err = l.recoveryCodeRepo.UseCode(ctx, user.ID, hashRecoveryCode(recoveryCode))
+		return ok, ok, err
+	}
+	if code == "" {
+		return false, false, nil
+	}
+
+	ok, err = l.verifyTOTP(ctx, user.ID, user.TwoFactorSecret, code)
+	return ok, false, err
+}
+
+// verifyTOTP validates code and rejects replay of already used time step
+func (l *TwoFactorLogic) verifyTOTP(ctx context.Context, userID uint, secret, code string) (bool, error) {
+	step, ok := util.ValidateTOTPCode(secret, strings.TrimSpace(code), time.Now(), l.config.PeriodSecond, l.config.Digits, l.config.Skew)
+	if !ok {
+		return false, nil
+	}
+
+	// Step is recorded only when above last used step, so concurrent requests
+	// with same code can't both pass
+	usedKey := _const.RedisKeyTwoFactorUsedStep.Key(strconv.FormatUint(uint64(userID), 10))
+	ttl := time.Duration(l.config.PeriodSecond*(2*l.config.Skew+1)) * time.Second
+	set, err := l.redisRepo.SetIfGreater(ctx, usedKey, step, ttl)
+	if err != nil {
+		return false, err
+	}
+
+	return set, nil
+}
+
+// replaceRecoveryCodes generates new recovery codes and stores their hashes
+func (l *TwoFactorLogic) replaceRecoveryCodes(ctx context.Context, userID uint) ([]string, error) {
+	codes := make([]string, 0, l.config.RecoveryCodeCount)
+	hashes := make([]string, 0, l.config.RecoveryCodeCount)
+	for i := 0; i < l.config.RecoveryCodeCount; i++ {
+		raw := util.GenerateRandomString(recoveryCodeLength)
+		code := raw[:recoveryCodeLength/2] + "-" + raw[recoveryCodeLength/2:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	if err := l.recoveryCodeRepo.ReplaceForUser(ctx, userID, hashes); err != nil {
+		l.logger.Error("Failed to store recovery codes", util.Error(err))
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// getUser gets user by ID and returns not found error when missing
+func (l *TwoFactorLogic) getUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := l.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		l.logger.Error("Failed to get user", util.Error(err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, util.NewError(_const.CodeUserNotFound.Message())
+	}
+	return user, nil
+}
+
+// hashRecoveryCode normalizes recovery code and returns its SHA-256 hex digest
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
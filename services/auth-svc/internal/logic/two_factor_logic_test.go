@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+func TestTwoFactorLogicVerifyTOTPReplay(t *testing.T) {
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	// Offsets stay within skew if step changes while test runs
+	current := util.TOTPStep(time.Now(), 30)
+	codeAt := func(offset int64) string {
+		code, err := util.GenerateTOTPCode(secret, current+offset, 6)
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode() error = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name string
+		// used are step offsets of codes accepted before, in order
+		used   []int64
+		offset int64
+		want   bool
+	}{
+		{name: "first use", offset: 0, want: true},
+		{name: "replay of same step", used: []int64{0}, offset: 0, want: false},
+		{name: "earlier step after later one", used: []int64{1}, offset: 0, want: false},
+		{name: "later step", used: []int64{0}, offset: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			l := &TwoFactorLogic{
+				config:    config.TwoFactorConfig{Digits: 6, PeriodSecond: 30, Skew: 1},
+				redisRepo: newFakeRedisRepo(),
+				logger:    util.NewZapLogger(zap.NewNop()),
+			}
+			// Other user used the same step just before, steps are tracked per user
+			if ok, err := l.verifyTOTP(ctx, 2, secret, codeAt(tt.offset)); err != nil || !ok {
+				t.Fatalf("verifyTOTP() of other user = %v, %v", ok, err)
+			}
+
+			for _, offset := range tt.used {
+				if ok, err := l.verifyTOTP(ctx, 1, secret, codeAt(offset)); err != nil || !ok {
+					t.Fatalf("verifyTOTP() of used step %d = %v, %v", offset, ok, err)
+				}
+			}
+
+			ok, err := l.verifyTOTP(ctx, 1, secret, codeAt(tt.offset))
+			if err != nil {
+				t.Fatalf("verifyTOTP() error = %v", err)
+			}
+			if ok != tt.want {
+				t.Errorf("verifyTOTP() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwoFactorLogicVerifyTOTPConcurrentReplay(t *testing.T) {
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	code, err := util.GenerateTOTPCode(secret, util.TOTPStep(time.Now(), 30), 6)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode() error = %v", err)
+	}
+
+	l := &TwoFactorLogic{
+		config:    config.TwoFactorConfig{Digits: 6, PeriodSecond: 30, Skew: 1},
+		redisRepo: newFakeRedisRepo(),
+		logger:    util.NewZapLogger(zap.NewNop()),
+	}
+
+	var accepted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := l.verifyTOTP(context.Background(), 1, secret, code)
+			if err != nil {
+				t.Errorf("verifyTOTP() error = %v", err)
+			}
+			if ok {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := accepted.Load(); got != 1 {
+		t.Errorf("verifyTOTP() accepted same code %d times, want 1", got)
+	}
+}
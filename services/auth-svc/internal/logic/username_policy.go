@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// UsernamePolicy validates username format against configured rules
+type UsernamePolicy struct {
+	config   config.UsernamePolicyConfig
+	reserved map[string]struct{}
+}
+
+// NewUsernamePolicy creates new UsernamePolicy instance
+func NewUsernamePolicy(config config.UsernamePolicyConfig) *UsernamePolicy {
+	reserved := make(map[string]struct{}, len(_const.ReservedUsernames)+len(config.ReservedNames))
+	for _, name := range _const.ReservedUsernames {
+		reserved[strings.ToLower(name)] = struct{}{}
+	}
+	for _, name := range config.ReservedNames {
+		reserved[strings.ToLower(strings.TrimSpace(name))] = struct{}{}
+	}
+
+	return &UsernamePolicy{config: config, reserved: reserved}
+}
+
+// Normalize lowercases username so names differing only in case are treated as the same account
+func (p *UsernamePolicy) Normalize(username string) string {
+	return strings.ToLower(username)
+}
+
+// Validate checks normalized username and returns error with message of the violated rule code.
+// Only ASCII letters, digits and underscore are allowed so that look-alike names can't be registered.
+func (p *UsernamePolicy) Validate(username string) error {
+	length := utf8.RuneCountInString(username)
+	if length == 0 || length < p.config.MinLength || (p.config.MaxLength > 0 && length > p.config.MaxLength) {
+		return util.NewError(_const.CodeAccountLength.Message())
+	}
+
+	if strings.IndexFunc(username, unicode.IsSpace) >= 0 {
+		return util.NewError(_const.CodeWhiteSpaceInAccount.Message())
+	}
+
+	for _, r := range username {
+		if !isUsernameRune(r) {
+			return util.NewError(_const.CodeAccountHaveSpecial.Message())
+		}
+	}
+
+	if username[0] >= '0' && username[0] <= '9' {
+		return util.NewError(_const.CodeAccountBeginNumber.Message())
+	}
+
+	if _, ok := p.reserved[username]; ok {
+		return util.NewError(_const.CodeAccountReserved.Message())
+	}
+
+	return nil
+}
+
+// isUsernameRune reports whether rune is allowed in username
+func isUsernameRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/taititans/bitzap/auth-svc/internal/config"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+)
+
+func TestUsernamePolicyValidate(t *testing.T) {
+	policy := NewUsernamePolicy(config.UsernamePolicyConfig{
+		MinLength:     3,
+		MaxLength:     12,
+		ReservedNames: []string{" Billing "},
+	})
+
+	tests := []struct {
+		name     string
+		username string
+		wantErr  string
+	}{
+		{name: "valid", username: "alice_01"},
+		{name: "underscore first", username: "_alice"},
+		{name: "empty", username: "", wantErr: _const.CodeAccountLength.Message()},
+		{name: "too short", username: "al", wantErr: _const.CodeAccountLength.Message()},
+		{name: "too long", username: "alice_wonderland", wantErr: _const.CodeAccountLength.Message()},
+		{name: "whitespace", username: "alice bob", wantErr: _const.CodeWhiteSpaceInAccount.Message()},
+		{name: "special character", username: "alice.bob", wantErr: _const.CodeAccountHaveSpecial.Message()},
+		{name: "cyrillic look-alike", username: "аlice", wantErr: _const.CodeAccountHaveSpecial.Message()},
+		{name: "begins with number", username: "1alice", wantErr: _const.CodeAccountBeginNumber.Message()},
+		{name: "built-in reserved name", username: "admin", wantErr: _const.CodeAccountReserved.Message()},
+		{name: "configured reserved name", username: "billing", wantErr: _const.CodeAccountReserved.Message()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.username)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v, want nil", tt.username, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, want %q", tt.username, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsernamePolicyNormalize(t *testing.T) {
+	policy := NewUsernamePolicy(config.UsernamePolicyConfig{MinLength: 3})
+
+	// Reserved names are matched after normalizing, so case can't be used to get around them
+	if err := policy.Validate(policy.Normalize("Admin")); err == nil || err.Error() != _const.CodeAccountReserved.Message() {
+		t.Errorf("Validate(Normalize(%q)) error = %v, want %q", "Admin", err, _const.CodeAccountReserved.Message())
+	}
+	if got, want := policy.Normalize("Alice_01"), "alice_01"; got != want {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// PermissionChecker checks roles and effective permissions of users
+type PermissionChecker interface {
+	HasRole(ctx context.Context, userID uint, role string) (bool, error)
+	HasPermission(ctx context.Context, userID uint, resource, action string) (bool, error)
+}
+
+// AccessControl creates middlewares that authorize authenticated users.
+// Its middlewares must be used after AuthMiddleware.
+type AccessControl struct {
+	checker PermissionChecker
+	logger  util.Logger
+}
+
+// NewAccessControl creates new AccessControl instance
+func NewAccessControl(checker PermissionChecker, logger util.Logger) *AccessControl {
+	return &AccessControl{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+// RequireRole create middleware that allows only users with given role
+func (a *AccessControl) RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := GetUserID(c)
+		if !ok {
+			return userCtxNotFound(c)
+		}
+
+		if isUnverifiedScope(c) {
+			return emailNotVerified(c)
+		}
+
+		hasRole, err := a.checker.HasRole(c.Context(), userID, role)
+		if err != nil {
+			a.logger.Error("Failed to check user role", util.Error(err))
+			return accessCheckFailed(c)
+		}
+		if !hasRole {
+			a.logger.Warn("User doesn't have required role",
+				util.Int("user_id", int(userID)),
+				util.String("role", role),
+				util.Path(c.Path()),
+			)
+			return c.Status(_const.CodeRoleRequired.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodeRoleRequired.Code(),
+				"message": _const.CodeRoleRequired.Message(),
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// RequirePermission create middleware that allows only users granted resource/action
+// by their roles or directly
+func (a *AccessControl) RequirePermission(resource, action string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := GetUserID(c)
+		if !ok {
+			return userCtxNotFound(c)
+		}
+
+		if isUnverifiedScope(c) {
+			return emailNotVerified(c)
+		}
+
+		hasPermission, err := a.checker.HasPermission(c.Context(), userID, resource, action)
+		if err != nil {
+			a.logger.Error("Failed to check user permission", util.Error(err))
+			return accessCheckFailed(c)
+		}
+		if !hasPermission {
+			a.logger.Warn("User doesn't have required permission",
+				util.Int("user_id", int(userID)),
+				util.String("resource", resource),
+				util.String("action", action),
+				util.Path(c.Path()),
+			)
+			return c.Status(_const.CodePermissionDenied.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodePermissionDenied.Code(),
+				"message": _const.CodePermissionDenied.Message(),
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireVerifiedEmail create middleware that rejects tokens limited by unverified email.
+// RequireRole and RequirePermission reject them as well.
+func (a *AccessControl) RequireVerifiedEmail() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := GetUserID(c); !ok {
+			return userCtxNotFound(c)
+		}
+		if isUnverifiedScope(c) {
+			return emailNotVerified(c)
+		}
+		return c.Next()
+	}
+}
+
+// isUnverifiedScope reports whether token was issued to unverified user under limited policy
+func isUnverifiedScope(c *fiber.Ctx) bool {
+	claims, ok := GetTokenClaims(c)
+	return ok && claims.Scope == _const.TokenScopeUnverified
+}
+
+// emailNotVerified responds when token scope is limited by unverified email
+func emailNotVerified(c *fiber.Ctx) error {
+	return c.Status(_const.CodeEmailNotVerified.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeEmailNotVerified.Code(),
+		"message": _const.CodeEmailNotVerified.Message(),
+	})
+}
+
+// userCtxNotFound responds when middleware runs before AuthMiddleware
+func userCtxNotFound(c *fiber.Ctx) error {
+	return c.Status(_const.CodeUserCtxNotFound.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeUserCtxNotFound.Code(),
+		"message": _const.CodeUserCtxNotFound.Message(),
+	})
+}
+
+// accessCheckFailed responds when roles or permissions can't be loaded
+func accessCheckFailed(c *fiber.Ctx) error {
+	return c.Status(_const.CodeDBError.HttpStatus()).JSON(fiber.Map{
+		"code":    _const.CodeDBError.Code(),
+		"message": _const.CodeDBError.Message(),
+	})
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+	"go.uber.org/zap"
+)
+
+var errTestStore = errors.New("store unavailable")
+
+// testValidator accepts tokens that are keys of claims
+type testValidator map[string]*model.TokenClaims
+
+func (v testValidator) ValidateAccessToken(ctx context.Context, tokenString string) (*model.TokenClaims, error) {
+	claims, ok := v[tokenString]
+	if !ok {
+		return nil, errors.New(_const.CodeInvalidToken.Message())
+	}
+	return claims, nil
+}
+
+// testChecker grants roles and "resource:action" permissions per user
+type testChecker struct {
+	roles       map[uint][]string
+	permissions map[uint][]string
+	err         error
+}
+
+func (c *testChecker) HasRole(ctx context.Context, userID uint, role string) (bool, error) {
+	return contains(c.roles[userID], role), c.err
+}
+
+func (c *testChecker) HasPermission(ctx context.Context, userID uint, resource, action string) (bool, error) {
+	return contains(c.permissions[userID], resource+":"+action), c.err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// testTokens are tokens known to testValidator
+var testTokens = testValidator{
+	"member":     {UserID: 1, FamilyID: "f1"},
+	"admin":      {UserID: 2, FamilyID: "f2"},
+	"unverified": {UserID: 3, FamilyID: "f3", Scope: _const.TokenScopeUnverified},
+}
+
+func testLogger() util.Logger {
+	return util.NewZapLogger(zap.NewNop())
+}
+
+// serve runs request with bearer token through handlers, returning status and response code.
+// Requests passing all handlers get 200 and CodeSuccess.
+func serve(t *testing.T, method, route, target string, header map[string]string, handlers ...fiber.Handler) (int, int) {
+	t.Helper()
+
+	app := fiber.New()
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"code": _const.CodeSuccess.Code()})
+	})
+	app.Add(method, route, handlers...)
+
+	req := httptest.NewRequest(method, target, nil)
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Code int `json:"code"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response error = %v", err)
+	}
+	return resp.StatusCode, body.Code
+}
+
+func bearer(token string) map[string]string {
+	if token == "" {
+		return nil
+	}
+	return map[string]string{fiber.HeaderAuthorization: _const.TokenSchemeBearer + " " + token}
+}
+
+func TestAccessControl(t *testing.T) {
+	checker := &testChecker{
+		roles:       map[uint][]string{1: {"user"}, 2: {"user", "admin"}, 3: {"admin"}},
+		permissions: map[uint][]string{1: {"profile:read"}, 2: {"profile:read", "users:delete"}, 3: {"users:delete"}},
+	}
+	access := NewAccessControl(checker, testLogger())
+	failing := NewAccessControl(&testChecker{err: errTestStore}, testLogger())
+
+	tests := []struct {
+		name       string
+		token      string
+		skipAuth   bool
+		handler    fiber.Handler
+		wantStatus int
+		wantCode   int
+	}{
+		{name: "role granted", token: "admin", handler: access.RequireRole("admin"), wantStatus: 200, wantCode: _const.CodeSuccess.Code()},
+		{name: "role missing", token: "member", handler: access.RequireRole("admin"), wantStatus: 403, wantCode: _const.CodeRoleRequired.Code()},
+		{name: "permission granted", token: "admin", handler: access.RequirePermission("users", "delete"), wantStatus: 200, wantCode: _const.CodeSuccess.Code()},
+		{name: "permission missing", token: "member", handler: access.RequirePermission("users", "delete"), wantStatus: 403, wantCode: _const.CodePermissionDenied.Code()},
+		{name: "unverified role is rejected", token: "unverified", handler: access.RequireRole("admin"), wantStatus: 403, wantCode: _const.CodeEmailNotVerified.Code()},
+		{name: "unverified permission is rejected", token: "unverified", handler: access.RequirePermission("users", "delete"), wantStatus: 403, wantCode: _const.CodeEmailNotVerified.Code()},
+		{name: "verified email passes", token: "member", handler: access.RequireVerifiedEmail(), wantStatus: 200, wantCode: _const.CodeSuccess.Code()},
+		{name: "unverified email", token: "unverified", handler: access.RequireVerifiedEmail(), wantStatus: 403, wantCode: _const.CodeEmailNotVerified.Code()},
+		{name: "checker failure", token: "admin", handler: failing.RequirePermission("users", "delete"), wantStatus: 500, wantCode: _const.CodeDBError.Code()},
+		{name: "without auth middleware", skipAuth: true, handler: access.RequireRole("admin"), wantStatus: 401, wantCode: _const.CodeUserCtxNotFound.Code()},
+		{name: "missing token", handler: access.RequireRole("admin"), wantStatus: _const.CodeTokenNotFound.HttpStatus(), wantCode: _const.CodeTokenNotFound.Code()},
+		{name: "invalid token", token: "forged", handler: access.RequireRole("admin"), wantStatus: _const.CodeInvalidToken.HttpStatus(), wantCode: _const.CodeInvalidToken.Code()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := []fiber.Handler{tt.handler}
+			if !tt.skipAuth {
+				handlers = append([]fiber.Handler{AuthMiddleware(testTokens, testLogger())}, handlers...)
+			}
+
+			status, code := serve(t, fiber.MethodGet, "/resource", "/resource", bearer(tt.token), handlers...)
+			if status != tt.wantStatus || code != tt.wantCode {
+				t.Errorf("response = %d/%d, want %d/%d", status, code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
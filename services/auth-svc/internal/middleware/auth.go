@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	LocalsUserID      = "user_id"
+	LocalsTokenClaims = "token_claims"
+)
+
+// TokenValidator validates access tokens
+type TokenValidator interface {
+	ValidateAccessToken(ctx context.Context, tokenString string) (*model.TokenClaims, error)
+}
+
+// AuthMiddleware create middleware that verifies bearer token and stores user in context
+func AuthMiddleware(validator TokenValidator, logger util.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		token := strings.TrimSpace(strings.TrimPrefix(header, _const.TokenSchemeBearer+" "))
+		if token == "" || token == header {
+			return c.Status(_const.CodeTokenNotFound.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodeTokenNotFound.Code(),
+				"message": _const.CodeTokenNotFound.Message(),
+			})
+		}
+
+		claims, err := validator.ValidateAccessToken(c.Context(), token)
+		if err != nil {
+			logger.Warn("Failed to validate access token",
+				util.Path(c.Path()),
+				util.Error(err),
+				util.String("ip", c.IP()),
+			)
+
+			code := _const.CodeInvalidToken
+			if err.Error() == _const.CodeTokenExpired.Message() {
+				code = _const.CodeTokenExpired
+			}
+			return c.Status(code.HttpStatus()).JSON(fiber.Map{
+				"code":    code.Code(),
+				"message": code.Message(),
+			})
+		}
+
+		// Add user to context
+		c.Locals(LocalsUserID, claims.UserID)
+		c.Locals(LocalsTokenClaims, claims)
+
+		return c.Next()
+	}
+}
+
+// GetUserID returns authenticated user ID from context
+func GetUserID(c *fiber.Ctx) (uint, bool) {
+	userID, ok := c.Locals(LocalsUserID).(uint)
+	return userID, ok
+}
+
+// GetTokenClaims returns authenticated token claims from context
+func GetTokenClaims(c *fiber.Ctx) (*model.TokenClaims, bool) {
+	claims, ok := c.Locals(LocalsTokenClaims).(*model.TokenClaims)
+	return claims, ok
+}
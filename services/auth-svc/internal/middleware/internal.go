@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+// HeaderInternalToken is header carrying shared token of internal callers
+const HeaderInternalToken = "X-Internal-Token"
+
+// InternalAuth create middleware that allows only callers presenting internal token.
+// Every request is rejected when token isn't configured.
+func InternalAuth(token string, logger util.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		presented := c.Get(HeaderInternalToken)
+		if token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			logger.Warn("Rejected internal request",
+				util.Path(c.Path()),
+				util.String("ip", c.IP()),
+			)
+			return c.Status(_const.CodePermissionNotAllowed.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodePermissionNotAllowed.Code(),
+				"message": _const.CodePermissionNotAllowed.Message(),
+			})
+		}
+
+		return c.Next()
+	}
+}
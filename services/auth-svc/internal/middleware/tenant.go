@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+	"github.com/taititans/bitzap/auth-svc/internal/util"
+)
+
+const (
+	// HeaderCompanyID is header selecting tenant of request
+	HeaderCompanyID = "X-Company-ID"
+
+	// ParamTenantID is path param of tenant compared with HeaderCompanyID
+	ParamTenantID = "tenant_id"
+
+	LocalsTenantContext = "tenant_context"
+)
+
+// Sources tenant of request is resolved from, in order of precedence
+const (
+	TenantSourceHeader = "header"
+	TenantSourcePath   = "path"
+	TenantSourceHost   = "host"
+	TenantSourceToken  = "token"
+)
+
+// TenantResolver resolves tenants of hosts and roles of users in tenants
+type TenantResolver interface {
+	TenantIDByHost(ctx context.Context, host string) (string, error)
+	MemberRole(ctx context.Context, tenantID string, userID uint) (string, error)
+}
+
+// TenantContextMiddleware create middleware that resolves tenant of request from
+// X-Company-ID header, tenant_id path param, subdomain or custom domain, then active
+// tenant in token, and stores it with role of user there in context. Header and path
+// param must match when both are present. Users that aren't members get
+// CodeTenantNotFound so tenants of others can't be discovered.
+// It must be used after AuthMiddleware.
+func TenantContextMiddleware(resolver TenantResolver, logger util.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetTokenClaims(c)
+		if !ok {
+			return userCtxNotFound(c)
+		}
+
+		// Tenant IDs are UUIDs, compare them case-insensitively
+		header := strings.ToLower(strings.TrimSpace(c.Get(HeaderCompanyID)))
+		param := strings.ToLower(c.Params(ParamTenantID))
+		if header != "" && param != "" && header != param {
+			logger.Warn("Tenant in header and path mismatch",
+				util.Int("user_id", int(claims.UserID)),
+				util.String("header", header),
+				util.String("param", param),
+				util.Path(c.Path()),
+			)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"code":    _const.CodeCompanyIdInHeaderMisMatch.Code(),
+				"message": _const.CodeCompanyIdInHeaderMisMatch.Message(),
+			})
+		}
+
+		tenantID, source := header, TenantSourceHeader
+		if tenantID == "" {
+			tenantID, source = param, TenantSourcePath
+		}
+		if tenantID == "" {
+			hostTenantID, err := resolver.TenantIDByHost(c.Context(), requestHost(c))
+			if err != nil {
+				return accessCheckFailed(c)
+			}
+			tenantID, source = hostTenantID, TenantSourceHost
+		}
+		if tenantID == "" {
+			tenantID, source = claims.TenantID, TenantSourceToken
+		}
+		if tenantID == "" {
+			return c.Status(_const.CodeCompanyCtxNotFound.HttpStatus()).JSON(fiber.Map{
+				"code":    _const.CodeCompanyCtxNotFound.Code(),
+				"message": _const.CodeCompanyCtxNotFound.Message(),
+			})
+		}
+
+		// Role in token can be stale, membership is checked on every request
+		role, err := resolver.MemberRole(c.Context(), tenantID, claims.UserID)
+		if err != nil {
+			return accessCheckFailed(c)
+		}
+		if role == "" {
+			logger.Warn("User isn't member of requested tenant",
+				util.Int("user_id", int(claims.UserID)),
+				util.String("tenant_id", tenantID),
+				util.String("source", source),
+				util.Path(c.Path()),
+			)
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"code":    _const.CodeTenantNotFound.Code(),
+				"message": _const.CodeTenantNotFound.Message(),
+			})
+		}
+
+		c.Locals(LocalsTenantContext, &model.TenantContext{
+			TenantID: tenantID,
+			UserID:   claims.UserID,
+			Role:     role,
+			Source:   source,
+		})
+
+		return c.Next()
+	}
+}
+
+// GetTenantContext returns tenant of request resolved by TenantContextMiddleware
+func GetTenantContext(c *fiber.Ctx) (*model.TenantContext, bool) {
+	tenant, ok := c.Locals(LocalsTenantContext).(*model.TenantContext)
+	return tenant, ok
+}
+
+// requestHost returns requested host without port
+func requestHost(c *fiber.Ctx) string {
+	host := c.Hostname()
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+	return host
+}
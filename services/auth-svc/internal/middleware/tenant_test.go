@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	_const "github.com/taititans/bitzap/auth-svc/internal/const"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+const (
+	testTenantA = "0b8f3c1e-5f57-4a39-9a53-2c1d7e0a9f11"
+	testTenantB = "7d2e6a40-91c3-4f0e-8b4a-5e6f7a8b9c22"
+)
+
+// testResolver serves tenants of hosts and roles of members
+type testResolver struct {
+	hosts   map[string]string
+	members map[string]map[uint]string
+	err     error
+}
+
+func (r *testResolver) TenantIDByHost(ctx context.Context, host string) (string, error) {
+	return r.hosts[host], r.err
+}
+
+func (r *testResolver) MemberRole(ctx context.Context, tenantID string, userID uint) (string, error) {
+	return r.members[tenantID][userID], r.err
+}
+
+func TestTenantContextMiddleware(t *testing.T) {
+	resolver := &testResolver{
+		hosts: map[string]string{"acme.bitzap.com": testTenantA},
+		members: map[string]map[uint]string{
+			testTenantA: {1: "member"},
+			testTenantB: {1: "admin"},
+		},
+	}
+	tokens := testValidator{
+		"member":        {UserID: 1, FamilyID: "f1"},
+		"active-tenant": {UserID: 1, FamilyID: "f2", TenantID: testTenantB},
+		"outsider":      {UserID: 9, FamilyID: "f3"},
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		route    string
+		target   string
+		header   string
+		resolver TenantResolver
+		// want is resolved tenant, nil when request is rejected
+		want       *model.TenantContext
+		wantStatus int
+		wantCode   int
+	}{
+		{
+			name: "header", token: "member", route: "/x", target: "/x", header: testTenantA,
+			want: &model.TenantContext{TenantID: testTenantA, UserID: 1, Role: "member", Source: TenantSourceHeader},
+		},
+		{
+			name: "path param", token: "member", route: "/tenants/:tenant_id", target: "/tenants/" + testTenantB,
+			want: &model.TenantContext{TenantID: testTenantB, UserID: 1, Role: "admin", Source: TenantSourcePath},
+		},
+		{
+			name: "header matches path ignoring case", token: "member", route: "/tenants/:tenant_id", target: "/tenants/" + testTenantA, header: " 0B8F3C1E-5F57-4A39-9A53-2C1D7E0A9F11 ",
+			want: &model.TenantContext{TenantID: testTenantA, UserID: 1, Role: "member", Source: TenantSourceHeader},
+		},
+		{
+			name: "header and path mismatch", token: "member", route: "/tenants/:tenant_id", target: "/tenants/" + testTenantA, header: testTenantB,
+			wantStatus: 400, wantCode: _const.CodeCompanyIdInHeaderMisMatch.Code(),
+		},
+		{
+			name: "subdomain", token: "member", route: "/x", target: "http://acme.bitzap.com:8080/x",
+			want: &model.TenantContext{TenantID: testTenantA, UserID: 1, Role: "member", Source: TenantSourceHost},
+		},
+		{
+			name: "header wins over subdomain", token: "member", route: "/x", target: "http://acme.bitzap.com/x", header: testTenantB,
+			want: &model.TenantContext{TenantID: testTenantB, UserID: 1, Role: "admin", Source: TenantSourceHeader},
+		},
+		{
+			name: "active tenant in token", token: "active-tenant", route: "/x", target: "/x",
+			want: &model.TenantContext{TenantID: testTenantB, UserID: 1, Role: "admin", Source: TenantSourceToken},
+		},
+		{
+			name: "no tenant", token: "member", route: "/x", target: "/x",
+			wantStatus: 401, wantCode: _const.CodeCompanyCtxNotFound.Code(),
+		},
+		{
+			name: "not member of header tenant", token: "outsider", route: "/x", target: "/x", header: testTenantA,
+			wantStatus: 404, wantCode: _const.CodeTenantNotFound.Code(),
+		},
+		{
+			name: "not member of path tenant", token: "outsider", route: "/tenants/:tenant_id", target: "/tenants/" + testTenantB,
+			wantStatus: 404, wantCode: _const.CodeTenantNotFound.Code(),
+		},
+		{
+			name: "resolver failure", token: "member", route: "/x", target: "/x", header: testTenantA, resolver: &testResolver{err: errTestStore},
+			wantStatus: 500, wantCode: _const.CodeDBError.Code(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.resolver
+			if r == nil {
+				r = resolver
+			}
+			header := bearer(tt.token)
+			if tt.header != "" {
+				header[HeaderCompanyID] = tt.header
+			}
+
+			var got *model.TenantContext
+			capture := func(c *fiber.Ctx) error {
+				got, _ = GetTenantContext(c)
+				return c.Next()
+			}
+
+			status, code := serve(t, fiber.MethodGet, tt.route, tt.target, header,
+				AuthMiddleware(tokens, testLogger()),
+				TenantContextMiddleware(r, testLogger()),
+				capture,
+			)
+
+			if tt.want == nil {
+				if status != tt.wantStatus || code != tt.wantCode {
+					t.Errorf("response = %d/%d, want %d/%d", status, code, tt.wantStatus, tt.wantCode)
+				}
+				if got != nil {
+					t.Errorf("tenant context = %+v, want none", got)
+				}
+				return
+			}
+			if status != 200 {
+				t.Fatalf("response = %d/%d, want 200", status, code)
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("tenant context = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// ActivityFilter represents activity log filters with cursor pagination.
+// Zero UserID lists activity of all users, Pseudonym selects activity of purged user.
+// Cursor is ID of last seen entry.
+type ActivityFilter struct {
+	UserID    uint
+	Pseudonym string
+	Actions   []string
+	IPAddress string
+	From      *time.Time
+	To        *time.Time
+	Cursor    uint
+	Limit     int
+}
+
+// ActivityPage represents page of activity logs, newest first.
+// NextCursor is empty on the last page.
+type ActivityPage struct {
+	Items      []*entity.UserActivityLog `json:"items"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+const (
+	// DefaultPageSize is page size used when not set
+	DefaultPageSize = 20
+	// MaxPageSize is largest page size accepted by list endpoints
+	MaxPageSize = 100
+)
+
+// UserFilter represents filters of admin user listing, nil filters are ignored
+type UserFilter struct {
+	Query       string
+	IsVerified  *bool
+	IsActive    *bool
+	Role        string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// ListUsersRequest represents admin user listing request
+type ListUsersRequest struct {
+	UserFilter
+	Page     int
+	PageSize int
+}
+
+// UserList represents page of users
+type UserList struct {
+	Users    []*entity.User `json:"users"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// AdminActionRequest represents admin action on user, reason is stored in activity log
+type AdminActionRequest struct {
+	Reason    string `json:"reason"`
+	AdminID   uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// AdminRoleRequest represents role assignment by admin
+type AdminRoleRequest struct {
+	Role      string `json:"role" validate:"required"`
+	AdminID   uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
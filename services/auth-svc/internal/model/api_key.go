@@ -0,0 +1,46 @@
+package model
+
+import "github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+
+// CreateAPIKeyRequest represents API key creation request
+type CreateAPIKeyRequest struct {
+	Name          string               `json:"name" validate:"required,max=100"`
+	Scopes        []entity.APIKeyScope `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays int                  `json:"expires_in_days"`
+	TenantID      string               `json:"-"`
+	IPAddress     string               `json:"-"`
+	UserAgent     string               `json:"-"`
+}
+
+// APIKeyActionRequest represents rotate or revoke request of API key,
+// TenantID is set for keys of tenant
+type APIKeyActionRequest struct {
+	TenantID  string `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// CreatedAPIKey represents newly issued API key, secret is returned only once
+type CreatedAPIKey struct {
+	Key    string         `json:"key"`
+	APIKey *entity.APIKey `json:"api_key"`
+}
+
+// VerifyAPIKeyRequest represents internal API key verification request.
+// Resource and action are optional, when set the key must have that scope.
+type VerifyAPIKeyRequest struct {
+	Key      string `json:"key" validate:"required"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	ClientIP string `json:"client_ip"`
+}
+
+// VerifyAPIKeyResponse represents owner and scopes of verified API key.
+// Scopes are scopes of key still granted to its owner.
+type VerifyAPIKeyResponse struct {
+	KeyID    uint                `json:"key_id"`
+	UserID   uint                `json:"user_id"`
+	TenantID string              `json:"tenant_id,omitempty"`
+	Prefix   string              `json:"prefix"`
+	Scopes   entity.APIKeyScopes `json:"scopes"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// DeviceReportRequest represents "this wasn't me" report of login from new device
+type DeviceReportRequest struct {
+	Token     string `json:"token" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// DeviceReportPreview represents login shown before it is reported
+type DeviceReportPreview struct {
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	LoginAt   time.Time `json:"login_at"`
+}
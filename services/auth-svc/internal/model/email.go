@@ -1,11 +1,44 @@
 package model
 
+import "time"
+
 // EmailVerificationRequest represents email verification request
 type EmailVerificationRequest struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 }
 
+// ResendVerificationRequest represents request to resend email verification link
+type ResendVerificationRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// ResendVerificationResponse represents resend throttling info returned to client
+type ResendVerificationResponse struct {
+	ResendIn int64 `json:"resend_in"`
+}
+
+// EmailChangeConfirmation represents confirmation of email change sent to new address.
+// FamilyID is token family of session that requested change, it stays signed in.
+type EmailChangeConfirmation struct {
+	UserID   uint   `json:"user_id"`
+	FamilyID string `json:"family_id"`
+	Name     string `json:"name"`
+	NewEmail string `json:"new_email"`
+}
+
+// TenantInvitationEmail represents invitation to join tenant sent to invitee
+type TenantInvitationEmail struct {
+	Email       string    `json:"email"`
+	TenantName  string    `json:"tenant_name"`
+	InviterName string    `json:"inviter_name"`
+	Role        string    `json:"role"`
+	Token       string    `json:"-"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 // PasswordResetRequest represents password reset request
 type PasswordResetRequest struct {
 	Email string `json:"email"`
@@ -27,3 +60,11 @@ type EmailData struct {
 	TextBody  string            `json:"text_body"`
 	Variables map[string]string `json:"variables"`
 }
+
+// NewDeviceAlert represents login from device not seen before
+type NewDeviceAlert struct {
+	IPAddress   string
+	UserAgent   string
+	LoginAt     time.Time
+	ReportToken string
+}
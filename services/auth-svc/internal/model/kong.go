@@ -0,0 +1,27 @@
+package model
+
+// KongConsumer represents Kong consumer entity
+type KongConsumer struct {
+	ID       string   `json:"id,omitempty"`
+	Username string   `json:"username"`
+	CustomID string   `json:"custom_id,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// KongJWTCredential represents Kong JWT plugin credential of consumer
+type KongJWTCredential struct {
+	ID           string   `json:"id,omitempty"`
+	Key          string   `json:"key"`
+	Algorithm    string   `json:"algorithm"`
+	RSAPublicKey string   `json:"rsa_public_key,omitempty"`
+	Secret       string   `json:"secret,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// KongReconcileResult represents summary of Kong reconciliation run
+type KongReconcileResult struct {
+	Provisioned   int `json:"provisioned"`
+	Deprovisioned int `json:"deprovisioned"`
+	Orphans       int `json:"orphans"`
+	Failed        int `json:"failed"`
+}
@@ -0,0 +1,36 @@
+package model
+
+// OAuthProfile represents user info returned by OAuth provider
+type OAuthProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	AvatarURL     string
+}
+
+// OAuthCallbackRequest represents provider redirect back to auth service
+type OAuthCallbackRequest struct {
+	Provider    string `json:"-"`
+	Code        string `json:"code" query:"code"`
+	State       string `json:"state" query:"state"`
+	StateCookie string `json:"-"`
+	IPAddress   string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// OAuthState represents authorization request data stored until callback
+type OAuthState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// OAuthAuthorization represents started social login. State must be kept by
+// browser in cookie and is compared with state returned to callback.
+type OAuthAuthorization struct {
+	URL       string
+	State     string
+	ExpiresIn int64
+}
@@ -0,0 +1,33 @@
+package model
+
+// OTPRequest represents request to send login one-time passcode
+type OTPRequest struct {
+	// Type of destination: 1 = email, 2 = phone
+	Type        int    `json:"type" validate:"required,oneof=1 2"`
+	Destination string `json:"destination" validate:"required"`
+	IPAddress   string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// OTPResponse represents sent one-time passcode info
+type OTPResponse struct {
+	ExpiresIn int64 `json:"expires_in"`
+	ResendIn  int64 `json:"resend_in"`
+}
+
+// OTPLoginRequest represents passwordless login with one-time passcode
+type OTPLoginRequest struct {
+	// Type of destination: 1 = email, 2 = phone
+	Type        int    `json:"type" validate:"required,oneof=1 2"`
+	Destination string `json:"destination" validate:"required"`
+	Code        string `json:"code" validate:"required"`
+	IPAddress   string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// PhoneVerificationRequest represents code sent to phone of current user
+type PhoneVerificationRequest struct {
+	Code      string `json:"code" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
@@ -0,0 +1,39 @@
+package model
+
+// PermissionWildcard matches any resource or action
+const PermissionWildcard = "*"
+
+// Permission represents resource/action pair granted to user
+type Permission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// EffectivePermissions represents roles of user and union of their
+// permissions with permissions granted to user directly
+type EffectivePermissions struct {
+	UserID      uint         `json:"user_id"`
+	Roles       []string     `json:"roles"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// HasRole reports whether user has role
+func (p *EffectivePermissions) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Has reports whether resource/action is granted, "*" matches any value
+func (p *EffectivePermissions) Has(resource, action string) bool {
+	for _, permission := range p.Permissions {
+		if (permission.Resource == PermissionWildcard || permission.Resource == resource) &&
+			(permission.Action == PermissionWildcard || permission.Action == action) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// DataExportFileName is name of JSON file inside data export archive
+const DataExportFileName = "personal-data.json"
+
+// DataExportRequest represents self-service data export request
+type DataExportRequest struct {
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// DataExport represents personal data of user bundled in export archive
+type DataExport struct {
+	ExportedAt   time.Time                 `json:"exported_at"`
+	User         *entity.User              `json:"user"`
+	Roles        []string                  `json:"roles"`
+	Permissions  []Permission              `json:"permissions"`
+	ActivityLogs []*entity.UserActivityLog `json:"activity_logs"`
+}
+
+// AccountDeletionRequest represents account deletion request, confirmed with ReauthProof
+type AccountDeletionRequest struct {
+	ReauthProof
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// AccountDeletionResponse represents scheduled account deletion
+type AccountDeletionResponse struct {
+	DeletionDueAt time.Time `json:"deletion_due_at"`
+}
+
+// CancelDeletionRequest represents account deletion cancel request
+type CancelDeletionRequest struct {
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// PurgeResult represents summary of account purge run
+type PurgeResult struct {
+	Purged int `json:"purged"`
+	Failed int `json:"failed"`
+}
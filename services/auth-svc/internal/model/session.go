@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// SessionClient identifies client that logged in or refreshed tokens
+type SessionClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// Session represents login session of user, backed by refresh token family
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uint      `json:"user_id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// SessionActionRequest represents session revoke request
+type SessionActionRequest struct {
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"time"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+)
+
+// CreateTenantRequest represents tenant creation request, slug is derived from name when empty
+type CreateTenantRequest struct {
+	Name      string `json:"name" validate:"required,max=100"`
+	Slug      string `json:"slug"`
+	UserID    uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TenantMembership represents tenant of user with role of user there
+type TenantMembership struct {
+	Tenant *entity.Tenant `json:"tenant"`
+	Role   string         `json:"role"`
+	Active bool           `json:"active"`
+}
+
+// TenantMember represents member of tenant visible to other members
+type TenantMember struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Firstname string    `json:"firstname"`
+	Lastname  string    `json:"lastname"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// TenantMemberRoleRequest represents request to change role of tenant member
+type TenantMemberRoleRequest struct {
+	Role      string `json:"role" validate:"required"`
+	ActorID   uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TenantActionRequest represents tenant action of authenticated user
+type TenantActionRequest struct {
+	ActorID   uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// Statuses of tenant invitation
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// CreateInvitationRequest represents request to invite email to tenant
+type CreateInvitationRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	Role      string `json:"role" validate:"required"`
+	ActorID   uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TenantInvitation represents invitation listed to tenant admins
+type TenantInvitation struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	InvitedBy *uint     `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InvitationPreview represents invitation shown to invitee before accepting,
+// registration form can be pre-filled from it
+type InvitationPreview struct {
+	TenantName  string    `json:"tenant_name"`
+	TenantSlug  string    `json:"tenant_slug"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	InviterName string    `json:"inviter_name"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Registered  bool      `json:"registered"`
+}
+
+// InvitationTokenRequest represents request to accept or decline invitation
+type InvitationTokenRequest struct {
+	Token     string `json:"token" validate:"required"`
+	UserID    uint   `json:"-"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TenantContext represents tenant of request resolved by tenant context middleware,
+// Role is role of authenticated user there
+type TenantContext struct {
+	TenantID string `json:"tenant_id"`
+	UserID   uint   `json:"user_id"`
+	Role     string `json:"role"`
+	Source   string `json:"source"`
+}
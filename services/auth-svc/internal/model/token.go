@@ -0,0 +1,41 @@
+package model
+
+import "github.com/golang-jwt/jwt/v5"
+
+// TokenClaims represents the JWT payload issued by auth service
+type TokenClaims struct {
+	UserID    uint   `json:"user_id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	TokenType string `json:"token_type"`
+	FamilyID  string `json:"family_id"`
+	KongKey   string `json:"kong_key,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+
+	// Active tenant of user and its role there, empty when user has no active tenant
+	TenantID   string `json:"tenant_id,omitempty"`
+	TenantRole string `json:"tenant_role,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// TokenPair represents access and refresh tokens returned to client
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// RefreshTokenRequest represents refresh token rotation request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// LogoutRequest represents logout request
+type LogoutRequest struct {
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
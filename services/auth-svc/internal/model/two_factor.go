@@ -0,0 +1,54 @@
+package model
+
+import "github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+
+// LoginResult represents result of login step.
+// Tokens is nil and TwoFactor is set when user must complete two-factor verification.
+type LoginResult struct {
+	User      *entity.User
+	Tokens    *TokenPair
+	TwoFactor *TwoFactorChallenge
+}
+
+// TwoFactorChallenge represents pending two-factor login step returned to client
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int64  `json:"expires_in"`
+}
+
+// TwoFactorVerifyRequest represents second login step with TOTP or recovery code
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+// TwoFactorEnrollResponse represents pending TOTP secret to add into authenticator app
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// TwoFactorCodeRequest represents request confirmed by TOTP code
+type TwoFactorCodeRequest struct {
+	Code      string `json:"code" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// TwoFactorDisableRequest represents request to disable two-factor authentication
+type TwoFactorDisableRequest struct {
+	Password     string `json:"password" validate:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// RecoveryCodesResponse represents one-time recovery codes shown to user once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
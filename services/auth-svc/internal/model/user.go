@@ -3,12 +3,15 @@ package model
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Username  string `json:"username" validate:"required,min=3,max=50"`
-	Password  string `json:"password" validate:"required,min=6"`
+	Password  string `json:"password" validate:"required"`
 	FirstName string `json:"first_name" validate:"required"`
 	LastName  string `json:"last_name" validate:"required"`
 	Phone     string `json:"phone"`
 	IPAddress string `json:"-"`
 	UserAgent string `json:"-"`
+
+	// Optional token of tenant invitation sent to email, joins tenant on registration
+	InvitationToken string `json:"invitation_token"`
 }
 
 type LoginRequest struct {
@@ -27,16 +30,39 @@ type UpdateProfileRequest struct {
 	UserAgent string `json:"-"`
 }
 
+// ReauthProof confirms identity of signed in user before sensitive action: password,
+// code sent by email or token family of session signed in recently
+type ReauthProof struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+	FamilyID string `json:"-"`
+}
+
+// ChangeEmailRequest represents email change request, confirmed with ReauthProof
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	ReauthProof
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// ConfirmEmailChangeRequest represents email change confirmation from link sent to new address
+type ConfirmEmailChangeRequest struct {
+	Token     string `json:"token" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" validate:"required"`
-	NewPassword string `json:"new_password" validate:"required,min=6"`
+	NewPassword string `json:"new_password" validate:"required"`
 	IPAddress   string `json:"-"`
 	UserAgent   string `json:"-"`
 }
 
 type ResetPasswordRequest struct {
 	Token           string `json:"token" validate:"required"`
-	NewPassword     string `json:"new_password" validate:"required,min=6"`
+	NewPassword     string `json:"new_password" validate:"required"`
 	ConfirmPassword string `json:"confirm_password" validate:"required"`
 	IPAddress       string `json:"-"`
 	UserAgent       string `json:"-"`
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/logic"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// AdminService defines the interface for admin user management service
+type AdminService interface {
+	// List users with filters
+	ListUsers(ctx context.Context, req model.ListUsersRequest) (*model.UserList, error)
+
+	// Get user with roles
+	GetUser(ctx context.Context, userID uint) (*entity.User, error)
+
+	// Lock user
+	LockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error
+
+	// Unlock user
+	UnlockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error
+
+	// Revoke all sessions of user
+	RevokeUserSessions(ctx context.Context, userID uint, req model.AdminActionRequest) error
+
+	// Force email verification
+	VerifyUserEmail(ctx context.Context, userID uint, req model.AdminActionRequest) error
+
+	// Assign role to user
+	AssignUserRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error
+
+	// Remove role from user
+	RemoveUserRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error
+
+	// List activity of all users
+	ListActivity(ctx context.Context, filter model.ActivityFilter) (*model.ActivityPage, error)
+}
+
+// adminService implements AdminService interface
+type adminService struct {
+	adminUserLogic *logic.AdminUserLogic
+	activityLogic  *logic.ActivityLogic
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(adminUserLogic *logic.AdminUserLogic, activityLogic *logic.ActivityLogic) AdminService {
+	return &adminService{
+		adminUserLogic: adminUserLogic,
+		activityLogic:  activityLogic,
+	}
+}
+
+// ListUsers lists users with filters
+func (s *adminService) ListUsers(ctx context.Context, req model.ListUsersRequest) (*model.UserList, error) {
+	return s.adminUserLogic.ListUsers(ctx, req)
+}
+
+// GetUser gets user with roles
+func (s *adminService) GetUser(ctx context.Context, userID uint) (*entity.User, error) {
+	return s.adminUserLogic.GetUser(ctx, userID)
+}
+
+// LockUser locks user
+func (s *adminService) LockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	return s.adminUserLogic.LockUser(ctx, userID, req)
+}
+
+// UnlockUser unlocks user
+func (s *adminService) UnlockUser(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	return s.adminUserLogic.UnlockUser(ctx, userID, req)
+}
+
+// RevokeUserSessions revokes all sessions of user
+func (s *adminService) RevokeUserSessions(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	return s.adminUserLogic.RevokeSessions(ctx, userID, req)
+}
+
+// VerifyUserEmail forces email verification of user
+func (s *adminService) VerifyUserEmail(ctx context.Context, userID uint, req model.AdminActionRequest) error {
+	return s.adminUserLogic.VerifyEmail(ctx, userID, req)
+}
+
+// AssignUserRole assigns role to user
+func (s *adminService) AssignUserRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error {
+	return s.adminUserLogic.AssignRole(ctx, userID, req)
+}
+
+// RemoveUserRole removes role from user
+func (s *adminService) RemoveUserRole(ctx context.Context, userID uint, req model.AdminRoleRequest) error {
+	return s.adminUserLogic.RemoveRole(ctx, userID, req)
+}
+
+// ListActivity lists activity of all users
+func (s *adminService) ListActivity(ctx context.Context, filter model.ActivityFilter) (*model.ActivityPage, error) {
+	return s.activityLogic.ListActivity(ctx, filter)
+}
@@ -14,7 +14,35 @@ type AuthService interface {
 	RegisterUser(ctx context.Context, req model.RegisterRequest) (*entity.User, error)
 
 	// Login user
-	LoginUser(ctx context.Context, req model.LoginRequest) (*entity.User, error)
+	LoginUser(ctx context.Context, req model.LoginRequest) (*model.LoginResult, error)
+
+	// Send passwordless login code
+	RequestLoginOTP(ctx context.Context, req model.OTPRequest) (*model.OTPResponse, error)
+
+	// Login with one-time passcode
+	LoginWithOTP(ctx context.Context, req model.OTPLoginRequest) (*model.LoginResult, error)
+
+	// Verify phone of current user so it can log in with OTP
+	RequestPhoneVerification(ctx context.Context, userID uint) (*model.OTPResponse, error)
+	VerifyPhone(ctx context.Context, userID uint, req model.PhoneVerificationRequest) error
+
+	// Get social login authorize URL
+	OAuthAuthorize(ctx context.Context, provider string) (*model.OAuthAuthorization, error)
+
+	// Login with social provider callback
+	LoginWithOAuth(ctx context.Context, req model.OAuthCallbackRequest) (*model.LoginResult, error)
+
+	// Complete login with second factor
+	VerifyTwoFactorLogin(ctx context.Context, req model.TwoFactorVerifyRequest) (*model.LoginResult, error)
+
+	// Refresh token
+	RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (*model.TokenPair, error)
+
+	// Logout current session
+	Logout(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error
+
+	// Logout all sessions
+	LogoutAll(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error
 
 	// Get user profile
 	GetUserProfile(ctx context.Context, userID uint) (*entity.User, error)
@@ -22,6 +50,11 @@ type AuthService interface {
 	// Update user profile
 	UpdateUserProfile(ctx context.Context, userID uint, req model.UpdateProfileRequest) (*entity.User, error)
 
+	// Change email, confirmed from new address
+	RequestEmailChange(ctx context.Context, userID uint, req model.ChangeEmailRequest) error
+	RequestReauthCode(ctx context.Context, userID uint) (*model.OTPResponse, error)
+	ConfirmEmailChange(ctx context.Context, req model.ConfirmEmailChangeRequest) error
+
 	// Change password
 	ChangePassword(ctx context.Context, userID uint, req model.ChangePasswordRequest) error
 
@@ -33,17 +66,62 @@ type AuthService interface {
 
 	// Verify email
 	VerifyEmail(ctx context.Context, token string) error
+
+	// Resend email verification link
+	ResendVerification(ctx context.Context, req model.ResendVerificationRequest) (*model.ResendVerificationResponse, error)
+
+	// Show login from new device of report token, then report it to lock account
+	PreviewLoginReport(ctx context.Context, token string) (*model.DeviceReportPreview, error)
+	ReportLogin(ctx context.Context, req model.DeviceReportRequest) error
+
+	// Two-factor authentication
+	EnrollTwoFactor(ctx context.Context, userID uint) (*model.TwoFactorEnrollResponse, error)
+	ConfirmTwoFactor(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID uint, req model.TwoFactorDisableRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error)
+
+	// API keys
+	CreateAPIKey(ctx context.Context, userID uint, req model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uint) ([]*entity.APIKey, error)
+	RotateAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) (*model.CreatedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) error
+	VerifyAPIKey(ctx context.Context, req model.VerifyAPIKeyRequest) (*model.VerifyAPIKeyResponse, error)
+
+	// Sessions of current user
+	ListSessions(ctx context.Context, claims *model.TokenClaims) ([]*model.Session, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string, req model.SessionActionRequest) error
+
+	// Data export and account deletion
+	RequestDataExport(ctx context.Context, userID uint, req model.DataExportRequest) error
+	DownloadDataExport(ctx context.Context, userID uint, token string) ([]byte, error)
+	RequestAccountDeletion(ctx context.Context, userID uint, req model.AccountDeletionRequest) (*model.AccountDeletionResponse, error)
+	CancelAccountDeletion(ctx context.Context, userID uint, req model.CancelDeletionRequest) error
+
+	// List activity of current user
+	ListMyActivity(ctx context.Context, userID uint, filter model.ActivityFilter) (*model.ActivityPage, error)
 }
 
 // authService implements AuthService
 type authService struct {
-	authLogic *logic.AuthLogic
+	authLogic      *logic.AuthLogic
+	twoFactorLogic *logic.TwoFactorLogic
+	apiKeyLogic    *logic.APIKeyLogic
+	activityLogic  *logic.ActivityLogic
+	deviceLogic    *logic.DeviceLogic
+	sessionLogic   *logic.SessionLogic
+	privacyLogic   *logic.PrivacyLogic
 }
 
 // NewAuthService creates a new auth service
-func NewAuthService(authLogic *logic.AuthLogic) AuthService {
+func NewAuthService(authLogic *logic.AuthLogic, twoFactorLogic *logic.TwoFactorLogic, apiKeyLogic *logic.APIKeyLogic, activityLogic *logic.ActivityLogic, deviceLogic *logic.DeviceLogic, sessionLogic *logic.SessionLogic, privacyLogic *logic.PrivacyLogic) AuthService {
 	return &authService{
-		authLogic: authLogic,
+		authLogic:      authLogic,
+		twoFactorLogic: twoFactorLogic,
+		apiKeyLogic:    apiKeyLogic,
+		activityLogic:  activityLogic,
+		deviceLogic:    deviceLogic,
+		sessionLogic:   sessionLogic,
+		privacyLogic:   privacyLogic,
 	}
 }
 
@@ -52,11 +130,61 @@ func (s *authService) RegisterUser(ctx context.Context, req model.RegisterReques
 	return s.authLogic.RegisterUser(ctx, req)
 }
 
-// LoginUser authenticates a user
-func (s *authService) LoginUser(ctx context.Context, req model.LoginRequest) (*entity.User, error) {
+// LoginUser authenticates a user and issues tokens
+func (s *authService) LoginUser(ctx context.Context, req model.LoginRequest) (*model.LoginResult, error) {
 	return s.authLogic.LoginUser(ctx, req)
 }
 
+// RequestLoginOTP sends passwordless login code
+func (s *authService) RequestLoginOTP(ctx context.Context, req model.OTPRequest) (*model.OTPResponse, error) {
+	return s.authLogic.RequestLoginOTP(ctx, req)
+}
+
+// LoginWithOTP authenticates user with one-time passcode
+func (s *authService) LoginWithOTP(ctx context.Context, req model.OTPLoginRequest) (*model.LoginResult, error) {
+	return s.authLogic.LoginWithOTP(ctx, req)
+}
+
+// RequestPhoneVerification sends verification code to phone of user
+func (s *authService) RequestPhoneVerification(ctx context.Context, userID uint) (*model.OTPResponse, error) {
+	return s.authLogic.RequestPhoneVerification(ctx, userID)
+}
+
+// VerifyPhone verifies phone of user with code
+func (s *authService) VerifyPhone(ctx context.Context, userID uint, req model.PhoneVerificationRequest) error {
+	return s.authLogic.VerifyPhone(ctx, userID, req)
+}
+
+// OAuthAuthorize returns social login authorize URL
+func (s *authService) OAuthAuthorize(ctx context.Context, provider string) (*model.OAuthAuthorization, error) {
+	return s.authLogic.OAuthAuthorize(ctx, provider)
+}
+
+// LoginWithOAuth authenticates user with social provider callback
+func (s *authService) LoginWithOAuth(ctx context.Context, req model.OAuthCallbackRequest) (*model.LoginResult, error) {
+	return s.authLogic.LoginWithOAuth(ctx, req)
+}
+
+// VerifyTwoFactorLogin completes login with second factor
+func (s *authService) VerifyTwoFactorLogin(ctx context.Context, req model.TwoFactorVerifyRequest) (*model.LoginResult, error) {
+	return s.authLogic.VerifyTwoFactorLogin(ctx, req)
+}
+
+// RefreshToken rotates refresh token and issues a new token pair
+func (s *authService) RefreshToken(ctx context.Context, req model.RefreshTokenRequest) (*model.TokenPair, error) {
+	return s.authLogic.RefreshToken(ctx, req)
+}
+
+// Logout revokes current session
+func (s *authService) Logout(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error {
+	return s.authLogic.Logout(ctx, claims, req)
+}
+
+// LogoutAll revokes all sessions of current user
+func (s *authService) LogoutAll(ctx context.Context, claims *model.TokenClaims, req model.LogoutRequest) error {
+	return s.authLogic.LogoutAll(ctx, claims, req)
+}
+
 // GetUserProfile gets user profile with roles and permissions
 func (s *authService) GetUserProfile(ctx context.Context, userID uint) (*entity.User, error) {
 	return s.authLogic.GetUserProfile(ctx, userID)
@@ -67,6 +195,21 @@ func (s *authService) UpdateUserProfile(ctx context.Context, userID uint, req mo
 	return s.authLogic.UpdateUserProfile(ctx, userID, req)
 }
 
+// RequestEmailChange sends email change confirmation to new address
+func (s *authService) RequestEmailChange(ctx context.Context, userID uint, req model.ChangeEmailRequest) error {
+	return s.authLogic.RequestEmailChange(ctx, userID, req)
+}
+
+// RequestReauthCode sends code confirming sensitive actions to email of user
+func (s *authService) RequestReauthCode(ctx context.Context, userID uint) (*model.OTPResponse, error) {
+	return s.authLogic.RequestReauthCode(ctx, userID)
+}
+
+// ConfirmEmailChange changes user email after confirmation
+func (s *authService) ConfirmEmailChange(ctx context.Context, req model.ConfirmEmailChangeRequest) error {
+	return s.authLogic.ConfirmEmailChange(ctx, req)
+}
+
 // ChangePassword changes user password
 func (s *authService) ChangePassword(ctx context.Context, userID uint, req model.ChangePasswordRequest) error {
 	return s.authLogic.ChangePassword(ctx, userID, req)
@@ -86,3 +229,98 @@ func (s *authService) ResetPassword(ctx context.Context, req model.ResetPassword
 func (s *authService) VerifyEmail(ctx context.Context, token string) error {
 	return s.authLogic.VerifyEmail(ctx, token)
 }
+
+// ResendVerification sends new email verification link
+func (s *authService) ResendVerification(ctx context.Context, req model.ResendVerificationRequest) (*model.ResendVerificationResponse, error) {
+	return s.authLogic.ResendVerification(ctx, req)
+}
+
+// PreviewLoginReport shows login of report token without using it
+func (s *authService) PreviewLoginReport(ctx context.Context, token string) (*model.DeviceReportPreview, error) {
+	return s.deviceLogic.PreviewReport(ctx, token)
+}
+
+// ReportLogin locks account after login from new device is reported
+func (s *authService) ReportLogin(ctx context.Context, req model.DeviceReportRequest) error {
+	return s.deviceLogic.ReportLogin(ctx, req)
+}
+
+// EnrollTwoFactor starts TOTP enrollment
+func (s *authService) EnrollTwoFactor(ctx context.Context, userID uint) (*model.TwoFactorEnrollResponse, error) {
+	return s.twoFactorLogic.Enroll(ctx, userID)
+}
+
+// ConfirmTwoFactor confirms TOTP enrollment and returns recovery codes
+func (s *authService) ConfirmTwoFactor(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	return s.twoFactorLogic.Confirm(ctx, userID, req)
+}
+
+// DisableTwoFactor disables two-factor authentication
+func (s *authService) DisableTwoFactor(ctx context.Context, userID uint, req model.TwoFactorDisableRequest) error {
+	return s.twoFactorLogic.Disable(ctx, userID, req)
+}
+
+// RegenerateRecoveryCodes replaces recovery codes
+func (s *authService) RegenerateRecoveryCodes(ctx context.Context, userID uint, req model.TwoFactorCodeRequest) (*model.RecoveryCodesResponse, error) {
+	return s.twoFactorLogic.RegenerateRecoveryCodes(ctx, userID, req)
+}
+
+// CreateAPIKey issues new API key
+func (s *authService) CreateAPIKey(ctx context.Context, userID uint, req model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error) {
+	return s.apiKeyLogic.Create(ctx, userID, req)
+}
+
+// ListAPIKeys lists API keys of user
+func (s *authService) ListAPIKeys(ctx context.Context, userID uint) ([]*entity.APIKey, error) {
+	return s.apiKeyLogic.List(ctx, userID, "")
+}
+
+// RotateAPIKey replaces API key with a new secret
+func (s *authService) RotateAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) (*model.CreatedAPIKey, error) {
+	return s.apiKeyLogic.Rotate(ctx, userID, id, req)
+}
+
+// RevokeAPIKey revokes API key
+func (s *authService) RevokeAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) error {
+	return s.apiKeyLogic.Revoke(ctx, userID, id, req)
+}
+
+// VerifyAPIKey verifies API key for gateway and internal services
+func (s *authService) VerifyAPIKey(ctx context.Context, req model.VerifyAPIKeyRequest) (*model.VerifyAPIKeyResponse, error) {
+	return s.apiKeyLogic.Verify(ctx, req)
+}
+
+// ListSessions lists active sessions of current user
+func (s *authService) ListSessions(ctx context.Context, claims *model.TokenClaims) ([]*model.Session, error) {
+	return s.sessionLogic.List(ctx, claims)
+}
+
+// RevokeSession revokes session of current user
+func (s *authService) RevokeSession(ctx context.Context, userID uint, sessionID string, req model.SessionActionRequest) error {
+	return s.sessionLogic.Revoke(ctx, userID, sessionID, req)
+}
+
+// RequestDataExport emails link to personal data export of user
+func (s *authService) RequestDataExport(ctx context.Context, userID uint, req model.DataExportRequest) error {
+	return s.privacyLogic.RequestExport(ctx, userID, req)
+}
+
+// DownloadDataExport returns data export archive of download token requested by user
+func (s *authService) DownloadDataExport(ctx context.Context, userID uint, token string) ([]byte, error) {
+	return s.privacyLogic.DownloadExport(ctx, userID, token)
+}
+
+// RequestAccountDeletion schedules deletion of user account
+func (s *authService) RequestAccountDeletion(ctx context.Context, userID uint, req model.AccountDeletionRequest) (*model.AccountDeletionResponse, error) {
+	return s.privacyLogic.RequestDeletion(ctx, userID, req)
+}
+
+// CancelAccountDeletion cancels scheduled deletion of user account
+func (s *authService) CancelAccountDeletion(ctx context.Context, userID uint, req model.CancelDeletionRequest) error {
+	return s.privacyLogic.CancelDeletion(ctx, userID, req)
+}
+
+// ListMyActivity lists activity of current user
+func (s *authService) ListMyActivity(ctx context.Context, userID uint, filter model.ActivityFilter) (*model.ActivityPage, error) {
+	return s.activityLogic.ListUserActivity(ctx, userID, filter)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/taititans/bitzap/auth-svc/internal/config"
@@ -22,6 +23,30 @@ type EmailService interface {
 	// Send welcome email
 	SendWelcomeEmail(ctx context.Context, email, name string) error
 
+	// Send login one-time passcode
+	SendLoginOTP(ctx context.Context, email, name, code string, expireMinute int) error
+
+	// Send login from new device alert
+	SendNewDeviceAlert(ctx context.Context, email, name string, alert model.NewDeviceAlert) error
+
+	// Send email change confirmation to new address
+	SendEmailChangeConfirmation(ctx context.Context, req model.EmailChangeConfirmation) error
+
+	// Send email change notice to current address
+	SendEmailChangeNotice(ctx context.Context, email, name, newEmail string) error
+
+	// Verify email change token
+	VerifyEmailChangeToken(ctx context.Context, token string) (*model.EmailChangeConfirmation, error)
+
+	// Send data export download link
+	SendDataExportReady(ctx context.Context, email, name, token string, expireHour int) error
+
+	// Send account deletion scheduled notice
+	SendAccountDeletionScheduled(ctx context.Context, email, name string, dueAt time.Time) error
+
+	// Send tenant invitation
+	SendTenantInvitation(ctx context.Context, req model.TenantInvitationEmail) error
+
 	// Send generic email
 	SendEmail(ctx context.Context, data model.EmailData) error
 
@@ -70,6 +95,46 @@ func (s *emailService) SendWelcomeEmail(ctx context.Context, email, name string)
 	return s.emailLogic.SendWelcomeEmail(ctx, email, name)
 }
 
+// SendLoginOTP sends login one-time passcode email
+func (s *emailService) SendLoginOTP(ctx context.Context, email, name, code string, expireMinute int) error {
+	return s.emailLogic.SendLoginOTP(ctx, email, name, code, expireMinute)
+}
+
+// SendNewDeviceAlert sends login from new device alert email
+func (s *emailService) SendNewDeviceAlert(ctx context.Context, email, name string, alert model.NewDeviceAlert) error {
+	return s.emailLogic.SendNewDeviceAlert(ctx, email, name, alert)
+}
+
+// SendEmailChangeConfirmation sends email change confirmation to new address
+func (s *emailService) SendEmailChangeConfirmation(ctx context.Context, req model.EmailChangeConfirmation) error {
+	return s.emailLogic.SendEmailChangeConfirmation(ctx, req)
+}
+
+// SendEmailChangeNotice sends email change notice to current address
+func (s *emailService) SendEmailChangeNotice(ctx context.Context, email, name, newEmail string) error {
+	return s.emailLogic.SendEmailChangeNotice(ctx, email, name, newEmail)
+}
+
+// VerifyEmailChangeToken verifies email change token
+func (s *emailService) VerifyEmailChangeToken(ctx context.Context, token string) (*model.EmailChangeConfirmation, error) {
+	return s.emailLogic.VerifyEmailChangeToken(ctx, token)
+}
+
+// SendDataExportReady sends data export download link email
+func (s *emailService) SendDataExportReady(ctx context.Context, email, name, token string, expireHour int) error {
+	return s.emailLogic.SendDataExportReady(ctx, email, name, token, expireHour)
+}
+
+// SendAccountDeletionScheduled sends account deletion scheduled email
+func (s *emailService) SendAccountDeletionScheduled(ctx context.Context, email, name string, dueAt time.Time) error {
+	return s.emailLogic.SendAccountDeletionScheduled(ctx, email, name, dueAt)
+}
+
+// SendTenantInvitation sends tenant invitation email
+func (s *emailService) SendTenantInvitation(ctx context.Context, req model.TenantInvitationEmail) error {
+	return s.emailLogic.SendTenantInvitation(ctx, req)
+}
+
 // SendEmail sends generic email using Mailjet
 func (s *emailService) SendEmail(ctx context.Context, data model.EmailData) error {
 	return s.emailLogic.SendEmail(ctx, data)
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+
+	"github.com/taititans/bitzap/auth-svc/internal/domain/entity"
+	"github.com/taititans/bitzap/auth-svc/internal/logic"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// TenantService defines the interface for tenant service
+type TenantService interface {
+	// Create tenant owned by user
+	CreateTenant(ctx context.Context, req model.CreateTenantRequest) (*entity.Tenant, error)
+
+	// List tenants of user
+	ListMyTenants(ctx context.Context, userID uint) ([]*model.TenantMembership, error)
+
+	// Get tenant of user
+	GetTenant(ctx context.Context, userID uint, tenantID string) (*model.TenantMembership, error)
+
+	// List members of tenant
+	ListMembers(ctx context.Context, userID uint, tenantID string) ([]*model.TenantMember, error)
+
+	// Change role of tenant member
+	UpdateMemberRole(ctx context.Context, tenantID string, userID uint, req model.TenantMemberRoleRequest) error
+
+	// Remove member from tenant or leave it
+	RemoveMember(ctx context.Context, tenantID string, userID uint, req model.TenantActionRequest) error
+
+	// Switch active tenant and reissue tokens
+	SwitchTenant(ctx context.Context, claims *model.TokenClaims, tenantID string, client model.SessionClient) (*model.TokenPair, error)
+
+	// Invite email to tenant
+	CreateInvitation(ctx context.Context, tenantID string, req model.CreateInvitationRequest) (*model.TenantInvitation, error)
+
+	// List invitations of tenant
+	ListInvitations(ctx context.Context, userID uint, tenantID string) ([]*model.TenantInvitation, error)
+
+	// Revoke pending invitation
+	RevokeInvitation(ctx context.Context, tenantID string, invitationID uint, req model.TenantActionRequest) error
+
+	// Preview invitation of token
+	PreviewInvitation(ctx context.Context, token string) (*model.InvitationPreview, error)
+
+	// Accept invitation and join tenant
+	AcceptInvitation(ctx context.Context, req model.InvitationTokenRequest) (*model.TenantMembership, error)
+
+	// Decline invitation
+	DeclineInvitation(ctx context.Context, req model.InvitationTokenRequest) error
+
+	// Issue API key of tenant
+	CreateAPIKey(ctx context.Context, userID uint, req model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error)
+
+	// List API keys of tenant
+	ListAPIKeys(ctx context.Context, userID uint, tenantID string) ([]*entity.APIKey, error)
+
+	// Replace API key of tenant with a new secret
+	RotateAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) (*model.CreatedAPIKey, error)
+
+	// Revoke API key of tenant
+	RevokeAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) error
+}
+
+// tenantService implements TenantService interface
+type tenantService struct {
+	tenantLogic     *logic.TenantLogic
+	invitationLogic *logic.InvitationLogic
+	apiKeyLogic     *logic.APIKeyLogic
+}
+
+// NewTenantService creates a new tenant service
+func NewTenantService(tenantLogic *logic.TenantLogic, invitationLogic *logic.InvitationLogic, apiKeyLogic *logic.APIKeyLogic) TenantService {
+	return &tenantService{
+		tenantLogic:     tenantLogic,
+		invitationLogic: invitationLogic,
+		apiKeyLogic:     apiKeyLogic,
+	}
+}
+
+// CreateTenant creates tenant owned by user
+func (s *tenantService) CreateTenant(ctx context.Context, req model.CreateTenantRequest) (*entity.Tenant, error) {
+	return s.tenantLogic.Create(ctx, req)
+}
+
+// ListMyTenants lists tenants of user
+func (s *tenantService) ListMyTenants(ctx context.Context, userID uint) ([]*model.TenantMembership, error) {
+	return s.tenantLogic.ListMine(ctx, userID)
+}
+
+// GetTenant gets tenant of user
+func (s *tenantService) GetTenant(ctx context.Context, userID uint, tenantID string) (*model.TenantMembership, error) {
+	return s.tenantLogic.Get(ctx, userID, tenantID)
+}
+
+// ListMembers lists members of tenant
+func (s *tenantService) ListMembers(ctx context.Context, userID uint, tenantID string) ([]*model.TenantMember, error) {
+	return s.tenantLogic.ListMembers(ctx, userID, tenantID)
+}
+
+// UpdateMemberRole changes role of tenant member
+func (s *tenantService) UpdateMemberRole(ctx context.Context, tenantID string, userID uint, req model.TenantMemberRoleRequest) error {
+	return s.tenantLogic.UpdateMemberRole(ctx, tenantID, userID, req)
+}
+
+// RemoveMember removes member from tenant
+func (s *tenantService) RemoveMember(ctx context.Context, tenantID string, userID uint, req model.TenantActionRequest) error {
+	return s.tenantLogic.RemoveMember(ctx, tenantID, userID, req)
+}
+
+// SwitchTenant switches active tenant and reissues tokens
+func (s *tenantService) SwitchTenant(ctx context.Context, claims *model.TokenClaims, tenantID string, client model.SessionClient) (*model.TokenPair, error) {
+	return s.tenantLogic.Switch(ctx, claims, tenantID, client)
+}
+
+// CreateInvitation invites email to tenant
+func (s *tenantService) CreateInvitation(ctx context.Context, tenantID string, req model.CreateInvitationRequest) (*model.TenantInvitation, error) {
+	return s.invitationLogic.Create(ctx, tenantID, req)
+}
+
+// ListInvitations lists invitations of tenant
+func (s *tenantService) ListInvitations(ctx context.Context, userID uint, tenantID string) ([]*model.TenantInvitation, error) {
+	return s.invitationLogic.List(ctx, userID, tenantID)
+}
+
+// RevokeInvitation revokes pending invitation
+func (s *tenantService) RevokeInvitation(ctx context.Context, tenantID string, invitationID uint, req model.TenantActionRequest) error {
+	return s.invitationLogic.Revoke(ctx, tenantID, invitationID, req)
+}
+
+// PreviewInvitation previews invitation of token
+func (s *tenantService) PreviewInvitation(ctx context.Context, token string) (*model.InvitationPreview, error) {
+	return s.invitationLogic.Preview(ctx, token)
+}
+
+// AcceptInvitation accepts invitation and joins tenant
+func (s *tenantService) AcceptInvitation(ctx context.Context, req model.InvitationTokenRequest) (*model.TenantMembership, error) {
+	return s.invitationLogic.Accept(ctx, req)
+}
+
+// DeclineInvitation declines invitation
+func (s *tenantService) DeclineInvitation(ctx context.Context, req model.InvitationTokenRequest) error {
+	return s.invitationLogic.Decline(ctx, req)
+}
+
+// CreateAPIKey issues new API key of tenant
+func (s *tenantService) CreateAPIKey(ctx context.Context, userID uint, req model.CreateAPIKeyRequest) (*model.CreatedAPIKey, error) {
+	return s.apiKeyLogic.Create(ctx, userID, req)
+}
+
+// ListAPIKeys lists API keys of tenant
+func (s *tenantService) ListAPIKeys(ctx context.Context, userID uint, tenantID string) ([]*entity.APIKey, error) {
+	return s.apiKeyLogic.List(ctx, userID, tenantID)
+}
+
+// RotateAPIKey replaces API key of tenant with a new secret
+func (s *tenantService) RotateAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) (*model.CreatedAPIKey, error) {
+	return s.apiKeyLogic.Rotate(ctx, userID, id, req)
+}
+
+// RevokeAPIKey revokes API key of tenant
+func (s *tenantService) RevokeAPIKey(ctx context.Context, userID, id uint, req model.APIKeyActionRequest) error {
+	return s.apiKeyLogic.Revoke(ctx, userID, id, req)
+}
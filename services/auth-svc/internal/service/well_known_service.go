@@ -0,0 +1,45 @@
+package service
+
+import (
+	"github.com/taititans/bitzap/auth-svc/internal/logic"
+	"github.com/taititans/bitzap/auth-svc/internal/model"
+)
+
+// WellKnownService defines the interface for public key discovery service
+type WellKnownService interface {
+	// Get public signing keys
+	JWKS() (*model.JWKS, error)
+
+	// Get OpenID discovery metadata
+	OpenIDConfiguration() *model.OpenIDConfiguration
+
+	// Get JWKS cache lifetime in seconds
+	JWKSCacheSecond() int
+}
+
+// wellKnownService implements WellKnownService interface
+type wellKnownService struct {
+	signingKeyLogic *logic.SigningKeyLogic
+}
+
+// NewWellKnownService creates a new well-known service
+func NewWellKnownService(signingKeyLogic *logic.SigningKeyLogic) WellKnownService {
+	return &wellKnownService{
+		signingKeyLogic: signingKeyLogic,
+	}
+}
+
+// JWKS returns public signing keys
+func (s *wellKnownService) JWKS() (*model.JWKS, error) {
+	return s.signingKeyLogic.JWKS()
+}
+
+// OpenIDConfiguration returns OpenID discovery metadata
+func (s *wellKnownService) OpenIDConfiguration() *model.OpenIDConfiguration {
+	return s.signingKeyLogic.OpenIDConfiguration()
+}
+
+// JWKSCacheSecond returns JWKS cache lifetime in seconds
+func (s *wellKnownService) JWKSCacheSecond() int {
+	return s.signingKeyLogic.JWKSCacheSecond()
+}
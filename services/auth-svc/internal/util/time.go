@@ -0,0 +1,18 @@
+package util
+
+import "time"
+
+// ParseTimeParam parses RFC3339 or YYYY-MM-DD request param, empty value returns nil
+func ParseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is base32 of the SHA1 seed "12345678901234567890" from RFC 6238 appendix B
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+
+	for _, tt := range tests {
+		step := TOTPStep(time.Unix(tt.unix, 0), 30)
+		got, err := GenerateTOTPCode(rfc6238Secret, step, 8)
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode(%d) error = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("GenerateTOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	// Lowercase secrets typed by hand are accepted
+	if got, _ := GenerateTOTPCode("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 1, 8); got != "94287082" {
+		t.Errorf("GenerateTOTPCode() of lowercase secret = %s, want 94287082", got)
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := TOTPStep(now, 30)
+	codeAt := func(step int64, digits int) string {
+		code, err := GenerateTOTPCode(rfc6238Secret, step, digits)
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode() error = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: codeAt(current, 6), skew: 1, wantStep: current, wantOK: true},
+		{name: "previous step within skew", code: codeAt(current-1, 6), skew: 1, wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", code: codeAt(current+1, 6), skew: 1, wantStep: current + 1, wantOK: true},
+		{name: "previous step without skew", code: codeAt(current-1, 6), skew: 0},
+		{name: "outside skew", code: codeAt(current-2, 6), skew: 1},
+		{name: "wrong length", code: codeAt(current, 8), skew: 1},
+		{name: "not numeric", code: "12345a", skew: 1},
+		{name: "empty", code: "", skew: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTPCode(rfc6238Secret, tt.code, now, 30, 6, tt.skew)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTPCode(%q) = %d, %v, want %d, %v", tt.code, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package util
+
+import "strings"
+
+// DeviceName derives human readable device name like "Chrome on Windows" from user agent
+func DeviceName(userAgent string) string {
+	browser := userAgentBrowser(userAgent)
+	system := userAgentOS(userAgent)
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
+
+// userAgentBrowser detects browser of user agent, order matters since
+// most browsers also identify as Chrome or Safari
+func userAgentBrowser(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "Edg/"), strings.Contains(userAgent, "EdgA/"), strings.Contains(userAgent, "EdgiOS/"):
+		return "Edge"
+	case strings.Contains(userAgent, "OPR/"), strings.Contains(userAgent, "Opera"):
+		return "Opera"
+	case strings.Contains(userAgent, "Firefox/"), strings.Contains(userAgent, "FxiOS/"):
+		return "Firefox"
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		return "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		return "Safari"
+	case strings.HasPrefix(userAgent, "curl/"):
+		return "curl"
+	case strings.HasPrefix(userAgent, "PostmanRuntime/"):
+		return "Postman"
+	default:
+		return ""
+	}
+}
+
+// userAgentOS detects operating system of user agent
+func userAgentOS(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "Windows"):
+		return "Windows"
+	case strings.Contains(userAgent, "Android"):
+		return "Android"
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		return "iOS"
+	case strings.Contains(userAgent, "Mac OS X"), strings.Contains(userAgent, "Macintosh"):
+		return "macOS"
+	case strings.Contains(userAgent, "CrOS"):
+		return "ChromeOS"
+	case strings.Contains(userAgent, "Linux"):
+		return "Linux"
+	default:
+		return ""
+	}
+}
@@ -7,11 +7,16 @@ CREATE TABLE users (
     firstname VARCHAR(255),
     lastname VARCHAR(255),
     phone VARCHAR(255),
+    phone_verified_at TIMESTAMP,
     avatar_url VARCHAR(255),
     is_active BOOLEAN DEFAULT false,
     is_verified BOOLEAN DEFAULT false,
     email_verified_at TIMESTAMP,
     last_login_at TIMESTAMP,
+    two_factor_enabled BOOLEAN DEFAULT false,
+    two_factor_secret VARCHAR(64),
+    two_factor_enabled_at TIMESTAMP,
+    deletion_due_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -19,6 +24,10 @@ CREATE TABLE users (
 -- Create indexes for users table
 CREATE INDEX idx_users_email ON users(email);
 CREATE INDEX idx_users_username ON users(username);
+CREATE UNIQUE INDEX idx_users_phone_verified ON users(phone) WHERE phone_verified_at IS NOT NULL;
+CREATE UNIQUE INDEX idx_users_username_lower ON users(LOWER(username));
+CREATE UNIQUE INDEX idx_users_email_lower ON users(LOWER(email));
+CREATE INDEX idx_users_deletion_due_at ON users(deletion_due_at) WHERE deletion_due_at IS NOT NULL;
 
 -- Create user_roles table
 CREATE TABLE user_roles (
@@ -32,6 +41,7 @@ CREATE TABLE user_roles (
 -- Create indexes for user_roles table
 CREATE INDEX idx_user_roles_user_id ON user_roles(user_id);
 CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+CREATE UNIQUE INDEX idx_user_roles_user_role ON user_roles(user_id, role_id);
 
 -- Create user_permissions table
 CREATE TABLE user_permissions (
@@ -48,9 +58,11 @@ CREATE INDEX idx_user_permissions_user_id ON user_permissions(user_id);
 CREATE INDEX idx_user_permissions_deleted_at ON user_permissions(deleted_at);
 
 -- Create user_activity_logs table
+-- Logs of purged accounts keep no user_id, only random pseudonym shared by logs of that account
 CREATE TABLE user_activity_logs (
     id SERIAL PRIMARY KEY,
-    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    pseudonym VARCHAR(64),
     action VARCHAR(255) NOT NULL,
     resource VARCHAR(255) NOT NULL,
     ip_address VARCHAR(45),
@@ -65,6 +77,127 @@ CREATE INDEX idx_user_activity_logs_user_id ON user_activity_logs(user_id);
 CREATE INDEX idx_user_activity_logs_deleted_at ON user_activity_logs(deleted_at);
 CREATE INDEX idx_user_activity_logs_action ON user_activity_logs(action);
 CREATE INDEX idx_user_activity_logs_created_at ON user_activity_logs(created_at);
+CREATE INDEX idx_user_activity_logs_user_id_id ON user_activity_logs(user_id, id DESC);
+CREATE INDEX idx_user_activity_logs_ip_address ON user_activity_logs(ip_address);
+CREATE INDEX idx_user_activity_logs_pseudonym ON user_activity_logs(pseudonym);
+
+-- Create user_recovery_codes table
+CREATE TABLE user_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for user_recovery_codes table
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes(user_id);
+
+-- Create user_identities table (OAuth2 / OIDC accounts linked to user)
+CREATE TABLE user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+-- Create indexes for user_identities table
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- Create api_keys table (hashed keys for programmatic access)
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) UNIQUE NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]',
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- Create indexes for api_keys table
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+CREATE INDEX idx_api_keys_deleted_at ON api_keys(deleted_at);
+
+-- Create user_devices table, known login devices of user
+CREATE TABLE user_devices (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL,
+    user_agent TEXT,
+    ip_address VARCHAR(45),
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for user_devices table
+CREATE UNIQUE INDEX idx_user_devices_fingerprint ON user_devices(user_id, fingerprint);
+
+-- Create tenants table, organizations users belong to with per-tenant roles
+CREATE TABLE tenants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(63) UNIQUE NOT NULL,
+    plan VARCHAR(50) DEFAULT 'free',
+    custom_domain VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Requests to custom domain of tenant are resolved to it
+CREATE INDEX idx_tenants_custom_domain ON tenants(LOWER(custom_domain));
+
+-- Create tenant_members table, role is one of owner, admin, member, viewer
+CREATE TABLE tenant_members (
+    id SERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for tenant_members table
+CREATE UNIQUE INDEX idx_tenant_members_user ON tenant_members(tenant_id, user_id);
+CREATE INDEX idx_tenant_members_user_id ON tenant_members(user_id);
+
+-- Create tenant_invitations table, single-use email invitations, only token hash is stored
+CREATE TABLE tenant_invitations (
+    id SERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    declined_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for tenant_invitations table
+CREATE INDEX idx_tenant_invitations_tenant_id ON tenant_invitations(tenant_id);
+CREATE INDEX idx_tenant_invitations_email ON tenant_invitations(tenant_id, email);
+
+-- Active tenant of user, included in issued tokens
+ALTER TABLE users ADD COLUMN active_tenant_id UUID REFERENCES tenants(id) ON DELETE SET NULL;
+
+-- Tenant owning API key, keys without tenant belong to user
+ALTER TABLE api_keys ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+CREATE INDEX idx_api_keys_tenant_id ON api_keys(tenant_id);
 
 -- Optional: Create roles table (referenced by user_roles.role_id)
 CREATE TABLE roles (
@@ -81,6 +214,36 @@ INSERT INTO roles (name, description) VALUES
 ('user', 'Regular user with limited access'),
 ('moderator', 'Moderator with intermediate access');
 
+-- Create role_permissions table, "*" as resource or action matches any value
+CREATE TABLE role_permissions (
+    id SERIAL PRIMARY KEY,
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    resource VARCHAR(255) NOT NULL,
+    action VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for role_permissions table
+CREATE UNIQUE INDEX idx_role_permissions_unique ON role_permissions(role_id, resource, action);
+
+-- Insert default role permissions
+INSERT INTO role_permissions (role_id, resource, action)
+SELECT roles.id, p.resource, p.action FROM roles
+JOIN (VALUES
+    ('admin', '*', '*'),
+    ('moderator', 'user', 'read'),
+    ('moderator', 'activity', 'read'),
+    ('moderator', 'url', 'read'),
+    ('moderator', 'url', 'update'),
+    ('moderator', 'url', 'delete'),
+    ('moderator', 'analytics', 'read'),
+    ('user', 'url', 'create'),
+    ('user', 'url', 'read'),
+    ('user', 'url', 'update'),
+    ('user', 'url', 'delete'),
+    ('user', 'analytics', 'read')
+) AS p(role, resource, action) ON p.role = roles.name;
+
 -- Add foreign key constraint for user_roles.role_id
 ALTER TABLE user_roles ADD CONSTRAINT fk_user_roles_role_id 
     FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE;
\ No newline at end of file